@@ -0,0 +1,380 @@
+// End-to-end tests against a real BIND9 server and REST API
+//
+// The tests run only with TF_ACC set. TestMain starts BIND9 and the REST
+// API of docker-compose.yml with the docker CLI found in PATH, and removes
+// them once the tests end; with BIND9_ENDPOINT set, the tests use that
+// server instead, with BIND9_API_KEY and BIND9_E2E_DNS. They build the
+// provider, apply configurations with the terraform CLI found in PATH, and
+// check the results with DNS queries to the server.
+
+package e2e
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	mathrand "math/rand"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// Defaults matching docker-compose.yml
+const (
+	defaultEndpoint = "http://127.0.0.1:8080"
+	defaultDNS      = "127.0.0.1:15353"
+)
+
+// apiStartTimeout is how long TestMain waits for the API to answer once its
+// container runs
+const apiStartTimeout = 2 * time.Minute
+
+// providerDir holds the provider binary built by TestMain
+var providerDir string
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+// run builds the provider and starts the containers of the tests unless
+// BIND9_ENDPOINT names a server, runs the tests, and removes what it set up
+func run(m *testing.M) int {
+	if os.Getenv("TF_ACC") == "" {
+		return m.Run()
+	}
+
+	dir, err := os.MkdirTemp("", "bind9-e2e-provider")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+	binary := filepath.Join(dir, "terraform-provider-bind9")
+	if runtime.GOOS == "windows" {
+		binary += ".exe"
+	}
+	build := exec.Command("go", "build", "-o", binary, "..")
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "building the provider:", err)
+		return 1
+	}
+	providerDir = dir
+
+	if os.Getenv("BIND9_ENDPOINT") == "" {
+		env, err := startEnvironment()
+		if env != nil {
+			defer env.stop()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "starting BIND9 and the REST API:", err)
+			return 1
+		}
+	}
+
+	return m.Run()
+}
+
+// environment is a docker compose project running docker-compose.yml
+type environment struct {
+	project string
+	env     []string
+}
+
+// startEnvironment starts BIND9 and the REST API with a random API key,
+// which it sets as BIND9_API_KEY for the provider, and waits for the API to
+// answer. The returned environment is to be stopped even on error.
+func startEnvironment() (*environment, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, errors.New("the docker CLI is not in PATH; set BIND9_ENDPOINT to test against a running server")
+	}
+
+	secret := make([]byte, 16)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	key := hex.EncodeToString(secret)
+	e := &environment{
+		project: "bind9-provider-e2e-" + key[:8],
+		env:     append(os.Environ(), "BIND9_E2E_API_KEY="+key),
+	}
+	if err := e.compose("up", "-d", "--build", "--wait"); err != nil {
+		return e, err
+	}
+	os.Setenv("BIND9_API_KEY", key)
+
+	endpoint := envOr("BIND9_ENDPOINT", defaultEndpoint)
+	deadline := time.Now().Add(apiStartTimeout)
+	for {
+		req, err := http.NewRequest("GET", endpoint+"/api/v1/version", nil)
+		if err != nil {
+			return e, err
+		}
+		req.Header.Set("X-API-Key", key)
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return e, nil
+			}
+			err = fmt.Errorf("GET /api/v1/version: %s", resp.Status)
+		}
+		if time.Now().After(deadline) {
+			return e, fmt.Errorf("the API at %s did not answer within %s: %w", endpoint, apiStartTimeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// stop removes the containers and volumes of the environment
+func (e *environment) stop() {
+	if err := e.compose("down", "-v"); err != nil {
+		fmt.Fprintln(os.Stderr, "stopping BIND9 and the REST API:", err)
+	}
+}
+
+// compose runs a docker compose command on the project of the environment
+func (e *environment) compose(args ...string) error {
+	cmd := exec.Command("docker", append([]string{"compose", "-f", "docker-compose.yml", "-p", e.project}, args...)...)
+	cmd.Env = e.env
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// harness applies configurations in a working directory of its own, using
+// the provider built by TestMain
+type harness struct {
+	t   *testing.T
+	dir string
+	env []string
+}
+
+// newHarness skips the test unless TF_ACC is set, and destroys whatever the
+// test applied once it ends
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("end-to-end tests run only with TF_ACC set")
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Fatal("end-to-end tests need the terraform CLI in PATH")
+	}
+
+	dir := t.TempDir()
+	cliConfig := filepath.Join(dir, "terraformrc")
+	err := os.WriteFile(cliConfig, []byte(fmt.Sprintf(`provider_installation {
+  dev_overrides {
+    "harutyundermenjyan/bind9" = %q
+  }
+  direct {}
+}
+`, filepath.ToSlash(providerDir))), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	h := &harness{
+		t:   t,
+		dir: dir,
+		env: append(os.Environ(),
+			"TF_CLI_CONFIG_FILE="+cliConfig,
+			"TF_IN_AUTOMATION=1",
+			"BIND9_ENDPOINT="+envOr("BIND9_ENDPOINT", defaultEndpoint),
+		),
+	}
+	t.Cleanup(func() {
+		if _, err := os.Stat(filepath.Join(dir, "terraform.tfstate")); err != nil {
+			return
+		}
+		if out, err := h.terraform("destroy", "-auto-approve"); err != nil {
+			t.Errorf("terraform destroy: %s\n%s", err, out)
+		}
+	})
+	return h
+}
+
+// terraform runs a terraform command in the working directory
+func (h *harness) terraform(args ...string) (string, error) {
+	args = append(args, "-input=false", "-no-color")
+	cmd := exec.Command("terraform", args...)
+	cmd.Dir = h.dir
+	cmd.Env = h.env
+	var out bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &out
+	err := cmd.Run()
+	return out.String(), err
+}
+
+// apply applies a configuration, then checks that planning it again finds
+// nothing to change, as a refresh must read back exactly what was applied
+func (h *harness) apply(config string) {
+	h.t.Helper()
+	err := os.WriteFile(filepath.Join(h.dir, "main.tf"), []byte("provider \"bind9\" {}\n\n"+config), 0o600)
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	if out, err := h.terraform("apply", "-auto-approve"); err != nil {
+		h.t.Fatalf("terraform apply: %s\n%s", err, out)
+	}
+
+	out, err := h.terraform("plan", "-detailed-exitcode")
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 2:
+		h.t.Fatalf("plan after apply is not empty:\n%s", out)
+	case err != nil:
+		h.t.Fatalf("terraform plan: %s\n%s", err, out)
+	}
+}
+
+// testZone returns a zone name no other test run uses
+func testZone(prefix string) string {
+	return fmt.Sprintf("%s-%d.e2e.test", prefix, mathrand.Intn(1e6))
+}
+
+// query asks the server for the records of a name and type, waiting up to
+// timeout for at least one to appear
+func query(t *testing.T, name string, rrtype uint16, timeout time.Duration) []dns.RR {
+	t.Helper()
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), rrtype)
+	m.SetEdns0(4096, true)
+	client := &dns.Client{Net: "tcp", Timeout: 5 * time.Second}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, _, err := client.Exchange(m, envOr("BIND9_E2E_DNS", defaultDNS))
+		if err == nil && resp.Rcode == dns.RcodeSuccess {
+			var answers []dns.RR
+			for _, rr := range resp.Answer {
+				if rr.Header().Rrtype == rrtype {
+					answers = append(answers, rr)
+				}
+			}
+			if len(answers) > 0 {
+				return answers
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no %s record for %s after %s (error: %v)", dns.TypeToString[rrtype], name, timeout, err)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// serial returns the serial of a zone
+func serial(t *testing.T, zone string) uint32 {
+	t.Helper()
+	return query(t, zone, dns.TypeSOA, 10*time.Second)[0].(*dns.SOA).Serial
+}
+
+// addresses returns the set of A record addresses of a name
+func addresses(t *testing.T, name string) map[string]bool {
+	t.Helper()
+	found := make(map[string]bool)
+	for _, rr := range query(t, name, dns.TypeA, 10*time.Second) {
+		found[rr.(*dns.A).A.String()] = true
+	}
+	return found
+}
+
+// envOr returns an environment variable, or fallback if it is unset
+func envOr(name, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// TestZoneAndRecordLifecycle creates a zone with a record, changes the record
+// and checks that the server answers with it and bumps the zone's serial
+func TestZoneAndRecordLifecycle(t *testing.T) {
+	h := newHarness(t)
+	zone := testZone("lifecycle")
+
+	config := `
+resource "bind9_zone" "test" {
+  name = %q
+  type = "master"
+}
+
+resource "bind9_record" "www" {
+  zone    = bind9_zone.test.name
+  name    = "www"
+  type    = "A"
+  ttl     = 300
+  records = [%s]
+}
+`
+	h.apply(fmt.Sprintf(config, zone, `"192.0.2.10"`))
+	if got := addresses(t, "www."+zone); !got["192.0.2.10"] || len(got) != 1 {
+		t.Fatalf("www.%s answers %v, want 192.0.2.10", zone, got)
+	}
+	before := serial(t, zone)
+
+	h.apply(fmt.Sprintf(config, zone, `"192.0.2.10", "192.0.2.11"`))
+	if got := addresses(t, "www."+zone); !got["192.0.2.10"] || !got["192.0.2.11"] || len(got) != 2 {
+		t.Fatalf("www.%s answers %v, want 192.0.2.10 and 192.0.2.11", zone, got)
+	}
+	if after := serial(t, zone); after <= before {
+		t.Fatalf("serial of %s is %d after the record change, want more than %d", zone, after, before)
+	}
+}
+
+// TestDynamicZoneJournal changes the records of a zone that accepts dynamic
+// updates, whose changes stay in the journal until the zone is frozen, and
+// checks that a refresh reads them back
+func TestDynamicZoneJournal(t *testing.T) {
+	h := newHarness(t)
+	zone := testZone("dynamic")
+
+	config := `
+resource "bind9_zone" "test" {
+  name         = %q
+  type         = "master"
+  allow_update = ["key ddns-key"]
+}
+
+resource "bind9_record" "txt" {
+  zone    = bind9_zone.test.name
+  name    = "info"
+  type    = "TXT"
+  records = [%q]
+}
+`
+	h.apply(fmt.Sprintf(config, zone, "first"))
+	h.apply(fmt.Sprintf(config, zone, "second"))
+
+	txt := query(t, "info."+zone, dns.TypeTXT, 10*time.Second)
+	if got := strings.Join(txt[0].(*dns.TXT).Txt, ""); len(txt) != 1 || got != "second" {
+		t.Fatalf("info.%s answers %v, want \"second\"", zone, txt)
+	}
+}
+
+// TestDNSSECSigning signs a zone with the default dnssec-policy and waits for
+// the server to publish its keys and signatures
+func TestDNSSECSigning(t *testing.T) {
+	h := newHarness(t)
+	zone := testZone("dnssec")
+
+	h.apply(fmt.Sprintf(`
+resource "bind9_zone" "test" {
+  name           = %q
+  type           = "master"
+  dnssec_policy  = "default"
+  inline_signing = true
+}
+`, zone))
+
+	query(t, zone, dns.TypeDNSKEY, time.Minute)
+	query(t, zone, dns.TypeRRSIG, time.Minute)
+}
@@ -114,7 +114,7 @@ resource "bind9_zone" "example" {
   allow_transfer = ["secondaries"]         # Only to secondary NS
   allow_update   = ["ddns-clients"]        # Only DDNS clients
   
-  notify = true
+  notify = "yes"
   
   # Delete zone file when zone is destroyed
   delete_file_on_destroy = false
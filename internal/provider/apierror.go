@@ -0,0 +1,98 @@
+// Structured API errors
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is an error response of the BIND9 REST API
+type APIError struct {
+	// HTTP status code of the response
+	StatusCode int
+	// Machine-readable error code, if the server gives one
+	Code string
+	// Error message, or the response body if it is not a JSON error
+	Message string
+	// Further details, such as the fields that failed validation
+	Detail string
+}
+
+// Error formats the error as "API error <status>: <message>"
+func (e *APIError) Error() string {
+	msg := e.Message
+	if e.Detail != "" {
+		if msg != "" {
+			msg += ": "
+		}
+		msg += e.Detail
+	}
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Code)
+	}
+	return fmt.Sprintf("API error %d: %s", e.StatusCode, msg)
+}
+
+// newAPIError builds the error for an API response with the given status and
+// body. JSON bodies of the forms {"code", "message", "detail"} and
+// {"error": "..."} are parsed; other bodies become the message.
+func newAPIError(status int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: status}
+
+	var parsed struct {
+		Code    json.RawMessage `json:"code"`
+		Message json.RawMessage `json:"message"`
+		Error   json.RawMessage `json:"error"`
+		Detail  json.RawMessage `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		apiErr.Code = jsonText(parsed.Code)
+		apiErr.Message = jsonText(parsed.Message)
+		if apiErr.Message == "" {
+			apiErr.Message = jsonText(parsed.Error)
+		}
+		apiErr.Detail = jsonText(parsed.Detail)
+	}
+
+	if apiErr.Message == "" && apiErr.Detail == "" {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
+
+// jsonText returns a JSON string value as is, and any other value as compact
+// JSON text
+func jsonText(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
+
+// apiStatus returns the HTTP status code of an API error, or zero if err is
+// not an API error
+func apiStatus(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// isNotFound reports whether the API answered that the object does not exist
+func isNotFound(err error) bool {
+	return apiStatus(err) == http.StatusNotFound
+}
@@ -7,12 +7,25 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // Client is the BIND9 API client
@@ -22,50 +35,235 @@ type Client struct {
 	token      string
 	username   string
 	password   string
+	userAgent  string
 	httpClient *http.Client
+
+	// Guards token, which re-authentication replaces while requests are in flight
+	authMu sync.Mutex
+
+	// Limits in-flight API requests; nil means unlimited
+	sem chan struct{}
+
+	// Defaults for records that omit ttl or class
+	defaultTTL   int64
+	defaultClass string
+
+	// Advisory policy checked at plan time
+	policy dnsPolicy
+
+	// Settings inherited by zones that leave them out; nil when not set
+	zoneDefaults *Bind9ZoneDefaultsModel
+
+	// Server version and features, nil if the server predates the version endpoint
+	serverInfo *ServerInfo
+
+	// Defers NOTIFY during record changes; nil when not enabled
+	notifySquelch *notifySquelcher
+	// Freezes dynamic zones while the REST API changes them
+	freezer *zoneFreezer
+
+	// Sends record changes as RFC 2136 dynamic updates; nil uses the REST API
+	dnsUpdate *dnsUpdater
+
+	// Verifies records read through the REST API with DNS queries; nil when
+	// not configured. With dnsQueryAlways, every read is verified, not only
+	// reads the API answers with no records.
+	dnsQuery       *dnsUpdater
+	dnsQueryAlways bool
+
+	// Runs zone operations the REST API lacks over rndc; nil when not configured
+	rndc *rndcClient
+
+	// Base URL of the BIND9 statistics channel; empty when not configured
+	statisticsURL string
+
+	// Serves record reads from per-zone listings; nil when prefetching is disabled
+	recordCache *recordCache
+
+	// Zone listings shared by the operations of one plan or apply
+	zoneLists *zoneListCache
+
+	// Zone renames planned in this process, for the records of the zones
+	zoneRenames *zoneRenames
+
+	// Server limits and the creations planned against them
+	quota *quotaTracker
+	// Record types planned for creation, for CNAME conflict checks
+	plannedTypes *plannedTypes
+	// Record sets planned by bind9_record resources, for duplicate checks
+	rrsetOwners *rrsetOwners
+
+	// Reaches the BIND9 servers through an SSH jump host; nil dials directly
+	tunnel *sshTunnel
+
+	// Server name presented to and verified for the endpoint; empty uses its host
+	tlsServerName string
+
+	// Groups concurrent record changes into atomic transactions; nil when not enabled
+	transactions *recordTransactions
+
+	// Retries of idempotent requests that fail with a network error
+	maxRetries int
+
+	// Counts requests for the end-of-run summary; nil when not enabled
+	metrics *requestMetrics
 }
 
-// NewClient creates a new BIND9 API client
-func NewClient(endpoint, apiKey, username, password string, insecure bool, timeout int64) (*Client, error) {
-	// Normalize endpoint
-	endpoint = strings.TrimSuffix(endpoint, "/")
+// dnsPolicy holds the advisory DNS policy limits. Zero disables a check.
+type dnsPolicy struct {
+	maxSOAMinimum int64
+	minRecordTTL  int64
+	maxRecordTTL  int64
+}
+
+// transportOptions tunes connection reuse between the provider and the API
+type transportOptions struct {
+	// Idle connections kept open to the API for reuse
+	maxIdleConnsPerHost int
+	// TCP keepalive probe interval; negative disables keepalive
+	keepAlive time.Duration
+	// How long an idle connection is kept; zero keeps it indefinitely
+	idleConnTimeout time.Duration
+}
+
+// defaultTransportOptions keeps enough idle connections for Terraform's
+// default parallelism of 10, so concurrent operations reuse connections
+// instead of opening a new one per request
+var defaultTransportOptions = transportOptions{
+	maxIdleConnsPerHost: 10,
+	keepAlive:           30 * time.Second,
+	idleConnTimeout:     90 * time.Second,
+}
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+// newTransport creates the HTTP transport shared by all API requests
+func newTransport(tlsConfig *tls.Config, opts transportOptions) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: opts.keepAlive,
+	}
+	return &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSClientConfig:     tlsConfig,
+		MaxIdleConns:        opts.maxIdleConnsPerHost,
+		MaxIdleConnsPerHost: opts.maxIdleConnsPerHost,
+		IdleConnTimeout:     opts.idleConnTimeout,
+	}
+}
+
+// NewClient creates a new BIND9 API client
+func NewClient(endpoint, apiKey, username, password string, insecure bool, timeout int64, version string) (*Client, error) {
+	endpoint, err := normalizeEndpoint(endpoint)
+	if err != nil {
+		return nil, err
 	}
 
+	transport := newTransport(&tls.Config{InsecureSkipVerify: insecure}, defaultTransportOptions)
+
 	client := &Client{
-		endpoint: endpoint,
-		apiKey:   apiKey,
-		username: username,
-		password: password,
+		endpoint:     endpoint,
+		apiKey:       apiKey,
+		username:     username,
+		password:     password,
+		userAgent:    "terraform-provider-bind9/" + version,
+		defaultTTL:   3600,
+		defaultClass: "IN",
+		policy:       dnsPolicy{maxSOAMinimum: 10800},
+		zoneLists:    newZoneListCache(),
+		zoneRenames:  newZoneRenames(),
+		quota:        newQuotaTracker(),
+		plannedTypes: newPlannedTypes(),
+		rrsetOwners:  newRRsetOwners(),
+		maxRetries:   defaultMaxRetries,
 		httpClient: &http.Client{
 			Timeout:   time.Duration(timeout) * time.Second,
 			Transport: transport,
 		},
 	}
+	client.freezer = newZoneFreezer(client)
+
+	return client, nil
+}
 
-	// If using username/password, get initial token
-	if apiKey == "" && username != "" && password != "" {
-		if err := client.authenticate(); err != nil {
-			return nil, fmt.Errorf("authentication failed: %w", err)
+// errInvalidEndpoint is wrapped by the errors of an endpoint URL the client
+// cannot use
+var errInvalidEndpoint = errors.New("invalid endpoint")
+
+// normalizeEndpoint checks the endpoint URL and returns it without a trailing
+// slash. A path in the URL is kept as the base path of the API, for servers
+// mounted below the root (e.g. https://dns.corp/api-gw/bind9), and a
+// trailing /api/v1 is dropped since every request path starts with it.
+func normalizeEndpoint(endpoint string) (string, error) {
+	if endpoint == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", errInvalidEndpoint, endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("%w %q: must be an http or https URL such as https://dns.example.com:8080", errInvalidEndpoint, endpoint)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%w %q: must not contain a query or fragment", errInvalidEndpoint, endpoint)
+	}
+
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+	if strings.HasSuffix(u.Path, "/api/v1") {
+		u.Path = strings.TrimSuffix(u.Path, "/api/v1")
+		u.RawPath = strings.TrimSuffix(u.RawPath, "/api/v1")
+	}
+	return u.String(), nil
+}
+
+// setToken authenticates with a bearer token issued outside the provider.
+// The API key takes precedence, and username/password, if also set, are
+// used to get a new token once it expires.
+func (c *Client) setToken(token string) {
+	c.authMu.Lock()
+	c.token = token
+	c.authMu.Unlock()
+}
+
+// bearerToken returns the current bearer token
+func (c *Client) bearerToken() string {
+	c.authMu.Lock()
+	defer c.authMu.Unlock()
+	return c.token
+}
+
+// login gets the initial token when using username/password. It runs once
+// the client's connections are set up, which may go through an SSH tunnel.
+func (c *Client) login(ctx context.Context) error {
+	if c.apiKey == "" && c.bearerToken() == "" && c.username != "" && c.password != "" {
+		if err := c.authenticate(ctx); err != nil {
+			return fmt.Errorf("logging in as %s: %w", c.username, err)
 		}
 	}
+	return nil
+}
 
-	return client, nil
+// hasAPI reports whether a REST API endpoint is configured
+func (c *Client) hasAPI() bool {
+	return c.endpoint != ""
 }
 
+// errAuthFailed is wrapped by the errors of a login the API rejected
+var errAuthFailed = errors.New("authentication failed")
+
 // authenticate gets a JWT token using username/password
-func (c *Client) authenticate() error {
+func (c *Client) authenticate(ctx context.Context) error {
 	data := url.Values{}
 	data.Set("username", c.username)
 	data.Set("password", c.password)
 
-	req, err := http.NewRequest("POST", c.endpoint+"/api/v1/auth/token", strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+"/api/v1/auth/token", strings.NewReader(data.Encode()))
 	if err != nil {
 		return err
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", c.userAgent)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -75,7 +273,7 @@ func (c *Client) authenticate() error {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed: %s - %s", resp.Status, string(body))
+		return fmt.Errorf("%w: %s - %s", errAuthFailed, resp.Status, string(body))
 	}
 
 	var tokenResp struct {
@@ -85,12 +283,245 @@ func (c *Client) authenticate() error {
 		return err
 	}
 
-	c.token = tokenResp.AccessToken
+	c.setToken(tokenResp.AccessToken)
 	return nil
 }
 
+// setTransportOptions replaces the transport with one using the given
+// connection reuse settings, closing idle connections of the previous one
+func (c *Client) setTransportOptions(opts transportOptions) {
+	old, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+	transport := newTransport(old.TLSClientConfig, opts)
+	if c.tunnel != nil {
+		transport.DialContext = c.tunnel.wrap(transport.DialContext)
+	}
+	if c.tlsServerName != "" {
+		transport.DialTLSContext = c.dialTLS(transport)
+	}
+	c.httpClient.Transport = transport
+	old.CloseIdleConnections()
+}
+
+// tlsVersions maps the tls_min_version values to TLS versions
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// setTLSOptions sets the minimum TLS version of all connections, and the
+// server name presented to and verified for the endpoint. An empty server
+// name uses the endpoint's host.
+func (c *Client) setTLSOptions(serverName string, minVersion uint16) {
+	transport, ok := c.httpClient.Transport.(*http.Transport)
+	if !ok {
+		return
+	}
+
+	tlsConfig := transport.TLSClientConfig.Clone()
+	tlsConfig.MinVersion = minVersion
+	transport.TLSClientConfig = tlsConfig
+
+	c.tlsServerName = serverName
+	if serverName != "" {
+		transport.DialTLSContext = c.dialTLS(transport)
+	}
+}
+
+// dialTLS returns a TLS dial function for a transport that presents the
+// configured server name to the endpoint, which may be reached by IP address
+// or through a load balancer with a shared certificate. Other hosts, such as
+// webhooks, are addressed by their own name.
+func (c *Client) dialTLS(transport *http.Transport) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	endpointAddr := urlHostPort(c.endpoint)
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := transport.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig := transport.TLSClientConfig.Clone()
+		if strings.EqualFold(addr, endpointAddr) {
+			tlsConfig.ServerName = c.tlsServerName
+		} else if host, _, err := net.SplitHostPort(addr); err == nil {
+			tlsConfig.ServerName = host
+		}
+
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return tlsConn, nil
+	}
+}
+
+// urlHostPort returns the host:port a URL connects to, or an empty string
+func urlHostPort(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// setSSHTunnel sends connections to the API, the statistics channel and rndc
+// through an SSH jump host
+func (c *Client) setSSHTunnel(t *sshTunnel) {
+	t.route(c.endpoint)
+	t.route(c.statisticsURL)
+	c.tunnel = t
+
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		transport.DialContext = t.wrap(transport.DialContext)
+	}
+	if c.rndc != nil {
+		c.rndc.dial = t.DialContext
+	}
+}
+
+// setMaxRetries sets how often idempotent requests are retried after network
+// errors. Zero disables retries.
+func (c *Client) setMaxRetries(n int64) {
+	c.maxRetries = int(max(n, 0))
+}
+
+// setMaxConcurrentRequests limits the number of API requests in flight at once.
+// A value of zero or less removes the limit.
+func (c *Client) setMaxConcurrentRequests(n int64) {
+	if n <= 0 {
+		c.sem = nil
+		return
+	}
+	c.sem = make(chan struct{}, n)
+}
+
+// acquire waits for a free request slot and returns a function releasing it
+func (c *Client) acquire(ctx context.Context) (func(), error) {
+	if c.sem == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-c.sem }) }, nil
+}
+
+// releasingBody releases the request slot when the response body is closed
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close closes the underlying body and releases the request slot
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+// Limits on honoring Retry-After from 429 and 503 responses. Longer waits,
+// or more retries, surface the response as an error instead.
+const (
+	maxRetryAfterAttempts = 5
+	maxRetryAfterWait     = 2 * time.Minute
+)
+
+// Retries of idempotent requests after network errors such as connection
+// resets, with exponential backoff between attempts
+const (
+	defaultMaxRetries = 3
+	maxMaxRetries     = 10
+	retryBaseDelay    = time.Second
+	maxRetryDelay     = 30 * time.Second
+)
+
 // doRequest performs an HTTP request with authentication
 func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	for retry := 0; ; retry++ {
+		resp, err := c.doRequestAttempt(ctx, method, path, body, 0, false)
+		if err == nil || retry >= c.maxRetries || !isRetryableNetworkError(ctx, method, err) {
+			return resp, err
+		}
+
+		// The shift stops growing past maxRetryDelay, so it cannot overflow
+		delay := min(retryBaseDelay<<min(retry, 5), maxRetryDelay)
+		tflog.Warn(ctx, "API request failed with a network error, retrying", map[string]any{
+			"method":  method,
+			"path":    path,
+			"error":   err.Error(),
+			"retry":   retry + 1,
+			"retries": c.maxRetries,
+			"delay":   delay.String(),
+		})
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
+
+// errReusedConnectionClosed marks a request that failed because the server
+// closed the kept-alive connection it was sent on
+var errReusedConnectionClosed = errors.New("the server closed a reused connection")
+
+// isRetryableNetworkError reports whether a failed request may be sent again:
+// it must be idempotent, and must have failed because the connection was
+// refused, reset or closed. Responses from the server, cancellations,
+// timeouts, name resolution failures, unreachable addresses and TLS failures
+// such as an untrusted certificate are not retried.
+func isRetryableNetworkError(ctx context.Context, method string, err error) bool {
+	if method != "GET" && method != "HEAD" && method != "DELETE" {
+		return false
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return false
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, errReusedConnectionClosed)
+}
+
+// errNoEndpoint is returned for requests to the REST API by a provider
+// configured without an endpoint, which manages records over DNS only
+var errNoEndpoint = errors.New("this operation requires the BIND9 REST API, but no endpoint is configured")
+
+// doRequestAttempt performs a request; attempt counts the retries so far
+// after Retry-After responses, and reauthenticated is set once the request
+// has been retried with a new token
+func (c *Client) doRequestAttempt(ctx context.Context, method, path string, body interface{}, attempt int, reauthenticated bool) (*http.Response, error) {
+	if !c.hasAPI() {
+		return nil, errNoEndpoint
+	}
+	path = viewQuery(path, viewFromContext(ctx))
+
 	var reqBody io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -100,40 +531,232 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body interf
 		reqBody = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	// The span covers the request until the response body is closed
+	spanCtx, span := startSpan(ctx, "HTTP "+method,
+		attribute.String("http.request.method", method),
+		attribute.String("url.path", strings.SplitN(path, "?", 2)[0]),
+	)
+
+	req, err := http.NewRequestWithContext(spanCtx, method, c.endpoint+path, reqBody)
 	if err != nil {
+		endSpan(span, err)
 		return nil, err
 	}
 
+	req.Header.Set("User-Agent", c.userAgent)
+	otel.GetTextMapPropagator().Inject(spanCtx, propagation.HeaderCarrier(req.Header))
+
 	// Set authentication header
 	if c.apiKey != "" {
 		req.Header.Set("X-API-Key", c.apiKey)
-	} else if c.token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if token := c.bearerToken(); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
 
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	// Guard changes against concurrent modification when the object's ETag is known
+	etag := etagFromContext(ctx)
+	if etag != nil && etag.etag != "" && method != "GET" {
+		req.Header.Set("If-Match", etag.etag)
+	}
+
+	// Hold a request slot until the response body is closed
+	release, err := c.acquire(spanCtx)
+	if err != nil {
+		endSpan(span, err)
+		return nil, err
+	}
+
+	// An EOF on a kept-alive connection means the server closed it while
+	// idle, and the request can be sent again on a new one
+	reused := false
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) { reused = info.Reused },
+	}))
+
+	started := time.Now()
 	resp, err := c.httpClient.Do(req)
+	if c.metrics != nil {
+		status := 0
+		if err == nil {
+			status = resp.StatusCode
+		}
+		c.metrics.observe(method, status, time.Since(started))
+	}
 	if err != nil {
+		if reused && errors.Is(err, io.EOF) {
+			err = fmt.Errorf("%w: %w", errReusedConnectionClosed, err)
+		}
+		release()
+		endSpan(span, err)
 		return nil, err
 	}
 
-	// Re-authenticate if token expired
-	if resp.StatusCode == http.StatusUnauthorized && c.username != "" {
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	} else if etag != nil {
+		etag.etag = resp.Header.Get("ETag")
+	}
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: func() {
+		release()
+		span.End()
+	}}
+
+	// Re-authenticate if token expired. A new token that is rejected as
+	// well is reported instead of retried again.
+	if resp.StatusCode == http.StatusUnauthorized && c.username != "" && !reauthenticated {
 		resp.Body.Close()
-		if err := c.authenticate(); err != nil {
+		if err := c.authenticate(ctx); err != nil {
 			return nil, err
 		}
 		// Retry request
-		return c.doRequest(ctx, method, path, body)
+		return c.doRequestAttempt(ctx, method, path, body, attempt, true)
+	}
+
+	// Wait and retry when the server asks to back off
+	if (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) &&
+		attempt < maxRetryAfterAttempts {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && delay <= maxRetryAfterWait {
+			resp.Body.Close()
+			tflog.Debug(ctx, "API asked to retry later", map[string]any{
+				"status": resp.StatusCode,
+				"delay":  delay.String(),
+				"path":   path,
+			})
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
+			return c.doRequestAttempt(ctx, method, path, body, attempt+1, reauthenticated)
+		}
 	}
 
 	return resp, nil
 }
 
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date. Dates in the past yield a zero delay.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	if delay := date.Sub(now); delay > 0 {
+		return delay, true
+	}
+	return 0, true
+}
+
+// listPageSize is the number of items requested per page by list operations
+const listPageSize = 1000
+
+// linkNext matches the next page URL of a Link header (RFC 8288)
+var linkNext = regexp.MustCompile(`<([^>]+)>[^,]*;\s*rel="?next"?`)
+
+// listAll fetches every page of a list endpoint and passes each page body to
+// decode, which appends the page's items and returns how many it held.
+// Pages follow the Link header's next URL when the API sends one, and are
+// otherwise requested with limit and offset until a short page arrives. A
+// limit set by the caller fetches a single page.
+func (c *Client) listAll(ctx context.Context, path string, query url.Values, decode func(body []byte) (int, error)) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	paged := query.Get("limit") == ""
+	if paged {
+		query.Set("limit", strconv.Itoa(listPageSize))
+	}
+
+	next := path + "?" + query.Encode()
+	offset := 0
+	var previous []byte
+	for {
+		resp, err := c.doRequest(ctx, "GET", next, nil)
+		if err != nil {
+			return err
+		}
+		link := c.nextPageLink(resp.Header.Get("Link"))
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode >= 400 {
+			return newAPIError(resp.StatusCode, body)
+		}
+
+		// An API that ignores offset returns the same page again
+		if previous != nil && bytes.Equal(body, previous) {
+			return nil
+		}
+		previous = body
+
+		n := 0
+		if len(body) > 0 {
+			if n, err = decode(body); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case !paged:
+			return nil
+		case link != "" && link != next:
+			next = link
+		case link == "" && n == listPageSize:
+			offset += n
+			query.Set("offset", strconv.Itoa(offset))
+			next = path + "?" + query.Encode()
+		default:
+			return nil
+		}
+	}
+}
+
+// nextPageLink returns the next page URL of a Link header as a path relative
+// to the endpoint, or "" if there is none
+func (c *Client) nextPageLink(header string) string {
+	m := linkNext.FindStringSubmatch(header)
+	if m == nil {
+		return ""
+	}
+
+	link := m[1]
+	if strings.HasPrefix(link, c.endpoint) {
+		return strings.TrimPrefix(link, c.endpoint)
+	}
+
+	// Strip the scheme and host, and the endpoint's base path if any
+	if u, err := url.Parse(link); err == nil {
+		link = u.RequestURI()
+	}
+	if base, err := url.Parse(c.endpoint); err == nil && base.Path != "" {
+		link = strings.TrimPrefix(link, strings.TrimSuffix(base.Path, "/"))
+	}
+	return link
+}
+
 // parseResponse parses the response body into the given interface
 func (c *Client) parseResponse(resp *http.Response, v interface{}) error {
 	defer resp.Body.Close()
@@ -144,7 +767,7 @@ func (c *Client) parseResponse(resp *http.Response, v interface{}) error {
 	}
 
 	if resp.StatusCode >= 400 {
-		return fmt.Errorf("API error %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp.StatusCode, body)
 	}
 
 	if v != nil && len(body) > 0 {
@@ -154,6 +777,92 @@ func (c *Client) parseResponse(resp *http.Response, v interface{}) error {
 	return nil
 }
 
+// Ping performs a lightweight authenticated request to verify that the API
+// is reachable and the credentials are accepted
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.doRequest(ctx, "GET", "/api/v1/zones?limit=1", nil)
+	if err != nil {
+		return err
+	}
+	return c.parseResponse(resp, nil)
+}
+
+// ============================================================================
+// Version Negotiation
+// ============================================================================
+
+// API features reported by the version endpoint
+const (
+	FeatureACLs         = "acls"
+	FeatureDNSSEC       = "dnssec"
+	FeatureViews        = "views"
+	FeatureRRsetReplace = "rrset_replace"
+
+	FeatureRecordComments = "record_comments"
+	FeatureRecordUpdate   = "record_update"
+	FeatureZoneBootstrap  = "zone_bootstrap"
+	FeatureZoneRename     = "zone_rename"
+	FeatureResponsePolicy = "response_policy"
+)
+
+// ServerInfo describes the API server version and the features it supports
+type ServerInfo struct {
+	Version  string   `json:"version"`
+	Features []string `json:"features"`
+}
+
+// GetServerInfo retrieves the API version and feature list
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/v1/version", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var info ServerInfo
+	if err := c.parseResponse(resp, &info); err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
+
+// negotiateVersion records the server's version and features. Servers without
+// the version endpoint are treated as supporting every feature.
+func (c *Client) negotiateVersion(ctx context.Context) error {
+	info, err := c.GetServerInfo(ctx)
+	if err != nil {
+		if isNotFound(err) {
+			c.serverInfo = nil
+			return nil
+		}
+		return err
+	}
+
+	c.serverInfo = info
+	return nil
+}
+
+// SupportsFeature reports whether the server supports the given feature
+func (c *Client) SupportsFeature(feature string) bool {
+	if c.serverInfo == nil {
+		return true
+	}
+	for _, f := range c.serverInfo.Features {
+		if strings.EqualFold(f, feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServerVersion returns the API server version, or "unknown"
+func (c *Client) ServerVersion() string {
+	if c.serverInfo == nil || c.serverInfo.Version == "" {
+		return "unknown"
+	}
+	return c.serverInfo.Version
+}
+
 // ============================================================================
 // Zone Operations
 // ============================================================================
@@ -162,26 +871,112 @@ func (c *Client) parseResponse(resp *http.Response, v interface{}) error {
 type Zone struct {
 	Name          string       `json:"name"`
 	Type          string       `json:"zone_type"`
+	Class         string       `json:"zone_class,omitempty"`
 	File          string       `json:"file,omitempty"`
 	Serial        int64        `json:"serial,omitempty"`
 	Loaded        bool         `json:"loaded,omitempty"`
 	DNSSECEnabled bool         `json:"dnssec_enabled,omitempty"`
+	Frozen        bool         `json:"frozen,omitempty"`
 	RecordCount   int64        `json:"record_count,omitempty"`
+	View          string       `json:"view,omitempty"`
 	Options       *ZoneOptions `json:"options,omitempty"`
 }
 
+// zoneClass returns the class of a zone, defaulting to IN
+func zoneClass(z Zone) string {
+	if z.Class == "" {
+		return "IN"
+	}
+	return strings.ToUpper(z.Class)
+}
+
 // ZoneOptions contains zone configuration options
 type ZoneOptions struct {
-	AllowTransfer []string `json:"allow_transfer,omitempty"`
-	AllowUpdate   []string `json:"allow_update,omitempty"`
-	AllowQuery    []string `json:"allow_query,omitempty"`
-	Notify        bool     `json:"notify,omitempty"`
+	AllowTransfer    []string      `json:"allow_transfer,omitempty"`
+	AllowUpdate      []string      `json:"allow_update,omitempty"`
+	UpdatePolicy     []string      `json:"update_policy,omitempty"`
+	AllowQuery       []string      `json:"allow_query,omitempty"`
+	Primaries        []ZonePrimary `json:"primaries,omitempty"`
+	Forwarders       *[]string     `json:"forwarders,omitempty"`
+	Forward          string        `json:"forward,omitempty"`
+	ServerAddresses  []string      `json:"server_addresses,omitempty"`
+	ServerNames      []string      `json:"server_names,omitempty"`
+	InlineSigning    *bool         `json:"inline_signing,omitempty"`
+	DNSSECPolicy     string        `json:"dnssec_policy,omitempty"`
+	KeyDirectory     string        `json:"key_directory,omitempty"`
+	AutoDNSSEC       string        `json:"auto_dnssec,omitempty"`
+	LoadKeysInterval int64         `json:"dnssec_loadkeys_interval,omitempty"`
+	SerialFormat     string        `json:"serial_format,omitempty"`
+	CheckNames       string        `json:"check_names,omitempty"`
+	MaxJournalSize   string        `json:"max_journal_size,omitempty"`
+	Journal          string        `json:"journal,omitempty"`
+	MasterfileFormat string        `json:"masterfile_format,omitempty"`
+	Notify           NotifyMode    `json:"notify,omitempty"`
+	NotifySource     string        `json:"notify_source,omitempty"`
+	TransferSource   string        `json:"transfer_source,omitempty"`
+	TransferSourceV6 string        `json:"transfer_source_v6,omitempty"`
+	MaxTransferTime  int64         `json:"max_transfer_time_in,omitempty"`
+	MaxTransferIdle  int64         `json:"max_transfer_idle_in,omitempty"`
+	TransferFormat   string        `json:"transfer_format,omitempty"`
+
+	// Entry of the zone in the server's response-policy statement
+	ResponsePolicy *ZoneResponsePolicy `json:"response_policy,omitempty"`
+}
+
+// Zone NOTIFY modes
+const (
+	notifyYes      = "yes"
+	notifyNo       = "no"
+	notifyExplicit = "explicit"
+)
+
+// NotifyMode is the NOTIFY setting of a zone: yes, no or explicit. yes and
+// no are sent as the booleans earlier API versions use, and read from
+// either form.
+type NotifyMode string
+
+// MarshalJSON encodes yes and no as booleans and other modes as strings
+func (m NotifyMode) MarshalJSON() ([]byte, error) {
+	switch m {
+	case notifyYes:
+		return []byte("true"), nil
+	case notifyNo:
+		return []byte("false"), nil
+	}
+	return json.Marshal(string(m))
+}
+
+// UnmarshalJSON decodes a boolean or a mode string
+func (m *NotifyMode) UnmarshalJSON(data []byte) error {
+	var enabled bool
+	if err := json.Unmarshal(data, &enabled); err == nil {
+		*m = notifyNo
+		if enabled {
+			*m = notifyYes
+		}
+		return nil
+	}
+
+	var mode string
+	if err := json.Unmarshal(data, &mode); err != nil {
+		return err
+	}
+	*m = NotifyMode(strings.ToLower(mode))
+	return nil
+}
+
+// ZonePrimary is a primary server a slave or stub zone loads from
+type ZonePrimary struct {
+	Address string `json:"address"`
+	Port    int    `json:"port,omitempty"`
+	Key     string `json:"key,omitempty"`
 }
 
 // ZoneCreateRequest is the request body for creating a zone
 type ZoneCreateRequest struct {
 	Name        string            `json:"name"`
 	Type        string            `json:"zone_type"`
+	Class       string            `json:"zone_class,omitempty"`
 	File        string            `json:"file,omitempty"`
 	SOAMname    string            `json:"soa_mname,omitempty"`
 	SOARname    string            `json:"soa_rname,omitempty"`
@@ -192,7 +987,12 @@ type ZoneCreateRequest struct {
 	DefaultTTL  int               `json:"default_ttl,omitempty"`
 	Nameservers []string          `json:"nameservers,omitempty"`
 	NSAddresses map[string]string `json:"ns_addresses,omitempty"`
+	View        string            `json:"view,omitempty"`
 	Options     *ZoneOptions      `json:"options,omitempty"`
+
+	// Content the zone is created with, besides its SOA and NS records
+	Records         []RecordCreateRequest `json:"records,omitempty"`
+	ZoneFileContent string                `json:"zone_file_content,omitempty"`
 }
 
 // GetZone retrieves a zone by name
@@ -210,36 +1010,109 @@ func (c *Client) GetZone(ctx context.Context, name string) (*Zone, error) {
 	return &zone, nil
 }
 
-// ListZones retrieves all zones, optionally filtered by parameters
+// ListZones retrieves all zones, optionally filtered by parameters. Listings
+// are cached until a zone is created, updated or deleted through the client.
 func (c *Client) ListZones(ctx context.Context, params map[string]string) ([]Zone, error) {
-	path := "/api/v1/zones"
+	query := url.Values{}
+	for k, v := range params {
+		query.Set(k, v)
+	}
 
-	if len(params) > 0 {
-		query := url.Values{}
-		for k, v := range params {
-			query.Set(k, v)
+	// A listing's ETag is not that of any one zone
+	ctx = withETag(ctx, nil)
+	return c.zoneLists.get(ctx, query, func() ([]Zone, error) {
+		return c.fetchZones(ctx, query)
+	})
+}
+
+// fetchZones retrieves all pages of a zone listing
+func (c *Client) fetchZones(ctx context.Context, query url.Values) ([]Zone, error) {
+	var zones []Zone
+	err := c.listAll(ctx, "/api/v1/zones", query, func(body []byte) (int, error) {
+		var page struct {
+			Zones []Zone `json:"zones"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
 		}
-		path += "?" + query.Encode()
+		zones = append(zones, page.Zones...)
+		return len(page.Zones), nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	resp, err := c.doRequest(ctx, "GET", path, nil)
+	return zones, nil
+}
+
+// CreateZone creates a new zone
+func (c *Client) CreateZone(ctx context.Context, req *ZoneCreateRequest) (*Zone, error) {
+	defer c.zoneLists.invalidate()
+
+	resp, err := c.doRequest(ctx, "POST", "/api/v1/zones", req)
 	if err != nil {
 		return nil, err
 	}
 
-	var result struct {
-		Zones []Zone `json:"zones"`
-	}
-	if err := c.parseResponse(resp, &result); err != nil {
+	var zone Zone
+	if err := c.parseResponse(resp, &zone); err != nil {
 		return nil, err
 	}
 
-	return result.Zones, nil
+	return &zone, nil
 }
 
-// CreateZone creates a new zone
-func (c *Client) CreateZone(ctx context.Context, req *ZoneCreateRequest) (*Zone, error) {
-	resp, err := c.doRequest(ctx, "POST", "/api/v1/zones", req)
+// ZoneUpdateRequest is the request body for updating zone options. Nil
+// fields are left unchanged.
+type ZoneUpdateRequest struct {
+	AllowTransfer    *[]string      `json:"allow_transfer,omitempty"`
+	AllowUpdate      *[]string      `json:"allow_update,omitempty"`
+	UpdatePolicy     *[]string      `json:"update_policy,omitempty"`
+	AllowQuery       *[]string      `json:"allow_query,omitempty"`
+	Primaries        *[]ZonePrimary `json:"primaries,omitempty"`
+	Forwarders       *[]string      `json:"forwarders,omitempty"`
+	Forward          *string        `json:"forward,omitempty"`
+	ServerAddresses  *[]string      `json:"server_addresses,omitempty"`
+	ServerNames      *[]string      `json:"server_names,omitempty"`
+	InlineSigning    *bool          `json:"inline_signing,omitempty"`
+	DNSSECPolicy     *string        `json:"dnssec_policy,omitempty"`
+	KeyDirectory     *string        `json:"key_directory,omitempty"`
+	AutoDNSSEC       *string        `json:"auto_dnssec,omitempty"`
+	LoadKeysInterval *int64         `json:"dnssec_loadkeys_interval,omitempty"`
+	SerialFormat     *string        `json:"serial_format,omitempty"`
+	CheckNames       *string        `json:"check_names,omitempty"`
+	MaxJournalSize   *string        `json:"max_journal_size,omitempty"`
+	Journal          *string        `json:"journal,omitempty"`
+	MasterfileFormat *string        `json:"masterfile_format,omitempty"`
+	Notify           *NotifyMode    `json:"notify,omitempty"`
+	NotifySource     *string        `json:"notify_source,omitempty"`
+	TransferSource   *string        `json:"transfer_source,omitempty"`
+	TransferSourceV6 *string        `json:"transfer_source_v6,omitempty"`
+	MaxTransferTime  *int64         `json:"max_transfer_time_in,omitempty"`
+	MaxTransferIdle  *int64         `json:"max_transfer_idle_in,omitempty"`
+	TransferFormat   *string        `json:"transfer_format,omitempty"`
+
+	// A pointer to nil is sent as null, which takes the zone out of the
+	// server's response-policy statement
+	ResponsePolicy **ZoneResponsePolicy `json:"response_policy,omitempty"`
+
+	// SOA fields. The server bumps the serial when they change.
+	SOAMname   *string `json:"soa_mname,omitempty"`
+	SOARname   *string `json:"soa_rname,omitempty"`
+	SOARefresh *int64  `json:"soa_refresh,omitempty"`
+	SOARetry   *int64  `json:"soa_retry,omitempty"`
+	SOAExpire  *int64  `json:"soa_expire,omitempty"`
+	SOAMinimum *int64  `json:"soa_minimum,omitempty"`
+}
+
+// UpdateZone updates options and SOA fields of an existing zone
+func (c *Client) UpdateZone(ctx context.Context, name string, req *ZoneUpdateRequest) (*Zone, error) {
+	defer c.zoneLists.invalidate()
+	if c.freezer != nil {
+		defer c.freezer.forget(ctx, name)
+	}
+
+	resp, err := c.doRequest(ctx, "PATCH", "/api/v1/zones/"+url.PathEscape(name), req)
 	if err != nil {
 		return nil, err
 	}
@@ -252,8 +1125,19 @@ func (c *Client) CreateZone(ctx context.Context, req *ZoneCreateRequest) (*Zone,
 	return &zone, nil
 }
 
+// NotifyZone sends NOTIFY messages for a zone to its secondaries
+func (c *Client) NotifyZone(ctx context.Context, name string) error {
+	resp, err := c.doRequest(ctx, "POST", "/api/v1/zones/"+url.PathEscape(name)+"/notify", nil)
+	if err != nil {
+		return err
+	}
+	return c.parseResponse(resp, nil)
+}
+
 // DeleteZone deletes a zone
 func (c *Client) DeleteZone(ctx context.Context, name string, deleteFile bool) error {
+	defer c.zoneLists.invalidate()
+
 	path := "/api/v1/zones/" + url.PathEscape(name)
 	if deleteFile {
 		path += "?delete_file=true"
@@ -269,11 +1153,100 @@ func (c *Client) DeleteZone(ctx context.Context, name string, deleteFile bool) e
 
 // ReloadZone reloads a zone
 func (c *Client) ReloadZone(ctx context.Context, name string) error {
-	resp, err := c.doRequest(ctx, "POST", "/api/v1/zones/"+url.PathEscape(name)+"/reload", nil)
-	if err != nil {
+	return c.zoneControl(ctx, "reload", name, func() error {
+		resp, err := c.doRequest(ctx, "POST", "/api/v1/zones/"+url.PathEscape(name)+"/reload", nil)
+		if err != nil {
+			return err
+		}
+		return c.parseResponse(resp, nil)
+	})
+}
+
+// FreezeZone suspends dynamic updates to a zone and syncs its journal to the
+// zone file, so the file can be edited
+func (c *Client) FreezeZone(ctx context.Context, name string) error {
+	return c.zoneControl(ctx, "freeze", name, func() error {
+		resp, err := c.doRequest(ctx, "POST", "/api/v1/zones/"+url.PathEscape(name)+"/freeze", nil)
+		if err != nil {
+			return err
+		}
+		return c.parseResponse(resp, nil)
+	})
+}
+
+// ThawZone reloads a frozen zone and re-enables dynamic updates
+func (c *Client) ThawZone(ctx context.Context, name string) error {
+	return c.zoneControl(ctx, "thaw", name, func() error {
+		resp, err := c.doRequest(ctx, "POST", "/api/v1/zones/"+url.PathEscape(name)+"/thaw", nil)
+		if err != nil {
+			return err
+		}
+		return c.parseResponse(resp, nil)
+	})
+}
+
+// zoneControl runs a zone operation through the REST API, falling back to
+// the equivalent rndc command when the API does not provide the operation
+func (c *Client) zoneControl(ctx context.Context, command, zone string, rest func() error) error {
+	err := rest()
+	if err == nil || c.rndc == nil || !isUnsupportedOperation(err) {
 		return err
 	}
-	return c.parseResponse(resp, nil)
+
+	tflog.Debug(ctx, "REST API does not provide zone operation, using rndc", map[string]any{
+		"command": command,
+		"zone":    zone,
+		"error":   err.Error(),
+	})
+	command += " " + zone
+	// rndc takes a view after the zone's class
+	if view := viewFromContext(ctx); view != "" {
+		z, err := c.GetZone(ctx, zone)
+		if err != nil {
+			return fmt.Errorf("reading the class of zone %s for rndc %s: %w", zone, command, err)
+		}
+		command += " " + zoneClass(*z) + " " + view
+	}
+	_, err = c.rndc.command(ctx, command)
+	return err
+}
+
+// isUnsupportedOperation reports whether an API error means the server does
+// not provide the requested operation at all
+func isUnsupportedOperation(err error) bool {
+	switch apiStatus(err) {
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return true
+	}
+	return errors.Is(err, errNoEndpoint)
+}
+
+// TransferStats describes the most recent inbound transfer of a secondary zone
+type TransferStats struct {
+	Zone         string  `json:"zone"`
+	Primary      string  `json:"primary,omitempty"`
+	TransferType string  `json:"transfer_type"`
+	LastTransfer string  `json:"last_transfer,omitempty"`
+	DurationMs   float64 `json:"duration_ms"`
+	Bytes        int64   `json:"bytes"`
+	Messages     int64   `json:"messages"`
+	Records      int64   `json:"records"`
+	Serial       int64   `json:"serial"`
+}
+
+// GetTransferStats retrieves statistics for the last transfer of a secondary zone
+func (c *Client) GetTransferStats(ctx context.Context, name string) (*TransferStats, error) {
+	resp, err := c.doRequest(ctx, "GET", "/api/v1/zones/"+url.PathEscape(name)+"/transfer-stats", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats TransferStats
+	if err := c.parseResponse(resp, &stats); err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
 }
 
 // ============================================================================
@@ -288,6 +1261,9 @@ type Record struct {
 	Class string `json:"class,omitempty"`
 	RData string `json:"rdata"`
 	Zone  string `json:"zone,omitempty"`
+
+	// Comment stored with the record set, e.g. as a zone file comment
+	Comment string `json:"comment,omitempty"`
 }
 
 // RecordCreateRequest is the request for creating a record
@@ -297,11 +1273,31 @@ type RecordCreateRequest struct {
 	TTL         int                    `json:"ttl"`
 	RecordClass string                 `json:"record_class,omitempty"`
 	Data        map[string]interface{} `json:"data"`
+
+	// Presentation-format record data, used by the dynamic update transport
+	RData string `json:"-"`
 }
 
-// GetRecords retrieves records for a zone
-func (c *Client) GetRecords(ctx context.Context, zone string, recordType, name string) ([]Record, error) {
-	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records"
+// RRsetReplaceRequest is the request for replacing all values of a record set
+type RRsetReplaceRequest struct {
+	TTL         int                      `json:"ttl"`
+	RecordClass string                   `json:"record_class,omitempty"`
+	Records     []map[string]interface{} `json:"records"`
+
+	// Presentation-format record data, used by the dynamic update transport
+	RData []string `json:"-"`
+}
+
+// GetRecords retrieves records for a zone. Empty filters match everything.
+func (c *Client) GetRecords(ctx context.Context, zone string, recordType, name, class string) ([]Record, error) {
+	if c.dnsUpdate != nil {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return c.dnsUpdate.getRecords(ctx, zone, recordType, name, class)
+	}
 
 	params := url.Values{}
 	if recordType != "" {
@@ -310,32 +1306,47 @@ func (c *Client) GetRecords(ctx context.Context, zone string, recordType, name s
 	if name != "" {
 		params.Set("name", name)
 	}
-	if len(params) > 0 {
-		path += "?" + params.Encode()
+	if class != "" {
+		params.Set("record_class", class)
 	}
 
-	resp, err := c.doRequest(ctx, "GET", path, nil)
+	records, err := c.listRecordPages(ctx, zone, params)
 	if err != nil {
 		return nil, err
 	}
 
-	var records []Record
-	if err := c.parseResponse(resp, &records); err != nil {
-		return nil, err
+	// Older API versions ignore the class filter, so apply it here as well.
+	// Records without a class are IN.
+	if class != "" {
+		filtered := records[:0]
+		for _, r := range records {
+			if strings.EqualFold(recordClass(r), class) {
+				filtered = append(filtered, r)
+			}
+		}
+		records = filtered
 	}
 
 	return records, nil
 }
 
+// recordClass returns the class of a record, defaulting to IN
+func recordClass(r Record) string {
+	if r.Class == "" {
+		return "IN"
+	}
+	return r.Class
+}
+
 // GetRecord retrieves a specific record
 func (c *Client) GetRecord(ctx context.Context, zone, name, recordType string) (*Record, error) {
-	records, err := c.GetRecords(ctx, zone, recordType, name)
+	records, err := c.GetRecords(ctx, zone, recordType, name, "")
 	if err != nil {
 		return nil, err
 	}
 
 	for _, r := range records {
-		if r.Name == name && r.Type == recordType {
+		if strings.EqualFold(r.Name, name) && strings.EqualFold(r.Type, recordType) {
 			return &r, nil
 		}
 	}
@@ -345,6 +1356,21 @@ func (c *Client) GetRecord(ctx context.Context, zone, name, recordType string) (
 
 // CreateRecord creates a new record
 func (c *Client) CreateRecord(ctx context.Context, zone string, req *RecordCreateRequest) (*Record, error) {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	req.RData = writeRdata(req.RecordType, req.RData)
+
+	if c.dnsUpdate != nil {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return c.dnsUpdate.createRecord(ctx, zone, req)
+	}
+
 	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records"
 
 	resp, err := c.doRequest(ctx, "POST", path, req)
@@ -360,13 +1386,35 @@ func (c *Client) CreateRecord(ctx context.Context, zone string, req *RecordCreat
 	return &record, nil
 }
 
-// DeleteRecord deletes a record
-func (c *Client) DeleteRecord(ctx context.Context, zone, name, recordType, rdata string) error {
+// DeleteRecord deletes a record. An empty class deletes from the IN class.
+func (c *Client) DeleteRecord(ctx context.Context, zone, name, recordType, class, rdata string) error {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	rdata = writeRdata(recordType, rdata)
+
+	if c.dnsUpdate != nil {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return c.dnsUpdate.deleteRecord(ctx, zone, name, recordType, class, rdata)
+	}
+
 	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records/" +
 		url.PathEscape(name) + "/" + url.PathEscape(recordType)
 
+	params := url.Values{}
 	if rdata != "" {
-		path += "?rdata=" + url.QueryEscape(rdata)
+		params.Set("rdata", rdata)
+	}
+	if class != "" && class != "IN" {
+		params.Set("record_class", class)
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
 	}
 
 	resp, err := c.doRequest(ctx, "DELETE", path, nil)
@@ -377,6 +1425,136 @@ func (c *Client) DeleteRecord(ctx context.Context, zone, name, recordType, rdata
 	return c.parseResponse(resp, nil)
 }
 
+// ReplaceRRset replaces all values of a record set in one atomic change, so
+// an interrupted run cannot leave the set partially updated
+func (c *Client) ReplaceRRset(ctx context.Context, zone, name, recordType string, req *RRsetReplaceRequest) error {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	for i := range req.RData {
+		req.RData[i] = writeRdata(recordType, req.RData[i])
+	}
+
+	if c.dnsUpdate != nil {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return c.dnsUpdate.replaceRRset(ctx, zone, name, recordType, req)
+	}
+
+	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records/" +
+		url.PathEscape(name) + "/" + url.PathEscape(recordType)
+
+	resp, err := c.doRequest(ctx, "PUT", path, req)
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(resp, nil)
+}
+
+// RecordUpdateRequest is the request for changing one value of a record set
+// to another
+type RecordUpdateRequest struct {
+	// Presentation-format record data of the value to change
+	RData       string                 `json:"rdata"`
+	TTL         int                    `json:"ttl"`
+	RecordClass string                 `json:"record_class,omitempty"`
+	Data        map[string]interface{} `json:"data"`
+}
+
+// UpdateRecord changes one value of a record set to another in a single
+// request. Unlike deleting the value and creating the new one, the record
+// set is never left without it, so a single-value set does not briefly
+// vanish from DNS. It needs the REST API.
+func (c *Client) UpdateRecord(ctx context.Context, zone, name, recordType string, req *RecordUpdateRequest) error {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	req.RData = writeRdata(recordType, req.RData)
+
+	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records/" +
+		url.PathEscape(name) + "/" + url.PathEscape(recordType) + "/value"
+
+	resp, err := c.doRequest(ctx, "PUT", path, req)
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(resp, nil)
+}
+
+// RRsetTTLUpdateRequest is the request for changing the TTL of a record set
+type RRsetTTLUpdateRequest struct {
+	TTL         int    `json:"ttl"`
+	RecordClass string `json:"record_class,omitempty"`
+}
+
+// UpdateRRsetTTL changes the TTL of all values of a record set, leaving the
+// values in place. It needs the REST API.
+func (c *Client) UpdateRRsetTTL(ctx context.Context, zone, name, recordType string, req *RRsetTTLUpdateRequest) error {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records/" +
+		url.PathEscape(name) + "/" + url.PathEscape(recordType)
+
+	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(resp, nil)
+}
+
+// RRsetCommentUpdateRequest is the request for changing the comment of a
+// record set. An empty comment removes it.
+type RRsetCommentUpdateRequest struct {
+	Comment     string `json:"comment"`
+	RecordClass string `json:"record_class,omitempty"`
+}
+
+// UpdateRRsetComment changes the comment stored with a record set, leaving
+// its values in place. It needs the REST API.
+func (c *Client) UpdateRRsetComment(ctx context.Context, zone, name, recordType string, req *RRsetCommentUpdateRequest) error {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records/" +
+		url.PathEscape(name) + "/" + url.PathEscape(recordType)
+
+	resp, err := c.doRequest(ctx, "PATCH", path, req)
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(resp, nil)
+}
+
+// supportsRecordComments reports whether record set comments can be stored
+// and read back, which needs the REST API for records
+func (c *Client) supportsRecordComments() bool {
+	return c.dnsUpdate == nil && c.SupportsFeature(FeatureRecordComments)
+}
+
+// canUpdateRecord reports whether single record values can be changed in
+// place with UpdateRecord
+func (c *Client) canUpdateRecord() bool {
+	return c.dnsUpdate == nil && c.SupportsFeature(FeatureRecordUpdate)
+}
+
+// canReplaceRRset reports whether record sets can be replaced atomically
+// with ReplaceRRset
+func (c *Client) canReplaceRRset() bool {
+	return c.dnsUpdate != nil || c.SupportsFeature(FeatureRRsetReplace)
+}
+
 // ============================================================================
 // DNSSEC Operations
 // ============================================================================
@@ -445,36 +1623,44 @@ func (c *Client) DeleteDNSSECKey(ctx context.Context, zone string, keyTag int) e
 
 // SignZone signs a zone
 func (c *Client) SignZone(ctx context.Context, zone string) error {
-	resp, err := c.doRequest(ctx, "POST", "/api/v1/dnssec/zones/"+url.PathEscape(zone)+"/sign", nil)
-	if err != nil {
-		return err
-	}
-	return c.parseResponse(resp, nil)
+	return c.zoneControl(ctx, "sign", zone, func() error {
+		resp, err := c.doRequest(ctx, "POST", "/api/v1/dnssec/zones/"+url.PathEscape(zone)+"/sign", nil)
+		if err != nil {
+			return err
+		}
+		return c.parseResponse(resp, nil)
+	})
 }
 
 // ListRecords retrieves records for a zone with optional filters
 func (c *Client) ListRecords(ctx context.Context, zone string, params map[string]string) ([]Record, error) {
-	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records"
-
-	if len(params) > 0 {
-		query := url.Values{}
-		for k, v := range params {
-			if k == "type" {
-				query.Set("record_type", v)
-			} else {
-				query.Set(k, v)
-			}
+	query := url.Values{}
+	for k, v := range params {
+		if k == "type" {
+			query.Set("record_type", v)
+		} else {
+			query.Set(k, v)
 		}
-		path += "?" + query.Encode()
 	}
 
-	resp, err := c.doRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
+	return c.listRecordPages(ctx, zone, query)
+}
 
+// listRecordPages fetches all pages of a zone's record listing
+func (c *Client) listRecordPages(ctx context.Context, zone string, query url.Values) ([]Record, error) {
 	var records []Record
-	if err := c.parseResponse(resp, &records); err != nil {
+	err := c.listAll(ctx, "/api/v1/zones/"+url.PathEscape(zone)+"/records", query, func(body []byte) (int, error) {
+		var page []Record
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+		for i := range page {
+			page[i].RData = readRdata(page[i].Type, page[i].RData)
+		}
+		records = append(records, page...)
+		return len(page), nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,143 @@
+// CNAME coexistence checks
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// cnameCompanions are the record types allowed at the name of a CNAME: the
+// DNSSEC records that sign it (RFC 2181 section 10.1, RFC 4035 section 2.5)
+var cnameCompanions = map[string]bool{
+	"RRSIG": true,
+	"NSEC":  true,
+}
+
+// cnameConflict returns a record type among others that cannot exist at
+// the same name as recordType because one of them is CNAME, or "" if there
+// is none
+func cnameConflict(recordType string, others []string) string {
+	recordType = strings.ToUpper(recordType)
+	if cnameCompanions[recordType] {
+		return ""
+	}
+	for _, other := range others {
+		other = strings.ToUpper(other)
+		if other == recordType || cnameCompanions[other] {
+			continue
+		}
+		if recordType == "CNAME" || other == "CNAME" {
+			return other
+		}
+	}
+	return ""
+}
+
+// plannedTypes remembers the record types planned for creation at each name
+// by this provider process, so that a CNAME and other records planned for
+// the same name in one run are reported at plan time
+type plannedTypes struct {
+	mu sync.Mutex
+	// Record types by zone and name, in lower case
+	types map[string]map[string]bool
+}
+
+// newPlannedTypes creates an empty registry of planned record types
+func newPlannedTypes() *plannedTypes {
+	return &plannedTypes{types: make(map[string]map[string]bool)}
+}
+
+// add records a record type planned at a name and returns the other types
+// planned at the same name so far
+func (p *plannedTypes) add(zone, name, recordType string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := cacheKey(zone) + "/" + strings.ToLower(name)
+	planned, ok := p.types[key]
+	if !ok {
+		planned = make(map[string]bool)
+		p.types[key] = planned
+	}
+	planned[strings.ToUpper(recordType)] = true
+
+	var others []string
+	for t := range planned {
+		others = append(others, t)
+	}
+	sort.Strings(others)
+	return others
+}
+
+// existingTypes returns the record types that exist at a name on the server
+func (c *Client) existingTypes(ctx context.Context, zone, name, class string) ([]string, error) {
+	records, err := c.ReadRecords(ctx, zone, "", name, class)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var types []string
+	for _, rec := range records {
+		t := strings.ToUpper(rec.Type)
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	return types, nil
+}
+
+// planCNAMEConflict checks a record set planned for creation against the
+// records at its name on the server and those planned for creation in the
+// same run. A conflict with another planned creation is an error. A conflict
+// with the server is a warning, since the conflicting records may be
+// destroyed in the same run.
+func planCNAMEConflict(ctx context.Context, client *Client, zone, name, class, recordType string, diags *diag.Diagnostics) {
+	if conflict := cnameConflict(recordType, client.plannedTypes.add(zone, name, recordType)); conflict != "" {
+		addCodedAttributeError(diags, path.Root("type"), ErrCodeConflict, "CNAME Conflict",
+			cnameConflictDetail(zone, name, conflict, "are also planned for creation"))
+		return
+	}
+
+	existing, err := client.existingTypes(ctx, zone, name, class)
+	if err != nil {
+		// The zone may be created in the same run
+		tflog.Debug(ctx, "Could not check records at name for CNAME conflicts", map[string]any{"error": err.Error()})
+		return
+	}
+	if conflict := cnameConflict(recordType, existing); conflict != "" {
+		diags.AddAttributeWarning(path.Root("type"), "CNAME Conflict",
+			cnameConflictDetail(zone, name, conflict, "exist on the server")+
+				" Creating the record fails unless the existing records are destroyed first; "+
+				"if this run destroys them, add depends_on so they are destroyed before this record is created.")
+	}
+}
+
+// checkCNAMEConflict fails the creation of a record set that cannot coexist
+// with the records at its name on the server
+func checkCNAMEConflict(ctx context.Context, client *Client, zone, name, class, recordType string, diags *diag.Diagnostics) {
+	existing, err := client.existingTypes(ctx, zone, name, class)
+	if err != nil {
+		addAPIError(diags, "Error Checking Records", fmt.Sprintf("Could not read the records at %s in zone %s", name, zone), err)
+		return
+	}
+	if conflict := cnameConflict(recordType, existing); conflict != "" {
+		addCodedAttributeError(diags, path.Root("type"), ErrCodeConflict, "CNAME Conflict",
+			cnameConflictDetail(zone, name, conflict, "exist on the server"))
+	}
+}
+
+// cnameConflictDetail describes a conflict with a record set of type
+// conflict at the name
+func cnameConflictDetail(zone, name, conflict, where string) string {
+	return fmt.Sprintf("A CNAME record cannot coexist with other records at the same name (RFC 1034 section 3.6.2), "+
+		"and %s records at %s %s, so BIND would reject the change.", conflict, recordFQDN(zone, name), where)
+}
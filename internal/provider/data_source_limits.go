@@ -0,0 +1,147 @@
+// Limits Data Source
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &LimitsDataSource{}
+
+// NewLimitsDataSource creates a new limits data source
+func NewLimitsDataSource() datasource.DataSource {
+	return &LimitsDataSource{}
+}
+
+// LimitsDataSource defines the data source implementation
+type LimitsDataSource struct {
+	client *Client
+}
+
+// LimitsDataSourceModel describes the data source data model
+type LimitsDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	MaxZones          types.Int64  `tfsdk:"max_zones"`
+	MaxRecordsPerZone types.Int64  `tfsdk:"max_records_per_zone"`
+	ZoneCount         types.Int64  `tfsdk:"zone_count"`
+	ZonesRemaining    types.Int64  `tfsdk:"zones_remaining"`
+}
+
+// Metadata returns the data source type name
+func (d *LimitsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_limits"
+}
+
+// Schema defines the schema for the data source
+func (d *LimitsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves the per-tenant limits enforced by the BIND9 REST API server.",
+		MarkdownDescription: `
+Retrieves the per-tenant limits enforced by the BIND9 REST API server, such as the maximum
+number of zones and of records per zone, along with the current zone usage.
+
+Limits the server does not enforce are null. Servers without the limits endpoint enforce none.
+
+## Example Usage
+
+` + "```hcl" + `
+data "bind9_limits" "current" {}
+
+check "zone_quota" {
+  assert {
+    condition     = data.bind9_limits.current.zones_remaining == null || data.bind9_limits.current.zones_remaining >= 10
+    error_message = "Fewer than 10 zones are left in the DNS server quota."
+  }
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier",
+				Computed:    true,
+			},
+			"max_zones": schema.Int64Attribute{
+				Description: "Maximum number of zones, or null when not limited",
+				Computed:    true,
+			},
+			"max_records_per_zone": schema.Int64Attribute{
+				Description: "Maximum number of records in a zone, or null when not limited",
+				Computed:    true,
+			},
+			"zone_count": schema.Int64Attribute{
+				Description: "Number of zones currently on the server",
+				Computed:    true,
+			},
+			"zones_remaining": schema.Int64Attribute{
+				Description: "Number of zones that can still be created, or null when not limited",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *LimitsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *LimitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_limits.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Reading limits")
+
+	limits, err := d.client.limits(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Limits", "Could not read the server limits", err)
+		return
+	}
+	if limits == nil {
+		limits = &Limits{}
+	}
+
+	zones, err := d.client.ListZones(ctx, nil)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Limits", "Could not list zones", err)
+		return
+	}
+
+	state := LimitsDataSourceModel{
+		ID:                types.StringValue("limits"),
+		MaxZones:          types.Int64Null(),
+		MaxRecordsPerZone: types.Int64Null(),
+		ZoneCount:         types.Int64Value(int64(len(zones))),
+		ZonesRemaining:    types.Int64Null(),
+	}
+	if limits.MaxZones > 0 {
+		state.MaxZones = types.Int64Value(limits.MaxZones)
+		state.ZonesRemaining = types.Int64Value(max(limits.MaxZones-int64(len(zones)), 0))
+	}
+	if limits.MaxRecordsPerZone > 0 {
+		state.MaxRecordsPerZone = types.Int64Value(limits.MaxRecordsPerZone)
+	}
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -31,6 +31,7 @@ type RecordDataSourceModel struct {
 	Zone    types.String `tfsdk:"zone"`
 	Name    types.String `tfsdk:"name"`
 	Type    types.String `tfsdk:"type"`
+	Class   types.String `tfsdk:"class"`
 	TTL     types.Int64  `tfsdk:"ttl"`
 	Records types.List   `tfsdk:"records"`
 }
@@ -78,6 +79,11 @@ output "www_ips" {
 				Description: "Record type",
 				Required:    true,
 			},
+			"class": schema.StringAttribute{
+				Description: "Record class (IN, CH, HS). Default: IN",
+				Optional:    true,
+				Computed:    true,
+			},
 			"ttl": schema.Int64Attribute{
 				Description: "Record TTL",
 				Computed:    true,
@@ -111,6 +117,9 @@ func (d *RecordDataSource) Configure(ctx context.Context, req datasource.Configu
 
 // Read refreshes the Terraform state with the latest data
 func (d *RecordDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_record.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var config RecordDataSourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -124,17 +133,21 @@ func (d *RecordDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		"type": config.Type.ValueString(),
 	})
 
-	records, err := d.client.GetRecords(ctx, config.Zone.ValueString(), config.Type.ValueString(), config.Name.ValueString())
+	class := "IN"
+	if !config.Class.IsNull() {
+		class = config.Class.ValueString()
+	}
+
+	records, err := d.client.GetRecords(ctx, config.Zone.ValueString(), config.Type.ValueString(), config.Name.ValueString(), class)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Record",
-			"Could not read record: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Reading Record", "Could not read record", err)
 		return
 	}
 
 	if len(records) == 0 {
-		resp.Diagnostics.AddError(
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeNotFound,
 			"Record Not Found",
 			fmt.Sprintf("No records found for %s %s in zone %s", config.Name.ValueString(), config.Type.ValueString(), config.Zone.ValueString()),
 		)
@@ -143,6 +156,7 @@ func (d *RecordDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	config.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", config.Zone.ValueString(), config.Name.ValueString(), config.Type.ValueString()))
 	config.TTL = types.Int64Value(int64(records[0].TTL))
+	config.Class = types.StringValue(class)
 
 	var recordValues []string
 	for _, r := range records {
@@ -181,6 +195,7 @@ type RecordsDataSource struct {
 type RecordsListModel struct {
 	Name  types.String `tfsdk:"name"`
 	Type  types.String `tfsdk:"type"`
+	Class types.String `tfsdk:"class"`
 	TTL   types.Int64  `tfsdk:"ttl"`
 	RData types.String `tfsdk:"rdata"`
 }
@@ -191,6 +206,7 @@ type RecordsDataSourceModel struct {
 	Zone    types.String       `tfsdk:"zone"`
 	Type    types.String       `tfsdk:"type"`
 	Name    types.String       `tfsdk:"name"`
+	Class   types.String       `tfsdk:"class"`
 	Records []RecordsListModel `tfsdk:"records"`
 }
 
@@ -244,6 +260,10 @@ data "bind9_records" "www" {
 				Description: "Filter by record name",
 				Optional:    true,
 			},
+			"class": schema.StringAttribute{
+				Description: "Filter by record class (IN, CH, HS)",
+				Optional:    true,
+			},
 			"records": schema.ListNestedAttribute{
 				Description: "List of records",
 				Computed:    true,
@@ -257,6 +277,10 @@ data "bind9_records" "www" {
 							Description: "Record type",
 							Computed:    true,
 						},
+						"class": schema.StringAttribute{
+							Description: "Record class",
+							Computed:    true,
+						},
 						"ttl": schema.Int64Attribute{
 							Description: "Record TTL",
 							Computed:    true,
@@ -292,6 +316,9 @@ func (d *RecordsDataSource) Configure(ctx context.Context, req datasource.Config
 
 // Read refreshes the Terraform state with the latest data
 func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_records.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var config RecordsDataSourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -313,12 +340,14 @@ func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		name = config.Name.ValueString()
 	}
 
-	records, err := d.client.GetRecords(ctx, config.Zone.ValueString(), recordType, name)
+	class := ""
+	if !config.Class.IsNull() {
+		class = config.Class.ValueString()
+	}
+
+	records, err := d.client.GetRecords(ctx, config.Zone.ValueString(), recordType, name, class)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Records",
-			"Could not read records: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Reading Records", "Could not read records", err)
 		return
 	}
 
@@ -329,6 +358,7 @@ func (d *RecordsDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		config.Records = append(config.Records, RecordsListModel{
 			Name:  types.StringValue(r.Name),
 			Type:  types.StringValue(r.Type),
+			Class: types.StringValue(recordClass(r)),
 			TTL:   types.Int64Value(int64(r.TTL)),
 			RData: types.StringValue(r.RData),
 		})
@@ -0,0 +1,653 @@
+// Statistics Channel Data Sources
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ datasource.DataSource = &ServerStatsDataSource{}
+	_ datasource.DataSource = &CacheStatsDataSource{}
+	_ datasource.DataSource = &ZoneStatsDataSource{}
+	_ datasource.DataSource = &ZonesStatsDataSource{}
+)
+
+// counterMap converts statistics counters into a Terraform map of numbers
+func counterMap(ctx context.Context, counters map[string]int64) (types.Map, diag.Diagnostics) {
+	if counters == nil {
+		counters = map[string]int64{}
+	}
+	return types.MapValueFrom(ctx, types.Int64Type, counters)
+}
+
+// configureStatsClient extracts the provider client for the statistics data sources
+func configureStatsClient(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *Client {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return nil
+	}
+
+	return client
+}
+
+// ============================================================================
+// Server Stats Data Source
+// ============================================================================
+
+// NewServerStatsDataSource creates a new server stats data source
+func NewServerStatsDataSource() datasource.DataSource {
+	return &ServerStatsDataSource{}
+}
+
+// ServerStatsDataSource defines the data source implementation
+type ServerStatsDataSource struct {
+	client *Client
+}
+
+// ServerStatsDataSourceModel describes the data source data model
+type ServerStatsDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Version     types.String `tfsdk:"version"`
+	BootTime    types.String `tfsdk:"boot_time"`
+	ConfigTime  types.String `tfsdk:"config_time"`
+	CurrentTime types.String `tfsdk:"current_time"`
+	Queries     types.Int64  `tfsdk:"queries"`
+	Opcodes     types.Map    `tfsdk:"opcodes"`
+	Rcodes      types.Map    `tfsdk:"rcodes"`
+	QTypes      types.Map    `tfsdk:"qtypes"`
+	NSStats     types.Map    `tfsdk:"nsstats"`
+}
+
+// Metadata returns the data source type name
+func (d *ServerStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *ServerStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves server-wide query and response counters from the BIND9 statistics channel.",
+		MarkdownDescription: `
+Retrieves server-wide query and response counters from the BIND9 statistics channel.
+Requires ` + "`statistics_url`" + ` in the provider configuration.
+
+## Example Usage
+
+` + "```hcl" + `
+data "bind9_server_stats" "this" {}
+
+output "queries" {
+  value = data.bind9_server_stats.this.queries
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (always \"server\")",
+				Computed:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "BIND9 version",
+				Computed:    true,
+			},
+			"boot_time": schema.StringAttribute{
+				Description: "Time the server started",
+				Computed:    true,
+			},
+			"config_time": schema.StringAttribute{
+				Description: "Time the configuration was last loaded",
+				Computed:    true,
+			},
+			"current_time": schema.StringAttribute{
+				Description: "Server time when the statistics were taken",
+				Computed:    true,
+			},
+			"queries": schema.Int64Attribute{
+				Description: "Number of queries received (QUERY opcode)",
+				Computed:    true,
+			},
+			"opcodes": schema.MapAttribute{
+				Description: "Requests received by opcode",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"rcodes": schema.MapAttribute{
+				Description: "Responses sent by response code",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"qtypes": schema.MapAttribute{
+				Description: "Queries received by query type",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"nsstats": schema.MapAttribute{
+				Description: "Name server statistics counters (e.g. QryAuthAns, QryNXDOMAIN)",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *ServerStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureStatsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ServerStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_server_stats.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	tflog.Debug(ctx, "Reading server statistics")
+
+	stats, err := d.client.GetServerStats(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Server Statistics", "Could not read the BIND9 statistics channel", err)
+		return
+	}
+
+	state := ServerStatsDataSourceModel{
+		ID:          types.StringValue("server"),
+		Version:     types.StringValue(stats.Version),
+		BootTime:    types.StringValue(stats.BootTime),
+		ConfigTime:  types.StringValue(stats.ConfigTime),
+		CurrentTime: types.StringValue(stats.CurrentTime),
+		Queries:     types.Int64Value(stats.Opcodes["QUERY"]),
+	}
+
+	var diags diag.Diagnostics
+	state.Opcodes, diags = counterMap(ctx, stats.Opcodes)
+	resp.Diagnostics.Append(diags...)
+	state.Rcodes, diags = counterMap(ctx, stats.Rcodes)
+	resp.Diagnostics.Append(diags...)
+	state.QTypes, diags = counterMap(ctx, stats.QTypes)
+	resp.Diagnostics.Append(diags...)
+	state.NSStats, diags = counterMap(ctx, stats.NSStats)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ============================================================================
+// Cache Stats Data Source
+// ============================================================================
+
+// NewCacheStatsDataSource creates a new cache stats data source
+func NewCacheStatsDataSource() datasource.DataSource {
+	return &CacheStatsDataSource{}
+}
+
+// CacheStatsDataSource defines the data source implementation
+type CacheStatsDataSource struct {
+	client *Client
+}
+
+// CacheStatsDataSourceModel describes the data source data model
+type CacheStatsDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	View        types.String `tfsdk:"view"`
+	CacheHits   types.Int64  `tfsdk:"cache_hits"`
+	CacheMisses types.Int64  `tfsdk:"cache_misses"`
+	QueryHits   types.Int64  `tfsdk:"query_hits"`
+	QueryMisses types.Int64  `tfsdk:"query_misses"`
+	RRsets      types.Map    `tfsdk:"rrsets"`
+	Stats       types.Map    `tfsdk:"stats"`
+}
+
+// Metadata returns the data source type name
+func (d *CacheStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cache_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *CacheStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves resolver cache statistics of a view from the BIND9 statistics channel.",
+		MarkdownDescription: `
+Retrieves resolver cache statistics of a view from the BIND9 statistics channel.
+Requires ` + "`statistics_url`" + ` in the provider configuration.
+
+## Example Usage
+
+` + "```hcl" + `
+data "bind9_cache_stats" "default" {}
+
+output "cache_hits" {
+  value = data.bind9_cache_stats.default.query_hits
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as view)",
+				Computed:    true,
+			},
+			"view": schema.StringAttribute{
+				Description: "View name. Default: _default",
+				Optional:    true,
+				Computed:    true,
+			},
+			"cache_hits": schema.Int64Attribute{
+				Description: "Cache lookups that found data",
+				Computed:    true,
+			},
+			"cache_misses": schema.Int64Attribute{
+				Description: "Cache lookups that found no data",
+				Computed:    true,
+			},
+			"query_hits": schema.Int64Attribute{
+				Description: "Client queries answered from the cache",
+				Computed:    true,
+			},
+			"query_misses": schema.Int64Attribute{
+				Description: "Client queries not answered from the cache",
+				Computed:    true,
+			},
+			"rrsets": schema.MapAttribute{
+				Description: "Cached RRsets by type",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"stats": schema.MapAttribute{
+				Description: "All cache statistics counters of the view",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *CacheStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureStatsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *CacheStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_cache_stats.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var config CacheStatsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	view := "_default"
+	if !config.View.IsNull() {
+		view = config.View.ValueString()
+	}
+
+	tflog.Debug(ctx, "Reading cache statistics", map[string]any{"view": view})
+
+	stats, err := d.client.GetServerStats(ctx)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Cache Statistics", "Could not read the BIND9 statistics channel", err)
+		return
+	}
+
+	v, ok := stats.Views[view]
+	if !ok {
+		addCodedAttributeError(
+			&resp.Diagnostics,
+			path.Root("view"),
+			ErrCodeNotFound,
+			"View Not Found",
+			fmt.Sprintf("The statistics channel has no view named %s.", view),
+		)
+		return
+	}
+
+	cache := v.Resolver.CacheStats
+	config.ID = types.StringValue(view)
+	config.View = types.StringValue(view)
+	config.CacheHits = types.Int64Value(cache["CacheHits"])
+	config.CacheMisses = types.Int64Value(cache["CacheMisses"])
+	config.QueryHits = types.Int64Value(cache["QueryHits"])
+	config.QueryMisses = types.Int64Value(cache["QueryMisses"])
+
+	config.RRsets, diags = counterMap(ctx, v.Resolver.Cache)
+	resp.Diagnostics.Append(diags...)
+	config.Stats, diags = counterMap(ctx, cache)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ============================================================================
+// Zone Stats Data Source
+// ============================================================================
+
+// NewZoneStatsDataSource creates a new zone stats data source
+func NewZoneStatsDataSource() datasource.DataSource {
+	return &ZoneStatsDataSource{}
+}
+
+// ZoneStatsDataSource defines the data source implementation
+type ZoneStatsDataSource struct {
+	client *Client
+}
+
+// ZoneStatsDataSourceModel describes the data source data model
+type ZoneStatsDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Zone      types.String `tfsdk:"zone"`
+	View      types.String `tfsdk:"view"`
+	Class     types.String `tfsdk:"class"`
+	Type      types.String `tfsdk:"type"`
+	Serial    types.Int64  `tfsdk:"serial"`
+	Loaded    types.String `tfsdk:"loaded"`
+	Queries   types.Int64  `tfsdk:"queries"`
+	Transfers types.Int64  `tfsdk:"transfers"`
+	Rcodes    types.Map    `tfsdk:"rcodes"`
+	QTypes    types.Map    `tfsdk:"qtypes"`
+}
+
+// Metadata returns the data source type name
+func (d *ZoneStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *ZoneStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves per-zone metrics from the BIND9 statistics channel.",
+		MarkdownDescription: `
+Retrieves per-zone metrics from the BIND9 statistics channel. Query counters are
+only reported for zones with ` + "`zone-statistics full;`" + ` enabled in named.conf.
+Requires ` + "`statistics_url`" + ` in the provider configuration.
+
+## Example Usage
+
+` + "```hcl" + `
+data "bind9_zone_stats" "example" {
+  zone = "example.com"
+}
+
+output "nxdomain_answers" {
+  value = lookup(data.bind9_zone_stats.example.rcodes, "QryNXDOMAIN", 0)
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as zone)",
+				Computed:    true,
+			},
+			"zone": schema.StringAttribute{
+				Description: "Zone name",
+				Required:    true,
+			},
+			"view": schema.StringAttribute{
+				Description: "View containing the zone. Default: the first view, in name order, that has the zone",
+				Optional:    true,
+			},
+			"class": schema.StringAttribute{
+				Description: "Zone class",
+				Computed:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Zone type as reported by BIND9 (primary, secondary, ...)",
+				Computed:    true,
+			},
+			"serial": schema.Int64Attribute{
+				Description: "Zone serial",
+				Computed:    true,
+			},
+			"loaded": schema.StringAttribute{
+				Description: "Time the zone was last loaded",
+				Computed:    true,
+			},
+			"queries": schema.Int64Attribute{
+				Description: "Number of queries received for the zone, the sum of qtypes",
+				Computed:    true,
+			},
+			"transfers": schema.Int64Attribute{
+				Description: "Number of zone transfers (AXFR and IXFR) served for the zone",
+				Computed:    true,
+			},
+			"rcodes": schema.MapAttribute{
+				Description: "Per-zone query result counters (e.g. QryAuthAns, QryNXDOMAIN)",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+			"qtypes": schema.MapAttribute{
+				Description: "Per-zone queries by query type",
+				Computed:    true,
+				ElementType: types.Int64Type,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *ZoneStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureStatsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ZoneStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_zone_stats.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var config ZoneStatsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading zone statistics", map[string]any{"zone": config.Zone.ValueString()})
+
+	stats, err := d.client.GetZoneStats(ctx, config.Zone.ValueString(), config.View.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Zone Statistics", "Could not read the BIND9 statistics channel", err)
+		return
+	}
+
+	config.ID = types.StringValue(config.Zone.ValueString())
+	config.Class = types.StringValue(stats.Class)
+	config.Type = types.StringValue(stats.Type)
+	config.Serial = types.Int64Value(stats.Serial)
+	config.Loaded = types.StringValue(stats.Loaded)
+	config.Queries = types.Int64Value(stats.queryCount())
+	config.Transfers = types.Int64Value(stats.transferCount())
+
+	config.Rcodes, diags = counterMap(ctx, stats.Rcodes)
+	resp.Diagnostics.Append(diags...)
+	config.QTypes, diags = counterMap(ctx, stats.QTypes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ============================================================================
+// Zones Stats Data Source
+// ============================================================================
+
+// NewZonesStatsDataSource creates a new zones stats data source
+func NewZonesStatsDataSource() datasource.DataSource {
+	return &ZonesStatsDataSource{}
+}
+
+// ZonesStatsDataSource defines the data source implementation
+type ZonesStatsDataSource struct {
+	client *Client
+}
+
+// ZonesStatsDataSourceModel describes the data source data model
+type ZonesStatsDataSourceModel struct {
+	ID    types.String     `tfsdk:"id"`
+	View  types.String     `tfsdk:"view"`
+	Zones []ZoneUsageModel `tfsdk:"zones"`
+}
+
+// ZoneUsageModel describes the usage counters of one zone
+type ZoneUsageModel struct {
+	Zone      types.String `tfsdk:"zone"`
+	View      types.String `tfsdk:"view"`
+	Class     types.String `tfsdk:"class"`
+	Type      types.String `tfsdk:"type"`
+	Serial    types.Int64  `tfsdk:"serial"`
+	Queries   types.Int64  `tfsdk:"queries"`
+	Transfers types.Int64  `tfsdk:"transfers"`
+}
+
+// Metadata returns the data source type name
+func (d *ZonesStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zones_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *ZonesStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves query and transfer counters of all zones from the BIND9 statistics channel.",
+		MarkdownDescription: `
+Retrieves query and transfer counters of all zones from the BIND9 statistics
+channel in a single request. Query counters are only reported for zones with
+` + "`zone-statistics full;`" + ` enabled in named.conf.
+Requires ` + "`statistics_url`" + ` in the provider configuration.
+
+## Example Usage
+
+` + "```hcl" + `
+data "bind9_zones_stats" "all" {}
+
+output "unused_zones" {
+  value = [for z in data.bind9_zones_stats.all.zones : z.zone if z.queries == 0]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier",
+				Computed:    true,
+			},
+			"view": schema.StringAttribute{
+				Description: "Only return zones in this view. Default: zones in all views",
+				Optional:    true,
+			},
+			"zones": schema.ListNestedAttribute{
+				Description: "Zones with their counters, ordered by view",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"zone": schema.StringAttribute{
+							Description: "Zone name",
+							Computed:    true,
+						},
+						"view": schema.StringAttribute{
+							Description: "View containing the zone",
+							Computed:    true,
+						},
+						"class": schema.StringAttribute{
+							Description: "Zone class",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Zone type as reported by BIND9 (primary, secondary, ...)",
+							Computed:    true,
+						},
+						"serial": schema.Int64Attribute{
+							Description: "Zone serial",
+							Computed:    true,
+						},
+						"queries": schema.Int64Attribute{
+							Description: "Number of queries received for the zone",
+							Computed:    true,
+						},
+						"transfers": schema.Int64Attribute{
+							Description: "Number of zone transfers (AXFR and IXFR) served for the zone",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *ZonesStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureStatsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *ZonesStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_zones_stats.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var config ZonesStatsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading statistics of all zones", map[string]any{"view": config.View.ValueString()})
+
+	zones, err := d.client.ListZoneStats(ctx, config.View.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Zone Statistics", "Could not read the BIND9 statistics channel", err)
+		return
+	}
+
+	config.ID = types.StringValue("zones_stats")
+	config.Zones = make([]ZoneUsageModel, 0, len(zones))
+	for i := range zones {
+		z := &zones[i]
+		config.Zones = append(config.Zones, ZoneUsageModel{
+			Zone:      types.StringValue(z.Name),
+			View:      types.StringValue(z.View),
+			Class:     types.StringValue(z.Class),
+			Type:      types.StringValue(z.Type),
+			Serial:    types.Int64Value(z.Serial),
+			Queries:   types.Int64Value(z.queryCount()),
+			Transfers: types.Int64Value(z.transferCount()),
+		})
+	}
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
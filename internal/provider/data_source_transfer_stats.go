@@ -0,0 +1,171 @@
+// Transfer Stats Data Source
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var _ datasource.DataSource = &TransferStatsDataSource{}
+
+// NewTransferStatsDataSource creates a new transfer stats data source
+func NewTransferStatsDataSource() datasource.DataSource {
+	return &TransferStatsDataSource{}
+}
+
+// TransferStatsDataSource defines the data source implementation
+type TransferStatsDataSource struct {
+	client *Client
+}
+
+// TransferStatsDataSourceModel describes the data source data model
+type TransferStatsDataSourceModel struct {
+	ID           types.String  `tfsdk:"id"`
+	Zone         types.String  `tfsdk:"zone"`
+	Primary      types.String  `tfsdk:"primary"`
+	TransferType types.String  `tfsdk:"transfer_type"`
+	FullTransfer types.Bool    `tfsdk:"full_transfer"`
+	LastTransfer types.String  `tfsdk:"last_transfer"`
+	DurationMs   types.Float64 `tfsdk:"duration_ms"`
+	Bytes        types.Int64   `tfsdk:"bytes"`
+	Messages     types.Int64   `tfsdk:"messages"`
+	Records      types.Int64   `tfsdk:"records"`
+	Serial       types.Int64   `tfsdk:"serial"`
+}
+
+// Metadata returns the data source type name
+func (d *TransferStatsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_transfer_stats"
+}
+
+// Schema defines the schema for the data source
+func (d *TransferStatsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Retrieves statistics about the last zone transfer of a secondary zone.",
+		MarkdownDescription: `
+Retrieves statistics about the most recent inbound zone transfer of a secondary (slave) zone,
+including whether an incremental (IXFR) or full (AXFR) transfer was used.
+
+## Example Usage
+
+` + "```hcl" + `
+data "bind9_transfer_stats" "example" {
+  zone = "example.com"
+}
+
+output "fell_back_to_axfr" {
+  value = data.bind9_transfer_stats.example.full_transfer
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Data source identifier (same as zone)",
+				Computed:    true,
+			},
+			"zone": schema.StringAttribute{
+				Description: "Secondary zone name",
+				Required:    true,
+			},
+			"primary": schema.StringAttribute{
+				Description: "Address of the primary the zone was transferred from",
+				Computed:    true,
+			},
+			"transfer_type": schema.StringAttribute{
+				Description: "Transfer type used (IXFR or AXFR)",
+				Computed:    true,
+			},
+			"full_transfer": schema.BoolAttribute{
+				Description: "Whether the last transfer was a full transfer (AXFR)",
+				Computed:    true,
+			},
+			"last_transfer": schema.StringAttribute{
+				Description: "Completion time of the last transfer (RFC 3339)",
+				Computed:    true,
+			},
+			"duration_ms": schema.Float64Attribute{
+				Description: "Duration of the last transfer in milliseconds",
+				Computed:    true,
+			},
+			"bytes": schema.Int64Attribute{
+				Description: "Number of bytes received in the last transfer",
+				Computed:    true,
+			},
+			"messages": schema.Int64Attribute{
+				Description: "Number of DNS messages received in the last transfer",
+				Computed:    true,
+			},
+			"records": schema.Int64Attribute{
+				Description: "Number of records received in the last transfer",
+				Computed:    true,
+			},
+			"serial": schema.Int64Attribute{
+				Description: "Zone serial after the last transfer",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *TransferStatsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *TransferStatsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_transfer_stats.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var config TransferStatsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading transfer stats", map[string]any{"zone": config.Zone.ValueString()})
+
+	stats, err := d.client.GetTransferStats(ctx, config.Zone.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Transfer Stats", "Could not read transfer statistics", err)
+		return
+	}
+
+	transferType := strings.ToUpper(stats.TransferType)
+
+	config.ID = types.StringValue(config.Zone.ValueString())
+	config.Primary = types.StringValue(stats.Primary)
+	config.TransferType = types.StringValue(transferType)
+	config.FullTransfer = types.BoolValue(transferType == "AXFR")
+	config.LastTransfer = types.StringValue(stats.LastTransfer)
+	config.DurationMs = types.Float64Value(stats.DurationMs)
+	config.Bytes = types.Int64Value(stats.Bytes)
+	config.Messages = types.Int64Value(stats.Messages)
+	config.Records = types.Int64Value(stats.Records)
+	config.Serial = types.Int64Value(stats.Serial)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+}
@@ -30,6 +30,7 @@ type ZoneDataSourceModel struct {
 	ID            types.String `tfsdk:"id"`
 	Name          types.String `tfsdk:"name"`
 	Type          types.String `tfsdk:"type"`
+	Class         types.String `tfsdk:"class"`
 	File          types.String `tfsdk:"file"`
 	Serial        types.Int64  `tfsdk:"serial"`
 	Loaded        types.Bool   `tfsdk:"loaded"`
@@ -74,6 +75,10 @@ output "zone_serial" {
 				Description: "Zone type (master, slave, forward, stub)",
 				Computed:    true,
 			},
+			"class": schema.StringAttribute{
+				Description: "Zone class (IN, CH, HS)",
+				Computed:    true,
+			},
 			"file": schema.StringAttribute{
 				Description: "Zone file path",
 				Computed:    true,
@@ -118,6 +123,9 @@ func (d *ZoneDataSource) Configure(ctx context.Context, req datasource.Configure
 
 // Read refreshes the Terraform state with the latest data
 func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_zone.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var config ZoneDataSourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -129,15 +137,13 @@ func (d *ZoneDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	zone, err := d.client.GetZone(ctx, config.Name.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Zone",
-			"Could not read zone: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Reading Zone", "Could not read zone", err)
 		return
 	}
 
 	config.ID = types.StringValue(zone.Name)
 	config.Type = types.StringValue(zone.Type)
+	config.Class = types.StringValue(zoneClass(*zone))
 	config.Serial = types.Int64Value(zone.Serial)
 	config.Loaded = types.BoolValue(zone.Loaded)
 	config.DNSSECEnabled = types.BoolValue(zone.DNSSECEnabled)
@@ -226,6 +232,9 @@ data "bind9_zones" "masters" {
 						"type": schema.StringAttribute{
 							Computed: true,
 						},
+						"class": schema.StringAttribute{
+							Computed: true,
+						},
 						"file": schema.StringAttribute{
 							Computed: true,
 						},
@@ -268,6 +277,9 @@ func (d *ZonesDataSource) Configure(ctx context.Context, req datasource.Configur
 
 // Read refreshes the Terraform state with the latest data
 func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := startSpan(ctx, "data.bind9_zones.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var config ZonesDataSourceModel
 	diags := req.Config.Get(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -287,10 +299,7 @@ func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	zones, err := d.client.ListZones(ctx, params)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Zones",
-			"Could not read zones: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Reading Zones", "Could not read zones", err)
 		return
 	}
 
@@ -306,6 +315,7 @@ func (d *ZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			ID:            types.StringValue(zone.Name),
 			Name:          types.StringValue(zone.Name),
 			Type:          types.StringValue(zone.Type),
+			Class:         types.StringValue(zoneClass(zone)),
 			Serial:        types.Int64Value(zone.Serial),
 			Loaded:        types.BoolValue(zone.Loaded),
 			DNSSECEnabled: types.BoolValue(zone.DNSSECEnabled),
@@ -0,0 +1,213 @@
+// Structured diagnostics with stable error codes
+
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/miekg/dns"
+)
+
+// Stable error codes attached to error diagnostics. Automation consuming
+// `terraform plan -json` output can match on the "Error code:" line of the
+// diagnostic detail to classify failures.
+const (
+	ErrCodeAuthFailed         = "BIND9_AUTH_FAILED"
+	ErrCodeConnectionFailed   = "BIND9_CONNECTION_FAILED"
+	ErrCodeConfigInvalid      = "BIND9_CONFIG_INVALID"
+	ErrCodeFeatureUnsupported = "BIND9_FEATURE_UNSUPPORTED"
+	ErrCodeNotFound           = "BIND9_NOT_FOUND"
+	ErrCodeZoneNotLoaded      = "BIND9_ZONE_NOT_LOADED"
+	ErrCodeRdataInvalid       = "BIND9_RDATA_INVALID"
+	ErrCodeInvalidRequest     = "BIND9_INVALID_REQUEST"
+	ErrCodeConflict           = "BIND9_CONFLICT"
+	ErrCodeAPIError           = "BIND9_API_ERROR"
+	ErrCodeNotPropagated      = "BIND9_NOT_PROPAGATED"
+	ErrCodeCanceled           = "BIND9_CANCELED"
+)
+
+// errorHints holds the remediation hint shown for each error code
+var errorHints = map[string]string{
+	ErrCodeAuthFailed:         "Check api_key (or token, or username/password), whether the token has expired, and the permissions granted to the key on the API server.",
+	ErrCodeConnectionFailed:   "Check the endpoint URL, network access to the API, and TLS settings.",
+	ErrCodeConfigInvalid:      "Correct the provider or resource configuration and run the command again.",
+	ErrCodeFeatureUnsupported: "Upgrade the BIND9 REST API server to a version that provides this feature.",
+	ErrCodeNotFound:           "Verify that the zone or object exists on the server, or import it into state.",
+	ErrCodeZoneNotLoaded:      "Check the zone file and BIND9 logs (named-checkzone), then reload the zone.",
+	ErrCodeRdataInvalid:       "Check the record data format for the record type; see the bind9_record documentation.",
+	ErrCodeInvalidRequest:     "The API rejected the request as invalid. Check the resource arguments against the documentation.",
+	ErrCodeConflict:           "The object was changed or already exists on the server. Refresh state and retry.",
+	ErrCodeAPIError:           "Check the BIND9 REST API server logs for details.",
+	ErrCodeNotPropagated:      "Check that the wait_for nameservers are authoritative for the zone and receive its updates (NOTIFY and zone transfers), or raise the timeout.",
+	ErrCodeCanceled:           "The operation was interrupted, for example by Ctrl-C. Run the command again; changes made before the interruption are picked up by the next refresh.",
+}
+
+// withErrorCode appends the error code and its remediation hint to a detail message
+func withErrorCode(code, detail string) string {
+	return fmt.Sprintf("%s\n\nError code: %s\nHint: %s", detail, code, errorHints[code])
+}
+
+// addCodedError adds an error diagnostic tagged with the given error code
+func addCodedError(diags *diag.Diagnostics, code, summary, detail string) {
+	diags.AddError(summary, withErrorCode(code, detail))
+}
+
+// addCodedAttributeError adds an attribute error diagnostic tagged with the given error code
+func addCodedAttributeError(diags *diag.Diagnostics, p path.Path, code, summary, detail string) {
+	diags.AddAttributeError(p, summary, withErrorCode(code, detail))
+}
+
+// addAPIError adds an error diagnostic for a failed API call. The error is
+// classified into an error code, and its message appended to the detail.
+func addAPIError(diags *diag.Diagnostics, summary, detail string, err error) {
+	if isPreconditionFailed(err) {
+		addChangedOutOfBandError(diags, detail, err)
+		return
+	}
+	addCodedError(diags, classifyError(err), summary, detail+": "+err.Error())
+}
+
+// addRecordAPIError adds an error diagnostic for a failed record API call.
+// Requests rejected as invalid are reported as invalid record data.
+func addRecordAPIError(diags *diag.Diagnostics, summary, detail string, err error) {
+	if isPreconditionFailed(err) {
+		addChangedOutOfBandError(diags, detail, err)
+		return
+	}
+	code := classifyError(err)
+	if code == ErrCodeInvalidRequest {
+		code = ErrCodeRdataInvalid
+	}
+	addCodedError(diags, code, summary, detail+": "+err.Error())
+}
+
+// isPreconditionFailed reports whether an If-Match request was rejected
+// because the object changed since it was read
+func isPreconditionFailed(err error) bool {
+	return apiStatus(err) == http.StatusPreconditionFailed
+}
+
+// addChangedOutOfBandError reports a change rejected because the object was
+// modified outside this Terraform run since it was last read
+func addChangedOutOfBandError(diags *diag.Diagnostics, detail string, err error) {
+	addCodedError(diags, ErrCodeConflict, "Resource Changed Out of Band",
+		detail+": the object was modified on the server since Terraform last read it, "+
+			"by another Terraform run or directly through the API. Run terraform refresh (or plan) "+
+			"to review the current server state, then apply again.\n\n"+err.Error())
+}
+
+// classifyError maps an API client error to an error code by its type:
+// responses of the REST API by status, DNS responses by response code, and
+// failures to reach a server as connection failures. Errors of no known
+// type are API errors.
+func classifyError(err error) string {
+	var apiErr *APIError
+	var rcodeErr *RcodeError
+	var rndcErr *RNDCError
+	switch {
+	case errors.As(err, &apiErr):
+		return classifyAPIError(apiErr)
+	case errors.Is(err, context.Canceled):
+		return ErrCodeCanceled
+	case errors.Is(err, errNoEndpoint), errors.Is(err, errNoStatisticsURL), errors.Is(err, errInvalidEndpoint):
+		return ErrCodeConfigInvalid
+	case errors.Is(err, errAuthFailed), errors.Is(err, errRNDCBadSignature),
+		errors.Is(err, dns.ErrSig), errors.Is(err, dns.ErrKey), errors.Is(err, dns.ErrSecret):
+		return ErrCodeAuthFailed
+	case errors.Is(err, errInvalidRdata):
+		return ErrCodeRdataInvalid
+	case errors.As(err, &rcodeErr):
+		return classifyRcode(rcodeErr.Rcode)
+	case errors.As(err, &rndcErr):
+		return classifyRNDCError(rndcErr)
+	case isConnectionError(err):
+		return ErrCodeConnectionFailed
+	default:
+		return ErrCodeAPIError
+	}
+}
+
+// classifyAPIError maps an error response of the REST API to an error code
+func classifyAPIError(apiErr *APIError) string {
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized, apiErr.StatusCode == http.StatusForbidden:
+		return ErrCodeAuthFailed
+	case strings.Contains(strings.ToLower(apiErr.Error()), "not loaded"):
+		return ErrCodeZoneNotLoaded
+	case apiErr.StatusCode == http.StatusNotFound:
+		return ErrCodeNotFound
+	case apiErr.StatusCode == http.StatusConflict, apiErr.StatusCode == http.StatusPreconditionFailed:
+		return ErrCodeConflict
+	case apiErr.StatusCode == http.StatusBadRequest, apiErr.StatusCode == http.StatusUnprocessableEntity:
+		return ErrCodeInvalidRequest
+	default:
+		return ErrCodeAPIError
+	}
+}
+
+// classifyRcode maps the response code of a DNS response to an error code.
+// Servers refuse updates and transfers signed with a key they do not accept,
+// and fail the prerequisites of an update to a record set changed since it
+// was read.
+func classifyRcode(rcode int) string {
+	switch rcode {
+	case dns.RcodeRefused, dns.RcodeNotAuth, dns.RcodeBadSig, dns.RcodeBadKey, dns.RcodeBadTime:
+		return ErrCodeAuthFailed
+	case dns.RcodeFormatError:
+		return ErrCodeInvalidRequest
+	case dns.RcodeNameError, dns.RcodeNotZone:
+		return ErrCodeNotFound
+	case dns.RcodeYXDomain, dns.RcodeYXRrset, dns.RcodeNXRrset:
+		return ErrCodeConflict
+	default:
+		return ErrCodeAPIError
+	}
+}
+
+// classifyRNDCError maps an rndc command that named failed to an error code.
+// named reports the failure as its result text only.
+func classifyRNDCError(rndcErr *RNDCError) string {
+	msg := strings.ToLower(rndcErr.Message)
+	switch {
+	case strings.Contains(msg, "not loaded"):
+		return ErrCodeZoneNotLoaded
+	case strings.Contains(msg, "not found"):
+		return ErrCodeNotFound
+	case strings.Contains(msg, "already exists"):
+		return ErrCodeConflict
+	default:
+		return ErrCodeAPIError
+	}
+}
+
+// isConnectionError reports whether an error means a server could not be
+// reached or the connection to it failed: a failed dial, name resolution,
+// TLS handshake or certificate check, a timeout, or a connection closed
+// before the response
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	var certErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr) ||
+		(errors.As(err, &netErr) && netErr.Timeout()) || errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &certErr) || errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, errReusedConnectionClosed) ||
+		errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
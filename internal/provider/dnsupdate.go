@@ -0,0 +1,348 @@
+// RFC 2136 dynamic update transport for record operations
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tsigAlgorithms maps the supported TSIG algorithm names to their identifiers
+var tsigAlgorithms = map[string]string{
+	"hmac-md5":    dns.HmacMD5,
+	"hmac-sha1":   dns.HmacSHA1,
+	"hmac-sha224": dns.HmacSHA224,
+	"hmac-sha256": dns.HmacSHA256,
+	"hmac-sha384": dns.HmacSHA384,
+	"hmac-sha512": dns.HmacSHA512,
+}
+
+// dnsUpdater performs record operations with DNS UPDATE messages and plain
+// DNS queries sent directly to the primary server, for deployments that
+// allow nsupdate but have no REST API
+type dnsUpdater struct {
+	server    string
+	keyName   string
+	keySecret string
+	algorithm string
+	timeout   time.Duration
+}
+
+// newDNSUpdater creates a dynamic update transport for the given server.
+// TSIG signing is disabled when keyName is empty.
+func newDNSUpdater(server, keyName, keySecret, algorithm string, timeout time.Duration) *dnsUpdater {
+	u := &dnsUpdater{
+		server:  dnsServerAddr(server),
+		timeout: timeout,
+	}
+	if keyName != "" {
+		u.keyName = dns.Fqdn(keyName)
+		u.keySecret = keySecret
+		u.algorithm = tsigAlgorithms[algorithm]
+	}
+	return u
+}
+
+// dnsServerAddr returns a DNS server address with port 53 added if the
+// address has no port. A bare IPv6 address is bracketed.
+func dnsServerAddr(server string) string {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(strings.Trim(server, "[]"), "53")
+	}
+	return server
+}
+
+// RcodeError is a DNS response with a response code other than NOERROR
+type RcodeError struct {
+	// Response code of the message
+	Rcode int
+	// Server that sent the response
+	Server string
+}
+
+// Error formats the error as "DNS error <rcode> from <server>"
+func (e *RcodeError) Error() string {
+	return fmt.Sprintf("DNS error %s from %s", dns.RcodeToString[e.Rcode], e.Server)
+}
+
+// errInvalidRdata is wrapped by the errors of record data that does not
+// parse for its type
+var errInvalidRdata = errors.New("invalid record data")
+
+// exchange signs and sends a message, returning an *RcodeError for any
+// response code other than NOERROR
+func (u *dnsUpdater) exchange(ctx context.Context, m *dns.Msg, net string) (resp *dns.Msg, err error) {
+	opcode := dns.OpcodeToString[m.Opcode]
+	ctx, span := startSpan(ctx, "DNS "+opcode,
+		attribute.String("dns.opcode", opcode),
+		attribute.String("dns.question.name", m.Question[0].Name),
+		attribute.String("network.transport", net),
+	)
+	defer func() { endSpan(span, err) }()
+
+	client := &dns.Client{Net: net, Timeout: u.timeout}
+	if u.keyName != "" {
+		client.TsigSecret = map[string]string{u.keyName: u.keySecret}
+		if m.IsTsig() == nil {
+			m.SetTsig(u.keyName, u.algorithm, 300, time.Now().Unix())
+		}
+	}
+
+	resp, _, err = client.ExchangeContext(ctx, m, u.server)
+	if err != nil {
+		return nil, fmt.Errorf("DNS request to %s failed: %w", u.server, err)
+	}
+
+	// Retry over TCP if the UDP response did not fit
+	if resp.Truncated && net != "tcp" {
+		return u.exchange(ctx, m, "tcp")
+	}
+
+	if resp.Rcode != dns.RcodeSuccess {
+		return resp, &RcodeError{Rcode: resp.Rcode, Server: u.server}
+	}
+
+	return resp, nil
+}
+
+// parseRR builds a resource record from a relative owner name and
+// presentation-format rdata. Relative names in the rdata are completed with
+// the zone origin.
+func parseRR(zone, name string, ttl int64, class, recordType, rdata string) (dns.RR, error) {
+	line := fmt.Sprintf("%s %d %s %s %s", ownerName(zone, name), ttl, class, recordType, rdata)
+
+	zp := dns.NewZoneParser(strings.NewReader(line), dns.Fqdn(zone), "")
+	rr, ok := zp.Next()
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", errInvalidRdata, rdata, err)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errInvalidRdata, rdata)
+	}
+	return rr, nil
+}
+
+// ownerName returns the fully qualified owner name for a record name
+// relative to the zone
+func ownerName(zone, name string) string {
+	if name == "@" || name == "" {
+		return dns.Fqdn(zone)
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + dns.Fqdn(zone)
+}
+
+// relativeName returns the record name of an owner relative to the zone
+func relativeName(zone, owner string) string {
+	origin := dns.Fqdn(zone)
+	if strings.EqualFold(owner, origin) {
+		return "@"
+	}
+	if strings.HasSuffix(strings.ToLower(owner), "."+strings.ToLower(origin)) {
+		return owner[:len(owner)-len(origin)-1]
+	}
+	return owner
+}
+
+// toRecord converts a resource record into the API record representation
+func toRecord(zone string, rr dns.RR) Record {
+	hdr := rr.Header()
+	return Record{
+		Name:  relativeName(zone, hdr.Name),
+		Type:  dns.TypeToString[hdr.Rrtype],
+		TTL:   int64(hdr.Ttl),
+		Class: dns.ClassToString[hdr.Class],
+		RData: readRdata(dns.TypeToString[hdr.Rrtype], strings.TrimPrefix(rr.String(), hdr.String())),
+		Zone:  zone,
+	}
+}
+
+// classCode returns the numeric class for a class mnemonic, defaulting to IN
+func classCode(class string) uint16 {
+	if code, ok := dns.StringToClass[strings.ToUpper(class)]; ok {
+		return code
+	}
+	return dns.ClassINET
+}
+
+// getRecords queries the server for records. A query is sent when both name
+// and type are given; otherwise the zone is transferred and filtered.
+func (u *dnsUpdater) getRecords(ctx context.Context, zone, recordType, name, class string) ([]Record, error) {
+	var rrs []dns.RR
+
+	if name != "" && recordType != "" {
+		qtype, ok := dns.StringToType[strings.ToUpper(recordType)]
+		if !ok {
+			return nil, fmt.Errorf("unknown record type %s", recordType)
+		}
+
+		m := new(dns.Msg)
+		m.SetQuestion(ownerName(zone, name), qtype)
+		m.Question[0].Qclass = classCode(class)
+		m.RecursionDesired = false
+
+		resp, err := u.exchange(ctx, m, "udp")
+		if err != nil {
+			// A missing name is an empty result, as with the REST API
+			if resp != nil && resp.Rcode == dns.RcodeNameError {
+				return []Record{}, nil
+			}
+			return nil, err
+		}
+		rrs = resp.Answer
+	} else {
+		var err error
+		rrs, err = u.transferZone(ctx, zone, class)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	records := []Record{}
+	for _, rr := range rrs {
+		record := toRecord(zone, rr)
+		if recordType != "" && !strings.EqualFold(record.Type, recordType) {
+			continue
+		}
+		if name != "" && !strings.EqualFold(record.Name, name) {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// transferZone fetches all records of a zone with AXFR
+func (u *dnsUpdater) transferZone(ctx context.Context, zone, class string) ([]dns.RR, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(dns.Fqdn(zone))
+	m.Question[0].Qclass = classCode(class)
+
+	t := &dns.Transfer{DialTimeout: u.timeout, ReadTimeout: u.timeout}
+	if u.keyName != "" {
+		t.TsigSecret = map[string]string{u.keyName: u.keySecret}
+		m.SetTsig(u.keyName, u.algorithm, 300, time.Now().Unix())
+	}
+
+	env, err := t.In(m, u.server)
+	if err != nil {
+		return nil, fmt.Errorf("zone transfer of %s from %s failed: %w", zone, u.server, err)
+	}
+
+	var rrs []dns.RR
+	for e := range env {
+		if e.Error != nil {
+			return nil, fmt.Errorf("zone transfer of %s from %s failed: %w", zone, u.server, e.Error)
+		}
+		for _, rr := range e.RR {
+			// The transfer starts and ends with the SOA record
+			if rr.Header().Rrtype == dns.TypeSOA && len(rrs) > 0 && rrs[0].Header().Rrtype == dns.TypeSOA {
+				continue
+			}
+			rrs = append(rrs, rr)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return rrs, nil
+}
+
+// createRecord adds a record with a DNS UPDATE
+func (u *dnsUpdater) createRecord(ctx context.Context, zone string, req *RecordCreateRequest) (*Record, error) {
+	class := req.RecordClass
+	if class == "" {
+		class = "IN"
+	}
+
+	rr, err := parseRR(zone, req.Name, int64(req.TTL), class, req.RecordType, req.RData)
+	if err != nil {
+		return nil, err
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Question[0].Qclass = classCode(class)
+	m.Insert([]dns.RR{rr})
+
+	if _, err := u.exchange(ctx, m, "udp"); err != nil {
+		return nil, err
+	}
+
+	record := toRecord(zone, rr)
+	return &record, nil
+}
+
+// replaceRRset replaces the values of an RRset with a single DNS UPDATE that
+// removes the set and adds the new values, which the server applies
+// atomically
+func (u *dnsUpdater) replaceRRset(ctx context.Context, zone, name, recordType string, req *RRsetReplaceRequest) error {
+	class := req.RecordClass
+	if class == "" {
+		class = "IN"
+	}
+	rrtype, ok := dns.StringToType[strings.ToUpper(recordType)]
+	if !ok {
+		return fmt.Errorf("unknown record type %s", recordType)
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Question[0].Qclass = classCode(class)
+	m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: ownerName(zone, name), Rrtype: rrtype, Class: classCode(class)}}})
+
+	var rrs []dns.RR
+	for _, rdata := range req.RData {
+		rr, err := parseRR(zone, name, int64(req.TTL), class, recordType, rdata)
+		if err != nil {
+			return err
+		}
+		rrs = append(rrs, rr)
+	}
+	if len(rrs) > 0 {
+		m.Insert(rrs)
+	}
+
+	_, err := u.exchange(ctx, m, "udp")
+	return err
+}
+
+// deleteRecord removes a record with a DNS UPDATE. An empty rdata removes
+// the whole RRset.
+func (u *dnsUpdater) deleteRecord(ctx context.Context, zone, name, recordType, class, rdata string) error {
+	if class == "" {
+		class = "IN"
+	}
+
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+	m.Question[0].Qclass = classCode(class)
+
+	if rdata == "" {
+		rrtype, ok := dns.StringToType[strings.ToUpper(recordType)]
+		if !ok {
+			return fmt.Errorf("unknown record type %s", recordType)
+		}
+		m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: ownerName(zone, name), Rrtype: rrtype, Class: classCode(class)}}})
+	} else {
+		rr, err := parseRR(zone, name, 0, class, recordType, rdata)
+		if err != nil {
+			return err
+		}
+		m.Remove([]dns.RR{rr})
+	}
+
+	_, err := u.exchange(ctx, m, "udp")
+	return err
+}
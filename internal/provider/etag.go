@@ -0,0 +1,57 @@
+// ETag / If-Match optimistic concurrency
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// etagPrivateKey is the private state key holding the last seen ETag
+const etagPrivateKey = "etag"
+
+// etagTracker follows the entity tag of an object across a sequence of
+// requests. Mutating requests send it as If-Match, and every successful
+// response replaces it with the ETag returned, or clears it if none was.
+type etagTracker struct {
+	etag string
+}
+
+type etagContextKey struct{}
+
+// withETag returns a context whose API requests use and update the tracker.
+// A nil tracker disables tracking for requests made with the context.
+func withETag(ctx context.Context, t *etagTracker) context.Context {
+	return context.WithValue(ctx, etagContextKey{}, t)
+}
+
+// etagFromContext returns the tracker of a context, or nil
+func etagFromContext(ctx context.Context) *etagTracker {
+	t, _ := ctx.Value(etagContextKey{}).(*etagTracker)
+	return t
+}
+
+// privateState is the private state of a resource request or response
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// loadETag returns a tracker holding the ETag stored in private state
+func loadETag(ctx context.Context, p privateState) *etagTracker {
+	t := &etagTracker{}
+	data, diags := p.GetKey(ctx, etagPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return t
+	}
+	_ = json.Unmarshal(data, &t.etag)
+	return t
+}
+
+// storeETag saves the tracker's ETag in private state
+func storeETag(ctx context.Context, p privateState, t *etagTracker) diag.Diagnostics {
+	data, _ := json.Marshal(t.etag)
+	return p.SetKey(ctx, etagPrivateKey, data)
+}
@@ -0,0 +1,196 @@
+// Freezing dynamic zones around changes made through the REST API
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// zoneFreezer freezes a dynamic zone while the REST API edits its zone file,
+// and thaws it once the last change in the zone has finished. A dynamic
+// zone keeps its changes in a journal, and editing its file while unfrozen
+// makes the journal disagree with the file. The lock only guards the maps;
+// freezing and thawing happen outside it, so a slow zone does not hold up
+// changes in the others.
+type zoneFreezer struct {
+	client *Client
+
+	mu sync.Mutex
+	// Changes in progress in each zone
+	zones map[string]*frozenZone
+	// Whether each zone looked up so far accepts dynamic updates
+	dynamic map[string]bool
+}
+
+// frozenZone tracks the changes in progress in a zone
+type frozenZone struct {
+	active int
+	// Whether the zone was frozen for the changes
+	frozen bool
+	// Closed once the first change has frozen the zone, or given up
+	ready chan struct{}
+	// Set while the zone is being thawed, and closed once it is
+	thawed chan struct{}
+}
+
+// newZoneFreezer creates a freezer for the zones of a client
+func newZoneFreezer(client *Client) *zoneFreezer {
+	return &zoneFreezer{
+		client:  client,
+		zones:   make(map[string]*frozenZone),
+		dynamic: make(map[string]bool),
+	}
+}
+
+// begin freezes the zone before a change, unless it is already frozen by
+// another change or does not accept dynamic updates. It returns once the
+// zone is frozen. A zone that cannot be frozen is changed anyway, as it was
+// before freezing was added.
+func (f *zoneFreezer) begin(ctx context.Context, zone string) {
+	key := zoneID(viewFromContext(ctx), zone)
+
+	f.mu.Lock()
+	for {
+		z, ok := f.zones[key]
+		if !ok {
+			break
+		}
+		if z.thawed != nil {
+			// Freeze again once the thaw has reached the server
+			thawed := z.thawed
+			f.mu.Unlock()
+			<-thawed
+			f.mu.Lock()
+			continue
+		}
+		z.active++
+		f.mu.Unlock()
+		<-z.ready
+		return
+	}
+	z := &frozenZone{active: 1, ready: make(chan struct{})}
+	f.zones[key] = z
+	dynamic, known := f.dynamic[key]
+	f.mu.Unlock()
+
+	frozen := false
+	defer func() {
+		f.mu.Lock()
+		z.frozen = frozen
+		f.mu.Unlock()
+		close(z.ready)
+	}()
+
+	if !known {
+		current, err := f.client.GetZone(ctx, zone)
+		if err != nil {
+			return
+		}
+		dynamic = isDynamicZone(current)
+		f.mu.Lock()
+		f.dynamic[key] = dynamic
+		f.mu.Unlock()
+	}
+	if !dynamic {
+		return
+	}
+
+	if err := f.client.FreezeZone(ctx, zone); err != nil {
+		tflog.Warn(ctx, "Could not freeze dynamic zone", map[string]any{"zone": key, "error": err.Error()})
+		return
+	}
+	tflog.Debug(ctx, "Froze dynamic zone during change", map[string]any{"zone": key})
+	frozen = true
+}
+
+// end marks a change as finished and thaws the zone after the last one.
+// Changes that begin while the zone is thawing wait for the thaw, so they
+// cannot be left unfrozen by it. Zones are told apart by the view of ctx.
+func (f *zoneFreezer) end(ctx context.Context, zone string) {
+	view := viewFromContext(ctx)
+	key := zoneID(view, zone)
+
+	f.mu.Lock()
+	z, ok := f.zones[key]
+	if !ok || z.thawed != nil {
+		f.mu.Unlock()
+		return
+	}
+	z.active--
+	if z.active > 0 {
+		f.mu.Unlock()
+		return
+	}
+	if !z.frozen {
+		delete(f.zones, key)
+		f.mu.Unlock()
+		return
+	}
+	z.thawed = make(chan struct{})
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		delete(f.zones, key)
+		f.mu.Unlock()
+		close(z.thawed)
+	}()
+
+	ctx = withView(context.Background(), view)
+	if err := f.client.ThawZone(ctx, zone); err != nil {
+		tflog.Error(ctx, "Could not thaw dynamic zone", map[string]any{"zone": key, "error": err.Error()})
+		return
+	}
+	tflog.Debug(ctx, "Thawed dynamic zone", map[string]any{"zone": key})
+}
+
+// holds reports whether changes are in progress in the zone
+func (f *zoneFreezer) holds(ctx context.Context, zone string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.zones[zoneID(viewFromContext(ctx), zone)]
+	return ok
+}
+
+// checkLeftoverFreeze warns about a zone the server reports frozen while no
+// change of this provider holds it. A provider killed between freezing and
+// thawing leaves the zone refusing dynamic updates until it is thawed.
+func checkLeftoverFreeze(ctx context.Context, client *Client, zone *Zone, diags *diag.Diagnostics) {
+	if !zone.Frozen || (client.freezer != nil && client.freezer.holds(ctx, zone.Name)) {
+		return
+	}
+	diags.AddWarning("Zone Is Frozen",
+		fmt.Sprintf("The zone %s is frozen and refuses dynamic updates. If it was left frozen by an interrupted apply, "+
+			"rather than frozen on purpose, thaw it with \"rndc thaw %s\".", zone.Name, zone.Name))
+}
+
+// forget drops what is known about a zone, whose options may have changed
+func (f *zoneFreezer) forget(ctx context.Context, zone string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.dynamic, zoneID(viewFromContext(ctx), zone))
+}
+
+// isDynamicZone reports whether a zone accepts dynamic updates
+func isDynamicZone(zone *Zone) bool {
+	if zone.Options == nil {
+		return false
+	}
+	if len(zone.Options.UpdatePolicy) > 0 {
+		return true
+	}
+	for _, acl := range zone.Options.AllowUpdate {
+		if !strings.EqualFold(strings.TrimSpace(acl), "none") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+// Import ID parsing
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+// importID describes the import ID formats of a resource: a positional form
+// joined by "/", and a key=value form (e.g. zone=example.com,name=www,type=A)
+// that also accepts optional keys.
+type importID struct {
+	positional []string
+	optional   []string
+
+	// The last positional component takes the rest of the ID, for values
+	// such as record data that may contain slashes
+	trailingRest bool
+}
+
+// example returns both forms of the import ID for error messages
+func (f importID) example() string {
+	keys := make([]string, 0, len(f.positional)+len(f.optional))
+	for _, k := range append(append([]string{}, f.positional...), f.optional...) {
+		keys = append(keys, k+"=...")
+	}
+	return fmt.Sprintf("%s or %s", strings.Join(f.positional, "/"), strings.Join(keys, ","))
+}
+
+// parse returns the components of an import ID by key
+func (f importID) parse(id string) (map[string]string, error) {
+	id = strings.TrimSpace(id)
+	values := make(map[string]string)
+
+	// Keys never contain slashes, so an "=" after the first slash is part of
+	// a positional component, such as TXT record data
+	key, _, isKeyValue := strings.Cut(id, "=")
+	isKeyValue = isKeyValue && !strings.Contains(key, "/")
+
+	if !isKeyValue {
+		// A lone component is taken whole, since classless reverse zone
+		// names (RFC 2317) contain slashes
+		parts := []string{id}
+		if len(f.positional) > 1 && f.trailingRest {
+			parts = strings.SplitN(id, "/", len(f.positional))
+		} else if len(f.positional) > 1 {
+			parts = strings.Split(id, "/")
+		}
+		if len(parts) != len(f.positional) {
+			return nil, fmt.Errorf("import ID must be in format %s", f.example())
+		}
+		for i, k := range f.positional {
+			values[k] = strings.TrimSpace(parts[i])
+		}
+	} else {
+		for _, pair := range strings.Split(id, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if !ok || key == "" {
+				return nil, fmt.Errorf("import ID component %q is not a key=value pair", strings.TrimSpace(pair))
+			}
+			if !f.accepts(key) {
+				return nil, fmt.Errorf("unknown import ID key %q; accepted keys are %s",
+					key, strings.Join(append(append([]string{}, f.positional...), f.optional...), ", "))
+			}
+			if _, dup := values[key]; dup {
+				return nil, fmt.Errorf("import ID key %q is given more than once", key)
+			}
+			values[key] = strings.TrimSpace(value)
+		}
+	}
+
+	for _, k := range f.positional {
+		if values[k] == "" {
+			return nil, fmt.Errorf("import ID is missing %s; use format %s", k, f.example())
+		}
+	}
+
+	return values, nil
+}
+
+// accepts reports whether key is a known import ID component
+func (f importID) accepts(key string) bool {
+	for _, k := range f.positional {
+		if k == key {
+			return true
+		}
+	}
+	for _, k := range f.optional {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// BIND key file parsing
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// tsigKey is a key statement read from a BIND key file
+type tsigKey struct {
+	name      string
+	algorithm string
+	secret    string
+}
+
+var (
+	keyFileComments = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*|#[^\n]*`)
+	keyStatement    = regexp.MustCompile(`(?s)key\s+"?([^"\s{]+)"?\s*\{(.*?)\}\s*;`)
+	keyAlgorithm    = regexp.MustCompile(`algorithm\s+"?([^"\s;]+)"?\s*;`)
+	keySecret       = regexp.MustCompile(`secret\s+"([^"]+)"\s*;`)
+)
+
+// parseKeyFile reads the key statements of a BIND key file, as written by
+// tsig-keygen, ddns-confgen or rndc-confgen
+func parseKeyFile(path string) ([]tsigKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read key file: %w", err)
+	}
+
+	content := keyFileComments.ReplaceAllString(string(data), "")
+
+	var keys []tsigKey
+	for _, m := range keyStatement.FindAllStringSubmatch(content, -1) {
+		key := tsigKey{name: m[1]}
+		if alg := keyAlgorithm.FindStringSubmatch(m[2]); alg != nil {
+			key.algorithm = strings.TrimSuffix(strings.ToLower(alg[1]), ".sig-alg.reg.int")
+		}
+		if secret := keySecret.FindStringSubmatch(m[2]); secret != nil {
+			key.secret = secret[1]
+		}
+		if key.algorithm == "" || key.secret == "" {
+			return nil, fmt.Errorf("key %q in %s is missing its algorithm or secret", key.name, path)
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no key statement found in %s", path)
+	}
+
+	return keys, nil
+}
+
+// loadKeyFile returns the named key from a BIND key file, or its only key
+// when name is empty
+func loadKeyFile(path, name string) (*tsigKey, error) {
+	keys, err := parseKeyFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		if len(keys) > 1 {
+			return nil, fmt.Errorf("%s contains %d keys; set key_name to choose one", path, len(keys))
+		}
+		return &keys[0], nil
+	}
+
+	for i := range keys {
+		if strings.EqualFold(strings.TrimSuffix(keys[i].name, "."), strings.TrimSuffix(name, ".")) {
+			return &keys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("key %q not found in %s", name, path)
+}
@@ -0,0 +1,163 @@
+// Per-tenant quota and limit awareness
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Limits holds the per-tenant quotas enforced by the API server. Zero means
+// the server enforces no limit.
+type Limits struct {
+	MaxZones          int64 `json:"max_zones"`
+	MaxRecordsPerZone int64 `json:"max_records_per_zone"`
+}
+
+// GetLimits retrieves the per-tenant limits. Servers without the limits
+// endpoint enforce none, and nil is returned.
+func (c *Client) GetLimits(ctx context.Context) (*Limits, error) {
+	resp, err := c.doRequest(withETag(ctx, nil), "GET", "/api/v1/limits", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits Limits
+	if err := c.parseResponse(resp, &limits); err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &limits, nil
+}
+
+// quotaTracker remembers the limits and the zones and records planned for
+// creation by this provider process, so an apply that would exceed a limit
+// is reported at plan time instead of failing partway through
+type quotaTracker struct {
+	mu      sync.Mutex
+	fetched bool
+	limits  *Limits
+
+	// Zones planned for creation, by cache key
+	zones map[string]bool
+	// Records planned for creation per zone, by resource key
+	records map[string]map[string]int64
+}
+
+// newQuotaTracker creates an empty quota tracker
+func newQuotaTracker() *quotaTracker {
+	return &quotaTracker{
+		zones:   make(map[string]bool),
+		records: make(map[string]map[string]int64),
+	}
+}
+
+// limits returns the server limits, fetched once per provider process.
+// Servers without a REST API or the limits endpoint have none.
+func (c *Client) limits(ctx context.Context) (*Limits, error) {
+	q := c.quota
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.fetched {
+		return q.limits, nil
+	}
+	if !c.hasAPI() {
+		q.fetched = true
+		return nil, nil
+	}
+
+	limits, err := c.GetLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q.limits, q.fetched = limits, true
+	return limits, nil
+}
+
+// planZoneCreate records a planned zone creation. It returns the number of
+// zones the server would hold after every creation planned so far, and the
+// limit, or zero when the server enforces none.
+func (c *Client) planZoneCreate(ctx context.Context, view, name string) (int64, int64, error) {
+	limits, err := c.limits(ctx)
+	if err != nil || limits == nil || limits.MaxZones <= 0 {
+		return 0, 0, err
+	}
+
+	zones, err := c.ListZones(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	existing := make(map[string]bool, len(zones))
+	for _, z := range zones {
+		existing[cacheKey(zoneID(z.View, z.Name))] = true
+	}
+
+	q := c.quota
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.zones[cacheKey(zoneID(view, name))] = true
+	total := int64(len(zones))
+	for zone := range q.zones {
+		// Zones already created during this apply are in the listing
+		if !existing[zone] {
+			total++
+		}
+	}
+	return total, limits.MaxZones, nil
+}
+
+// planRecordCreate records count records planned for creation in a zone by
+// the resource identified by key. It returns the number of records the zone
+// would hold after every creation planned so far, and the limit, or zero
+// when the server enforces none or the zone does not exist yet.
+func (c *Client) planRecordCreate(ctx context.Context, zone, key string, count int64) (int64, int64, error) {
+	limits, err := c.limits(ctx)
+	if err != nil || limits == nil || limits.MaxRecordsPerZone <= 0 {
+		return 0, 0, err
+	}
+
+	zones, err := c.ListZones(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	var current int64 = -1
+	for _, z := range zones {
+		if cacheKey(z.Name) == cacheKey(zone) && (z.View == "" || z.View == viewFromContext(ctx)) {
+			current = z.RecordCount
+			break
+		}
+	}
+	if current < 0 {
+		return 0, 0, nil
+	}
+
+	q := c.quota
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	planned, ok := q.records[viewCacheKey(ctx, zone)]
+	if !ok {
+		planned = make(map[string]int64)
+		q.records[viewCacheKey(ctx, zone)] = planned
+	}
+	planned[key] = count
+
+	total := current
+	for _, n := range planned {
+		total += n
+	}
+	return total, limits.MaxRecordsPerZone, nil
+}
+
+// quotaWarningDetail describes a planned change that exceeds a server limit
+func quotaWarningDetail(what string, total, limit int64) string {
+	return fmt.Sprintf("This plan would bring the number of %s to %d, above the limit of %d set by the API server. "+
+		"The apply will fail once the limit is reached, possibly partway through. "+
+		"Raise the limit or reduce the number of planned objects.", what, total, limit)
+}
@@ -0,0 +1,166 @@
+// API request metrics
+
+package provider
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Metrics of all configured clients, logged at the end of apply operations
+// and on provider shutdown
+var (
+	metricsMu     sync.Mutex
+	clientMetrics []*requestMetrics
+)
+
+// Number of Create, Update and Delete operations in flight
+var (
+	applyMu      sync.Mutex
+	applyPending int
+)
+
+// requestMetrics counts the API requests a client makes during a Terraform
+// run, so operators can see how much load a workspace puts on the API
+type requestMetrics struct {
+	endpoint string
+	started  time.Time
+
+	// Context of the provider configuration, which carries its logger
+	logCtx context.Context
+
+	mu       sync.Mutex
+	requests map[string]*methodMetrics
+	// Failed requests by HTTP status, or "network" for transport errors
+	errors map[string]int64
+}
+
+// methodMetrics counts the requests of one HTTP method
+type methodMetrics struct {
+	count   int64
+	total   time.Duration
+	slowest time.Duration
+}
+
+// newRequestMetrics creates the metrics of a client and registers them for
+// logging
+func newRequestMetrics(ctx context.Context, endpoint string) *requestMetrics {
+	m := &requestMetrics{
+		endpoint: endpoint,
+		started:  time.Now(),
+		logCtx:   context.WithoutCancel(ctx),
+		requests: make(map[string]*methodMetrics),
+		errors:   make(map[string]int64),
+	}
+
+	metricsMu.Lock()
+	clientMetrics = append(clientMetrics, m)
+	metricsMu.Unlock()
+
+	return m
+}
+
+// observe records a completed request. status is zero for requests that got
+// no response.
+func (m *requestMetrics) observe(method string, status int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mm, ok := m.requests[method]
+	if !ok {
+		mm = &methodMetrics{}
+		m.requests[method] = mm
+	}
+	mm.count++
+	mm.total += latency
+	mm.slowest = max(mm.slowest, latency)
+
+	switch {
+	case status == 0:
+		m.errors["network"]++
+	case status >= 400:
+		m.errors[strconv.Itoa(status)]++
+	}
+}
+
+// log writes a summary of the requests made so far
+func (m *requestMetrics) log() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	var total, slowest time.Duration
+	byMethod := make(map[string]any, len(m.requests))
+	for method, mm := range m.requests {
+		count += mm.count
+		total += mm.total
+		slowest = max(slowest, mm.slowest)
+		byMethod[method] = map[string]any{
+			"count":          mm.count,
+			"avg_latency_ms": mm.total.Milliseconds() / mm.count,
+			"max_latency_ms": mm.slowest.Milliseconds(),
+		}
+	}
+
+	var avg int64
+	if count > 0 {
+		avg = total.Milliseconds() / count
+	}
+
+	failed := make(map[string]any, len(m.errors))
+	for status, n := range m.errors {
+		failed[status] = n
+	}
+
+	tflog.Info(m.logCtx, "BIND9 API request summary", map[string]any{
+		"endpoint":           m.endpoint,
+		"duration":           time.Since(m.started).Round(time.Millisecond).String(),
+		"requests":           count,
+		"requests_by_method": byMethod,
+		"errors_by_status":   failed,
+		"avg_latency_ms":     avg,
+		"max_latency_ms":     slowest.Milliseconds(),
+	})
+}
+
+// logRequestMetrics logs the request summary of every client that collects
+// metrics
+func logRequestMetrics() {
+	metricsMu.Lock()
+	pending := clientMetrics
+	metricsMu.Unlock()
+
+	for _, m := range pending {
+		m.log()
+	}
+}
+
+// beginApplyOperation marks a Create, Update or Delete as started and
+// returns the function that marks it as ended. Whenever no apply operation is
+// left in flight, NOTIFY is re-enabled on the zones squelched so far and the
+// request summaries so far are logged: Terraform may stop the provider
+// process before Shutdown gets to either. The ending operation waits for
+// both, so they complete before Terraform receives its result.
+func beginApplyOperation() func() {
+	applyMu.Lock()
+	applyPending++
+	applyMu.Unlock()
+
+	return func() {
+		applyMu.Lock()
+		applyPending--
+		idle := applyPending == 0
+		applyMu.Unlock()
+
+		if idle {
+			ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+			defer cancel()
+			flushSquelchers(ctx)
+			logRequestMetrics()
+		}
+	}
+}
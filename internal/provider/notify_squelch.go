@@ -0,0 +1,373 @@
+// NOTIFY squelching during bulk record changes
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// notifyQuietPeriod is how long a zone must go without record changes before
+// NOTIFY is re-enabled and sent
+const notifyQuietPeriod = 5 * time.Second
+
+// flushTimeout bounds re-enabling NOTIFY on the squelched zones at the end
+// of an apply. Terraform kills a provider that has not exited about two
+// seconds after the end of a run, so Shutdown cannot wait for long.
+const flushTimeout = 1500 * time.Millisecond
+
+// Squelchers of all configured clients, flushed whenever no apply operation
+// is in flight and on provider shutdown
+var (
+	squelchersMu sync.Mutex
+	squelchers   []*notifySquelcher
+)
+
+// notifySquelcher disables NOTIFY on a zone while records are being changed
+// and re-enables it, sending a single NOTIFY, once the zone has been quiet
+// for notifyQuietPeriod, no apply operation is left in flight, or the
+// provider shuts down. The lock only guards the
+// map; the API calls happen outside it.
+type notifySquelcher struct {
+	client *Client
+
+	mu    sync.Mutex
+	zones map[string]*squelchedZone
+}
+
+// squelchedZone tracks the record changes in progress in a zone
+type squelchedZone struct {
+	zone   string
+	view   string
+	active int
+	timer  *time.Timer
+	// Whether NOTIFY was disabled for the changes
+	squelched bool
+	// NOTIFY mode restored afterwards
+	mode NotifyMode
+	// Closed once the first change has disabled NOTIFY, or given up
+	ready chan struct{}
+	// Set while NOTIFY is being re-enabled, and closed once it is
+	restored chan struct{}
+}
+
+// newNotifySquelcher creates a squelcher and registers it for shutdown flushing
+func newNotifySquelcher(client *Client) *notifySquelcher {
+	s := &notifySquelcher{
+		client: client,
+		zones:  make(map[string]*squelchedZone),
+	}
+
+	squelchersMu.Lock()
+	squelchers = append(squelchers, s)
+	squelchersMu.Unlock()
+
+	return s
+}
+
+// begin disables NOTIFY for the zone before a record change. Zones that
+// already have NOTIFY disabled in their configuration are left alone.
+func (s *notifySquelcher) begin(ctx context.Context, zone string) {
+	view := viewFromContext(ctx)
+	key := zoneID(view, zone)
+
+	s.mu.Lock()
+	for {
+		z, ok := s.zones[key]
+		if !ok {
+			break
+		}
+		if z.restored != nil {
+			// Disable NOTIFY again once it has been re-enabled
+			restored := z.restored
+			s.mu.Unlock()
+			<-restored
+			s.mu.Lock()
+			continue
+		}
+		if z.timer != nil {
+			z.timer.Stop()
+		}
+		z.active++
+		s.mu.Unlock()
+		<-z.ready
+		return
+	}
+	z := &squelchedZone{zone: zone, view: view, active: 1, ready: make(chan struct{})}
+	s.zones[key] = z
+	s.mu.Unlock()
+
+	mode, squelched := s.disable(ctx, zone)
+
+	s.mu.Lock()
+	z.mode = mode
+	z.squelched = squelched
+	z.timer = time.AfterFunc(notifyQuietPeriod, func() { s.restore(key) })
+	z.timer.Stop()
+	s.mu.Unlock()
+	close(z.ready)
+}
+
+// disable turns NOTIFY off for the zone, returning the mode it had and
+// whether it was turned off
+func (s *notifySquelcher) disable(ctx context.Context, zone string) (NotifyMode, bool) {
+	current, err := s.client.GetZone(ctx, zone)
+	if err != nil {
+		return "", false
+	}
+	mode := NotifyMode(notifyYes)
+	if current.Options != nil {
+		mode = current.Options.Notify
+	}
+	if mode == "" || mode == notifyNo {
+		return mode, false
+	}
+
+	disabled := NotifyMode(notifyNo)
+	if _, err := s.client.UpdateZone(ctx, zone, &ZoneUpdateRequest{Notify: &disabled}); err != nil {
+		tflog.Warn(ctx, "Could not disable NOTIFY for zone", map[string]any{"zone": zone, "error": err.Error()})
+		return mode, false
+	}
+
+	tflog.Debug(ctx, "Disabled NOTIFY for zone during apply", map[string]any{"zone": zone})
+	return mode, true
+}
+
+// end marks a record change as finished and restarts the quiet period
+func (s *notifySquelcher) end(ctx context.Context, zone string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := zoneID(viewFromContext(ctx), zone)
+	z, ok := s.zones[key]
+	if !ok || z.restored != nil {
+		return
+	}
+
+	z.active--
+	if z.active > 0 {
+		return
+	}
+	if !z.squelched {
+		delete(s.zones, key)
+		return
+	}
+	z.timer.Reset(notifyQuietPeriod)
+}
+
+// restore re-enables NOTIFY for a quiet zone and sends a single NOTIFY
+func (s *notifySquelcher) restore(key string) {
+	s.mu.Lock()
+	z, ok := s.zones[key]
+	if !ok || z.active > 0 || z.restored != nil {
+		s.mu.Unlock()
+		return
+	}
+	z.restored = make(chan struct{})
+	s.mu.Unlock()
+
+	s.client.enableNotify(withView(context.Background(), z.view), z.zone, z.mode)
+
+	s.mu.Lock()
+	delete(s.zones, key)
+	s.mu.Unlock()
+	close(z.restored)
+}
+
+// flush restores every squelched zone that no change holds immediately.
+// Changes beginning meanwhile wait for the zone to be restored, as with
+// restore.
+func (s *notifySquelcher) flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := make(map[string]*squelchedZone)
+	for key, z := range s.zones {
+		if z.restored != nil || !z.squelched || z.active > 0 {
+			continue
+		}
+		z.timer.Stop()
+		z.restored = make(chan struct{})
+		pending[key] = z
+	}
+	s.mu.Unlock()
+
+	for key, z := range pending {
+		s.client.enableNotify(withView(ctx, z.view), z.zone, z.mode)
+
+		s.mu.Lock()
+		delete(s.zones, key)
+		s.mu.Unlock()
+		close(z.restored)
+	}
+}
+
+// flushSquelchers restores the squelched zones of every client
+func flushSquelchers(ctx context.Context) {
+	squelchersMu.Lock()
+	pending := squelchers
+	squelchersMu.Unlock()
+
+	for _, s := range pending {
+		s.flush(ctx)
+	}
+}
+
+// holds reports whether NOTIFY of the zone is disabled by this squelcher
+func (s *notifySquelcher) holds(ctx context.Context, zone string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.zones[zoneID(viewFromContext(ctx), zone)]
+	return ok
+}
+
+// enableNotify turns NOTIFY back on for the zone in its prior mode and sends
+// NOTIFY to secondaries. It reports whether NOTIFY was turned back on.
+func (c *Client) enableNotify(ctx context.Context, zone string, mode NotifyMode) bool {
+	if _, err := c.UpdateZone(ctx, zone, &ZoneUpdateRequest{Notify: &mode}); err != nil {
+		tflog.Error(ctx, "Could not re-enable NOTIFY for zone", map[string]any{"zone": zone, "error": err.Error()})
+		return false
+	}
+	if err := c.NotifyZone(ctx, zone); err != nil {
+		tflog.Warn(ctx, "Could not send NOTIFY for zone", map[string]any{"zone": zone, "error": err.Error()})
+	}
+	return true
+}
+
+// squelchPrivateKey is the private state key listing the zones whose NOTIFY
+// was disabled by a change of the resource
+const squelchPrivateKey = "notify_squelch"
+
+// squelchMarker records the NOTIFY mode to restore on a squelched zone
+type squelchMarker struct {
+	Zone string `json:"zone"`
+	View string `json:"view,omitempty"`
+	Mode string `json:"mode"`
+}
+
+// loadSquelchMarkers returns the squelched zones recorded in private state
+func loadSquelchMarkers(ctx context.Context, p privateState) []squelchMarker {
+	var markers []squelchMarker
+	data, diags := p.GetKey(ctx, squelchPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return nil
+	}
+	_ = json.Unmarshal(data, &markers)
+	return markers
+}
+
+// storeSquelchMarkers saves the squelched zones in private state
+func storeSquelchMarkers(ctx context.Context, p privateState, markers []squelchMarker) diag.Diagnostics {
+	data, _ := json.Marshal(markers)
+	return p.SetKey(ctx, squelchPrivateKey, data)
+}
+
+// persistSquelch records the zones whose NOTIFY is currently disabled in
+// the private state of a changed resource. NOTIFY is only restored once the
+// zone is quiet or no apply operation is left in flight, and a provider
+// that is killed first leaves it disabled; the next Read of the resource
+// restores it.
+func (c *Client) persistSquelch(ctx context.Context, p privateState) diag.Diagnostics {
+	if c.notifySquelch == nil {
+		return nil
+	}
+
+	markers := loadSquelchMarkers(ctx, p)
+	seen := make(map[string]bool, len(markers))
+	for _, m := range markers {
+		seen[zoneID(m.View, m.Zone)] = true
+	}
+
+	s := c.notifySquelch
+	s.mu.Lock()
+	for key, z := range s.zones {
+		if !z.squelched || seen[key] {
+			continue
+		}
+		markers = append(markers, squelchMarker{Zone: z.zone, View: z.view, Mode: string(z.mode)})
+	}
+	s.mu.Unlock()
+
+	if len(markers) == 0 {
+		return nil
+	}
+	return storeSquelchMarkers(ctx, p, markers)
+}
+
+// restoreSquelch re-enables NOTIFY on the zones recorded by persistSquelch
+// that are still disabled while no change of this provider holds them.
+// Zones that cannot be read are kept for the next Read.
+func (c *Client) restoreSquelch(ctx context.Context, p privateState) diag.Diagnostics {
+	markers := loadSquelchMarkers(ctx, p)
+	if len(markers) == 0 {
+		return nil
+	}
+
+	var pending []squelchMarker
+	for _, m := range markers {
+		zoneCtx := withView(ctx, m.View)
+		if c.notifySquelch != nil && c.notifySquelch.holds(zoneCtx, m.Zone) {
+			pending = append(pending, m)
+			continue
+		}
+		current, err := c.GetZone(zoneCtx, m.Zone)
+		if err != nil {
+			if !isNotFound(err) {
+				pending = append(pending, m)
+			}
+			continue
+		}
+		if current.Options == nil || current.Options.Notify != notifyNo {
+			continue
+		}
+
+		tflog.Warn(ctx, "Re-enabling NOTIFY left disabled by an interrupted apply", map[string]any{"zone": zoneID(m.View, m.Zone)})
+		if !c.enableNotify(zoneCtx, m.Zone, NotifyMode(m.Mode)) {
+			pending = append(pending, m)
+		}
+	}
+
+	return storeSquelchMarkers(ctx, p, pending)
+}
+
+// beginZoneChange is called before a record change in a zone. Dynamic
+// zones are frozen unless changes are sent as dynamic updates, which need
+// the zone thawed.
+func (c *Client) beginZoneChange(ctx context.Context, zone string) {
+	if c.notifySquelch != nil {
+		c.notifySquelch.begin(ctx, zone)
+	}
+	if c.freezer != nil && c.dnsUpdate == nil {
+		c.freezer.begin(ctx, zone)
+	}
+}
+
+// endZoneChange is called after a record change in a zone
+func (c *Client) endZoneChange(ctx context.Context, zone string) {
+	if c.freezer != nil {
+		c.freezer.end(ctx, zone)
+	}
+	if c.notifySquelch != nil {
+		c.notifySquelch.end(ctx, zone)
+	}
+}
+
+// Shutdown re-enables NOTIFY on every zone still squelched, logs the API
+// request summaries and flushes pending trace spans, within flushTimeout.
+// It is called by main after the plugin server stops, at the end of an
+// apply; the end of the last apply operation has normally restored the
+// zones already.
+func Shutdown(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, flushTimeout)
+	defer cancel()
+
+	flushSquelchers(ctx)
+
+	logRequestMetrics()
+
+	shutdownTracing(ctx)
+}
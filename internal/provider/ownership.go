@@ -0,0 +1,76 @@
+// Record set ownership checks
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// rrsetOwners remembers the record sets planned by bind9_record resources
+// in this provider process. A resource is planned once per run, except that
+// Terraform plans a resource it replaces a second time, as a create without
+// prior state. Apart from those re-plans, a record set planned twice
+// belongs to two resources, which would overwrite each other's values on
+// every apply.
+type rrsetOwners struct {
+	mu sync.Mutex
+	// Planned record sets by view, zone, owner name, type and class, so that
+	// "@" and the zone name, or a relative and a fully qualified name, match
+	planned map[string]bool
+	// Number of replacements planned per record set whose create re-plan is
+	// still to come
+	replacing map[string]int
+}
+
+// newRRsetOwners creates an empty registry of planned record sets
+func newRRsetOwners() *rrsetOwners {
+	return &rrsetOwners{planned: make(map[string]bool), replacing: make(map[string]int)}
+}
+
+// claim records a record set planned by a resource and returns false if
+// another resource planned it before. replace tells that the plan, made
+// with prior state, replaces the resource, and create that the plan has no
+// prior state; the create re-plan of a replacement keeps the claim of the
+// first plan.
+func (o *rrsetOwners) claim(ctx context.Context, zone, name, recordType, class string, replace, create bool) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key := strings.Join([]string{
+		viewCacheKey(ctx, zone),
+		strings.ToLower(recordFQDN(zone, name)),
+		strings.ToUpper(recordType),
+		strings.ToUpper(class),
+	}, "/")
+	if o.planned[key] {
+		if create && o.replacing[key] > 0 {
+			o.replacing[key]--
+			return true
+		}
+		return false
+	}
+	o.planned[key] = true
+	if replace {
+		o.replacing[key]++
+	}
+	return true
+}
+
+// planRRsetOwner fails the plan of a record set that another bind9_record
+// resource of the same configuration already manages
+func planRRsetOwner(ctx context.Context, client *Client, zone, name, recordType, class string, replace, create bool, diags *diag.Diagnostics) {
+	if client.rrsetOwners.claim(ctx, zone, name, recordType, class, replace, create) {
+		return
+	}
+	addCodedAttributeError(diags, path.Root("name"), ErrCodeConflict, "Duplicate Record Set",
+		fmt.Sprintf("Another bind9_record resource in this configuration also manages the %s %s records of %s in zone %s. "+
+			"Each would remove the other's values on every apply. Manage the record set with a single bind9_record resource "+
+			"listing all its values, or use bind9_rrset_entry resources to manage its values separately.",
+			strings.ToUpper(class), strings.ToUpper(recordType), name, zone))
+}
@@ -0,0 +1,167 @@
+// Plan modifiers shared by resources
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// dnsNameString returns a plan modifier that keeps the prior state value
+// when the planned value names the same DNS name, differing from it only in
+// letter case or a trailing dot. DNS names are case-insensitive, and the API
+// returns them fully qualified, so such a change must not produce a diff.
+func dnsNameString() planmodifier.String {
+	return dnsNameStringModifier{}
+}
+
+type dnsNameStringModifier struct{}
+
+func (m dnsNameStringModifier) Description(ctx context.Context) string {
+	return "Ignores changes that differ only in letter case or a trailing dot."
+}
+
+func (m dnsNameStringModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m dnsNameStringModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if sameDNSName(req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// recordNameString returns a plan modifier for the name of a record in the
+// planned zone. Besides changes in letter case or a trailing dot, it ignores
+// changes between spellings of the same owner: "@", "" and the zone's fully
+// qualified name for the zone apex, and a relative name and its fully
+// qualified form.
+func recordNameString() planmodifier.String {
+	return recordNameStringModifier{}
+}
+
+type recordNameStringModifier struct{}
+
+func (m recordNameStringModifier) Description(ctx context.Context) string {
+	return "Ignores changes between spellings of the same record owner name."
+}
+
+func (m recordNameStringModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordNameStringModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var zone types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if sameOwnerName(zone.ValueString(), req.StateValue.ValueString(), req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// sameOwnerName reports whether two record names are the same owner in a
+// zone. Without a zone, only the spelling of the names is compared.
+func sameOwnerName(zone, a, b string) bool {
+	if sameDNSName(a, b) {
+		return true
+	}
+	if zone == "" {
+		return false
+	}
+	return sameDNSName(recordFQDN(zone, a), recordFQDN(zone, b))
+}
+
+// sameDNSName reports whether two names are the same DNS name, ignoring
+// letter case and a trailing dot
+func sameDNSName(a, b string) bool {
+	if a != "." {
+		a = strings.TrimSuffix(a, ".")
+	}
+	if b != "." {
+		b = strings.TrimSuffix(b, ".")
+	}
+	return strings.EqualFold(a, b)
+}
+
+// ipAddressString returns a plan modifier that keeps the prior state value
+// when the planned value is the same IP address spelled differently, such as
+// 2001:0db8::1 for 2001:db8::1
+func ipAddressString() planmodifier.String {
+	return ipAddressStringModifier{}
+}
+
+type ipAddressStringModifier struct{}
+
+func (m ipAddressStringModifier) Description(ctx context.Context) string {
+	return "Ignores changes that spell the same IP address differently."
+}
+
+func (m ipAddressStringModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ipAddressStringModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if canonicalAddress(req.StateValue.ValueString()) == canonicalAddress(req.PlanValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// rdataComparison returns a plan modifier for the records attribute that
+// keeps the prior state value when the planned values are equivalent under
+// the resource's rdata_case_sensitive setting
+func rdataComparison() planmodifier.Set {
+	return rdataComparisonModifier{}
+}
+
+type rdataComparisonModifier struct{}
+
+func (m rdataComparisonModifier) Description(ctx context.Context) string {
+	return "Ignores record data changes that are equivalent under the configured comparison mode."
+}
+
+func (m rdataComparisonModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m rdataComparisonModifier) PlanModifySet(ctx context.Context, req planmodifier.SetRequest, resp *planmodifier.SetResponse) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var caseSensitive types.Bool
+	var recordType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rdata_case_sensitive"), &caseSensitive)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	if resp.Diagnostics.HasError() || caseSensitive.IsUnknown() || recordType.IsUnknown() {
+		return
+	}
+	sensitive := rdataCaseSensitiveFor(recordType.ValueString(), caseSensitive.IsNull() || caseSensitive.ValueBool())
+
+	var planned, prior []string
+	resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &planned, false)...)
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() || !rdataSetsEqual(recordType.ValueString(), planned, prior, sensitive) {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
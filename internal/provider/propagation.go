@@ -0,0 +1,174 @@
+// Waiting for record changes to reach the authoritative servers
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/miekg/dns"
+)
+
+const (
+	// Defaults of the wait_for block
+	defaultPropagationTimeout  = 5 * time.Minute
+	defaultPropagationInterval = 5 * time.Second
+
+	// Timeout of a single query to a nameserver
+	propagationQueryTimeout = 5 * time.Second
+)
+
+// WaitForModel describes the wait_for block
+type WaitForModel struct {
+	Nameservers types.List  `tfsdk:"nameservers"`
+	Timeout     types.Int64 `tfsdk:"timeout"`
+	Interval    types.Int64 `tfsdk:"interval"`
+}
+
+// propagationWait holds the settings of a wait for record propagation
+type propagationWait struct {
+	nameservers []string
+	timeout     time.Duration
+	interval    time.Duration
+}
+
+// newPropagationWait returns the settings of a wait_for block, with defaults
+// for omitted values
+func newPropagationWait(ctx context.Context, m WaitForModel) (propagationWait, diag.Diagnostics) {
+	w := propagationWait{
+		timeout:  defaultPropagationTimeout,
+		interval: defaultPropagationInterval,
+	}
+	diags := m.Nameservers.ElementsAs(ctx, &w.nameservers, false)
+	if !m.Timeout.IsNull() && !m.Timeout.IsUnknown() {
+		w.timeout = time.Duration(m.Timeout.ValueInt64()) * time.Second
+	}
+	if !m.Interval.IsNull() && !m.Interval.IsUnknown() {
+		w.interval = time.Duration(m.Interval.ValueInt64()) * time.Second
+	}
+	return w, diags
+}
+
+// waitForPropagation queries each nameserver until it answers for the record
+// set with exactly the given values, or the timeout passes. Queries are
+// non-recursive, so the nameservers must be authoritative for the zone.
+func waitForPropagation(ctx context.Context, w propagationWait, zone, name, recordType, class string, records []string) error {
+	owner := ownerName(zone, name)
+	qtype, ok := dns.StringToType[strings.ToUpper(recordType)]
+	if !ok {
+		return fmt.Errorf("record type %s cannot be queried", recordType)
+	}
+
+	// Parse the values, which completes relative names in them, so they
+	// can be compared with the answers
+	var want []dns.RR
+	for _, rdata := range records {
+		rr, err := parseRR(zone, name, 0, class, recordType, writeRdata(recordType, rdata))
+		if err != nil {
+			return err
+		}
+		want = append(want, rr)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	pending := make(map[string]string, len(w.nameservers))
+	for _, ns := range w.nameservers {
+		pending[ns] = "not queried"
+	}
+
+	for {
+		for ns := range pending {
+			got, err := queryRRset(ctx, dnsServerAddr(ns), owner, qtype, classCode(class))
+			switch {
+			case err != nil:
+				pending[ns] = err.Error()
+			case len(got) != len(want):
+				pending[ns] = fmt.Sprintf("answered %d values, %d expected", len(got), len(want))
+			case !sameRRset(got, want):
+				pending[ns] = fmt.Sprintf("%d of %d values match", countMatching(got, want), len(want))
+			default:
+				delete(pending, ns)
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for record propagation", map[string]any{
+			"name":    owner,
+			"type":    recordType,
+			"pending": len(pending),
+		})
+
+		timer := time.NewTimer(w.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			var details []string
+			for ns, status := range pending {
+				details = append(details, fmt.Sprintf("%s: %s", ns, status))
+			}
+			sort.Strings(details)
+			return fmt.Errorf("%s %s did not propagate within %s (%s)", strings.TrimSuffix(owner, "."), recordType, w.timeout, strings.Join(details, "; "))
+		case <-timer.C:
+		}
+	}
+}
+
+// queryRRset asks a nameserver for the records of a type at a name, without
+// recursion
+func queryRRset(ctx context.Context, server, owner string, qtype, qclass uint16) ([]dns.RR, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(owner, qtype)
+	m.Question[0].Qclass = qclass
+	m.RecursionDesired = false
+
+	client := &dns.Client{Timeout: propagationQueryTimeout}
+	resp, _, err := client.ExchangeContext(ctx, m, server)
+	if err == nil && resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.ExchangeContext(ctx, m, server)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return nil, &RcodeError{Rcode: resp.Rcode, Server: server}
+	}
+
+	var rrs []dns.RR
+	for _, rr := range resp.Answer {
+		if rr.Header().Rrtype == qtype && strings.EqualFold(rr.Header().Name, owner) {
+			rrs = append(rrs, rr)
+		}
+	}
+	return rrs, nil
+}
+
+// sameRRset reports whether two record sets hold the same records, ignoring
+// TTLs
+func sameRRset(got, want []dns.RR) bool {
+	return len(got) == len(want) && countMatching(got, want) == len(want)
+}
+
+// countMatching returns the number of wanted records that are in got
+func countMatching(got, want []dns.RR) int {
+	n := 0
+	for _, w := range want {
+		for _, g := range got {
+			if dns.IsDuplicate(g, w) {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
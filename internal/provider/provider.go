@@ -4,13 +4,21 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
 	"os"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -29,8 +37,99 @@ type Bind9ProviderModel struct {
 	APIKey   types.String `tfsdk:"api_key"`
 	Username types.String `tfsdk:"username"`
 	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
 	Insecure types.Bool   `tfsdk:"insecure"`
 	Timeout  types.Int64  `tfsdk:"timeout"`
+
+	TLSServerName types.String `tfsdk:"tls_server_name"`
+	TLSMinVersion types.String `tfsdk:"tls_min_version"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	MaxRetries            types.Int64 `tfsdk:"max_retries"`
+	MaxIdleConnsPerHost   types.Int64 `tfsdk:"max_idle_conns_per_host"`
+	KeepAlive             types.Int64 `tfsdk:"keepalive"`
+	IdleConnTimeout       types.Int64 `tfsdk:"idle_conn_timeout"`
+	SkipHealthCheck       types.Bool  `tfsdk:"skip_health_check"`
+
+	SuppressNotifyDuringApply types.Bool `tfsdk:"suppress_notify_during_apply"`
+	PrefetchRecords           types.Bool `tfsdk:"prefetch_records"`
+	RecordTransactions        types.Bool `tfsdk:"record_transactions"`
+	LogRequestMetrics         types.Bool `tfsdk:"log_request_metrics"`
+
+	DefaultTTL   types.Int64  `tfsdk:"default_ttl"`
+	DefaultClass types.String `tfsdk:"default_class"`
+
+	Policy *Bind9PolicyModel `tfsdk:"policy"`
+
+	ZoneDefaults *Bind9ZoneDefaultsModel `tfsdk:"zone_defaults"`
+
+	DNSUpdate *Bind9DNSUpdateModel `tfsdk:"dns_update"`
+	DNSQuery  *Bind9DNSQueryModel  `tfsdk:"dns_query"`
+	RNDC      *Bind9RNDCModel      `tfsdk:"rndc"`
+
+	StatisticsURL types.String `tfsdk:"statistics_url"`
+
+	SSHTunnel *Bind9SSHTunnelModel `tfsdk:"ssh_tunnel"`
+}
+
+// Bind9PolicyModel describes the advisory DNS policy checked at plan time
+type Bind9PolicyModel struct {
+	MaxSOAMinimum types.Int64 `tfsdk:"max_soa_minimum"`
+	MinRecordTTL  types.Int64 `tfsdk:"min_record_ttl"`
+	MaxRecordTTL  types.Int64 `tfsdk:"max_record_ttl"`
+}
+
+// Bind9ZoneDefaultsModel describes the settings bind9_zone resources
+// inherit when their configuration leaves them out
+type Bind9ZoneDefaultsModel struct {
+	SOAMname      types.String `tfsdk:"soa_mname"`
+	SOARname      types.String `tfsdk:"soa_rname"`
+	SOARefresh    types.Int64  `tfsdk:"soa_refresh"`
+	SOARetry      types.Int64  `tfsdk:"soa_retry"`
+	SOAExpire     types.Int64  `tfsdk:"soa_expire"`
+	SOAMinimum    types.Int64  `tfsdk:"soa_minimum"`
+	DefaultTTL    types.Int64  `tfsdk:"default_ttl"`
+	Nameservers   types.List   `tfsdk:"nameservers"`
+	AllowTransfer types.List   `tfsdk:"allow_transfer"`
+	AllowQuery    types.List   `tfsdk:"allow_query"`
+}
+
+// Bind9DNSUpdateModel describes the RFC 2136 dynamic update transport
+type Bind9DNSUpdateModel struct {
+	Server       types.String `tfsdk:"server"`
+	KeyFile      types.String `tfsdk:"key_file"`
+	KeyName      types.String `tfsdk:"key_name"`
+	KeySecret    types.String `tfsdk:"key_secret"`
+	KeyAlgorithm types.String `tfsdk:"key_algorithm"`
+}
+
+// Bind9DNSQueryModel describes the DNS queries that verify records read
+// through the REST API
+type Bind9DNSQueryModel struct {
+	Server       types.String `tfsdk:"server"`
+	KeyFile      types.String `tfsdk:"key_file"`
+	KeyName      types.String `tfsdk:"key_name"`
+	KeySecret    types.String `tfsdk:"key_secret"`
+	KeyAlgorithm types.String `tfsdk:"key_algorithm"`
+	Always       types.Bool   `tfsdk:"always"`
+}
+
+// Bind9RNDCModel describes the rndc control channel used for zone operations
+type Bind9RNDCModel struct {
+	Server       types.String `tfsdk:"server"`
+	KeyFile      types.String `tfsdk:"key_file"`
+	KeySecret    types.String `tfsdk:"key_secret"`
+	KeyAlgorithm types.String `tfsdk:"key_algorithm"`
+}
+
+// Bind9SSHTunnelModel describes the SSH jump host used to reach the servers
+type Bind9SSHTunnelModel struct {
+	Host           types.String `tfsdk:"host"`
+	User           types.String `tfsdk:"user"`
+	PrivateKey     types.String `tfsdk:"private_key"`
+	PrivateKeyFile types.String `tfsdk:"private_key_file"`
+	HostKey        types.String `tfsdk:"host_key"`
+	KnownHostsFile types.String `tfsdk:"known_hosts_file"`
 }
 
 // New creates a new provider instance
@@ -61,6 +160,7 @@ The provider supports two authentication methods:
 
 1. **API Key** (recommended): Set the ` + "`api_key`" + ` attribute or ` + "`BIND9_API_KEY`" + ` environment variable
 2. **Username/Password**: Set ` + "`username`" + ` and ` + "`password`" + ` attributes or ` + "`BIND9_USERNAME`" + ` and ` + "`BIND9_PASSWORD`" + ` environment variables
+3. **Bearer Token**: Set the ` + "`token`" + ` attribute or ` + "`BIND9_TOKEN`" + ` environment variable to a JWT issued outside Terraform
 
 ## Example Usage
 
@@ -86,7 +186,7 @@ resource "bind9_record" "www" {
 `,
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				Description: "BIND9 REST API endpoint URL (e.g., https://dns.example.com:8080). Can also be set via BIND9_ENDPOINT environment variable.",
+				Description: "BIND9 REST API endpoint URL (e.g., https://dns.example.com:8080). A path is kept as the base path of an API served below the root (e.g., https://dns.corp/api-gw/bind9). Can also be set via BIND9_ENDPOINT environment variable.",
 				Optional:    true,
 			},
 			"api_key": schema.StringAttribute{
@@ -103,14 +203,304 @@ resource "bind9_record" "www" {
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"token": schema.StringAttribute{
+				Description: "Pre-issued bearer token (JWT), used instead of username/password to authenticate, e.g. a short-lived token minted by Vault in CI. Can also be set via BIND9_TOKEN environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+			},
 			"insecure": schema.BoolAttribute{
 				Description: "Skip TLS certificate verification. Default: false",
 				Optional:    true,
 			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "Server name sent (SNI) to the endpoint and expected in its certificate, instead of the endpoint's host. " +
+					"Use when the endpoint is reached by IP address, or through a load balancer whose certificate names another host.",
+				Optional: true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				Description: "Minimum TLS version accepted (1.0, 1.1, 1.2 or 1.3). Default: 1.2",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("1.0", "1.1", "1.2", "1.3"),
+				},
+			},
 			"timeout": schema.Int64Attribute{
 				Description: "API request timeout in seconds. Default: 30",
 				Optional:    true,
 			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description: "Maximum number of API requests in flight at once. Lower this if the API reports intermittent errors under load. Default: unlimited",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times GET and DELETE requests are retried after network errors such as connection resets, with exponential backoff. 0 disables retries, and at most 10 are allowed. Default: 3",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, maxMaxRetries),
+				},
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Description: "Maximum number of idle connections kept open to the API for reuse. Default: 10",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"keepalive": schema.Int64Attribute{
+				Description: "Interval in seconds between TCP keepalive probes on API connections. 0 disables keepalive. Default: 30",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"idle_conn_timeout": schema.Int64Attribute{
+				Description: "Seconds an idle API connection is kept open before it is closed. 0 keeps idle connections open indefinitely. Default: 90",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"skip_health_check": schema.BoolAttribute{
+				Description: "Skip the authenticated connectivity check performed when the provider is configured. Default: false",
+				Optional:    true,
+			},
+			"suppress_notify_during_apply": schema.BoolAttribute{
+				Description: "Disable NOTIFY on zones while their records are being changed, then re-enable it and send a single NOTIFY once the zone is quiet or the apply ends. Default: false",
+				Optional:    true,
+			},
+			"prefetch_records": schema.BoolAttribute{
+				Description: "Read bind9_record resources from whole-zone listings, fetching all zones concurrently at the start of refresh. Default: false",
+				Optional:    true,
+			},
+			"record_transactions": schema.BoolAttribute{
+				Description: "Commit the bind9_record changes applied to a zone within 250 ms of each other as one atomic transaction, so that either all of them take effect or none does. " +
+					"Uses a single dynamic update with dns_update, and the API's transaction endpoint otherwise. Default: false",
+				Optional: true,
+			},
+			"log_request_metrics": schema.BoolAttribute{
+				Description: "Log a summary of the API requests made during the run (counts by method, errors by status and latency) when Terraform finishes. " +
+					"The summary is logged at INFO level, shown with TF_LOG_PROVIDER=INFO. Default: false",
+				Optional: true,
+			},
+			"default_ttl": schema.Int64Attribute{
+				Description: "TTL applied to bind9_record resources that do not set ttl. Default: 3600",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"default_class": schema.StringAttribute{
+				Description: "Class applied to bind9_record resources that do not set class (IN, CH, HS). Default: IN",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+			"policy": schema.SingleNestedAttribute{
+				Description: "Advisory DNS policy. Violations are reported as warnings during plan and never block an apply.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"max_soa_minimum": schema.Int64Attribute{
+						Description: "Warn when a zone's soa_minimum (negative caching TTL) exceeds this value. Default: 10800, the default max-ncache-ttl of BIND9 resolvers",
+						Optional:    true,
+					},
+					"min_record_ttl": schema.Int64Attribute{
+						Description: "Warn when a record TTL is below this value",
+						Optional:    true,
+					},
+					"max_record_ttl": schema.Int64Attribute{
+						Description: "Warn when a record TTL is above this value",
+						Optional:    true,
+					},
+				},
+			},
+			"zone_defaults": schema.SingleNestedAttribute{
+				Description: "Settings inherited by bind9_zone resources that leave them out of their configuration.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"soa_mname": schema.StringAttribute{
+						Description: "Primary nameserver for the SOA record of zones",
+						Optional:    true,
+					},
+					"soa_rname": schema.StringAttribute{
+						Description: "Responsible person email for the SOA record of zones (use . instead of @)",
+						Optional:    true,
+					},
+					"soa_refresh": schema.Int64Attribute{
+						Description: "SOA refresh interval of zones in seconds",
+						Optional:    true,
+					},
+					"soa_retry": schema.Int64Attribute{
+						Description: "SOA retry interval of zones in seconds",
+						Optional:    true,
+					},
+					"soa_expire": schema.Int64Attribute{
+						Description: "SOA expire time of zones in seconds",
+						Optional:    true,
+					},
+					"soa_minimum": schema.Int64Attribute{
+						Description: "SOA minimum/negative TTL of zones in seconds",
+						Optional:    true,
+					},
+					"default_ttl": schema.Int64Attribute{
+						Description: "Default TTL for records of zones",
+						Optional:    true,
+					},
+					"nameservers": schema.ListAttribute{
+						Description: "Authoritative nameservers of zones",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"allow_transfer": schema.ListAttribute{
+						Description: "ACL for transfers of zones",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+					"allow_query": schema.ListAttribute{
+						Description: "ACL for queries of zones",
+						Optional:    true,
+						ElementType: types.StringType,
+					},
+				},
+			},
+			"statistics_url": schema.StringAttribute{
+				Description: "URL of the BIND9 statistics channel (statistics-channels in named.conf), e.g. http://dns.example.com:8053. " +
+					"Used by the statistics data sources. Can also be set via BIND9_STATISTICS_URL environment variable.",
+				Optional: true,
+			},
+			"dns_update": schema.SingleNestedAttribute{
+				Description: "Manage bind9_record resources with RFC 2136 dynamic updates (as nsupdate does) instead of the REST API. " +
+					"The endpoint is then only needed for zones, ACLs and DNSSEC keys.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"server": schema.StringAttribute{
+						Description: "Primary server accepting dynamic updates, as host or host:port. Default port: 53",
+						Required:    true,
+					},
+					"key_file": schema.StringAttribute{
+						Description: "Path to a BIND key file (as written by tsig-keygen or ddns-confgen) providing the key name, algorithm and secret. " +
+							"If the file contains several keys, key_name selects one. Attributes set explicitly take precedence over the file.",
+						Optional: true,
+					},
+					"key_name": schema.StringAttribute{
+						Description: "Name of the TSIG key used to sign updates. Updates are unsigned if neither this nor key_file is set.",
+						Optional:    true,
+					},
+					"key_secret": schema.StringAttribute{
+						Description: "Base64 TSIG key secret. Can also be set via BIND9_TSIG_KEY_SECRET environment variable.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"key_algorithm": schema.StringAttribute{
+						Description: "TSIG algorithm (hmac-md5, hmac-sha1, hmac-sha224, hmac-sha256, hmac-sha384, hmac-sha512). Default: hmac-sha256",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"),
+						},
+					},
+				},
+			},
+			"dns_query": schema.SingleNestedAttribute{
+				Description: "Verify records with DNS queries sent directly to the server when the REST API lists none, so records of dynamic zones " +
+					"that are only in the zone journal are still seen, and records deleted outside Terraform are detected. " +
+					"Not needed with dns_update, which always reads records with DNS queries.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"server": schema.StringAttribute{
+						Description: "Server to query, as host or host:port. Default port: 53",
+						Required:    true,
+					},
+					"key_file": schema.StringAttribute{
+						Description: "Path to a BIND key file providing the key name, algorithm and secret used to sign queries. " +
+							"If the file contains several keys, key_name selects one. Attributes set explicitly take precedence over the file.",
+						Optional: true,
+					},
+					"key_name": schema.StringAttribute{
+						Description: "Name of the TSIG key used to sign queries. Queries are unsigned if neither this nor key_file is set.",
+						Optional:    true,
+					},
+					"key_secret": schema.StringAttribute{
+						Description: "Base64 TSIG key secret. Can also be set via BIND9_DNS_QUERY_KEY_SECRET environment variable.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"key_algorithm": schema.StringAttribute{
+						Description: "TSIG algorithm (hmac-md5, hmac-sha1, hmac-sha224, hmac-sha256, hmac-sha384, hmac-sha512). Default: hmac-sha256",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"),
+						},
+					},
+					"always": schema.BoolAttribute{
+						Description: "Query the server on every record read, not only when the REST API lists no records, " +
+							"for servers whose API shows the zone file without the changes in the journal. Default: false",
+						Optional: true,
+					},
+				},
+			},
+			"rndc": schema.SingleNestedAttribute{
+				Description: "rndc control channel used for zone reload, freeze, thaw and sign when the REST API does not provide them. " +
+					"Record changes always use the REST API or dns_update.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"server": schema.StringAttribute{
+						Description: "Control channel address of named, as host or host:port. Default port: 953",
+						Required:    true,
+					},
+					"key_file": schema.StringAttribute{
+						Description: "Path to the rndc key file (rndc.key) providing the key algorithm and secret. Attributes set explicitly take precedence over the file.",
+						Optional:    true,
+					},
+					"key_secret": schema.StringAttribute{
+						Description: "Base64 secret of the rndc key. Can also be set via BIND9_RNDC_KEY_SECRET environment variable.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"key_algorithm": schema.StringAttribute{
+						Description: "rndc key algorithm (hmac-md5, hmac-sha1, hmac-sha224, hmac-sha256, hmac-sha384, hmac-sha512). Default: hmac-sha256",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf("hmac-md5", "hmac-sha1", "hmac-sha224", "hmac-sha256", "hmac-sha384", "hmac-sha512"),
+						},
+					},
+				},
+			},
+			"ssh_tunnel": schema.SingleNestedAttribute{
+				Description: "Reach the REST API, the statistics channel and rndc through an SSH jump host (bastion), as ssh -J does. " +
+					"Dynamic updates (dns_update) are not tunneled.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Description: "Jump host address, as host or host:port. Default port: 22",
+						Required:    true,
+					},
+					"user": schema.StringAttribute{
+						Description: "User to log in to the jump host as",
+						Required:    true,
+					},
+					"private_key": schema.StringAttribute{
+						Description: "PEM-encoded private key used to log in. Can also be set via BIND9_SSH_PRIVATE_KEY environment variable. " +
+							"The keys of the running ssh-agent are used if neither this nor private_key_file is set.",
+						Optional:  true,
+						Sensitive: true,
+					},
+					"private_key_file": schema.StringAttribute{
+						Description: "Path to the private key used to log in",
+						Optional:    true,
+					},
+					"host_key": schema.StringAttribute{
+						Description: "Expected public host key of the jump host, as in known_hosts (e.g. \"ssh-ed25519 AAAA...\")",
+						Optional:    true,
+					},
+					"known_hosts_file": schema.StringAttribute{
+						Description: "known_hosts file used to verify the jump host when host_key is not set. Default: ~/.ssh/known_hosts",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -131,6 +521,7 @@ func (p *Bind9Provider) Configure(ctx context.Context, req provider.ConfigureReq
 	apiKey := os.Getenv("BIND9_API_KEY")
 	username := os.Getenv("BIND9_USERNAME")
 	password := os.Getenv("BIND9_PASSWORD")
+	token := os.Getenv("BIND9_TOKEN")
 
 	// Override with config values if set
 	if !config.Endpoint.IsNull() {
@@ -145,22 +536,122 @@ func (p *Bind9Provider) Configure(ctx context.Context, req provider.ConfigureReq
 	if !config.Password.IsNull() {
 		password = config.Password.ValueString()
 	}
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
 
-	// Validate required configuration
-	if endpoint == "" {
-		resp.Diagnostics.AddAttributeError(
+	statisticsURL := os.Getenv("BIND9_STATISTICS_URL")
+	if !config.StatisticsURL.IsNull() {
+		statisticsURL = config.StatisticsURL.ValueString()
+	}
+
+	// Validate required configuration. The REST API is optional when record
+	// changes are sent as dynamic updates or only statistics are read.
+	if endpoint == "" && config.DNSUpdate == nil && statisticsURL == "" {
+		addCodedAttributeError(
+			&resp.Diagnostics,
 			path.Root("endpoint"),
+			ErrCodeConfigInvalid,
 			"Missing BIND9 API Endpoint",
 			"The provider cannot create the BIND9 API client as there is a missing or empty value for the BIND9 API endpoint. "+
 				"Set the endpoint value in the configuration or use the BIND9_ENDPOINT environment variable.",
 		)
 	}
 
-	if apiKey == "" && (username == "" || password == "") {
-		resp.Diagnostics.AddError(
+	if _, err := normalizeEndpoint(endpoint); err != nil {
+		addCodedAttributeError(
+			&resp.Diagnostics,
+			path.Root("endpoint"),
+			ErrCodeConfigInvalid,
+			"Invalid BIND9 API Endpoint",
+			"The BIND9 API endpoint must be the base URL of the API, such as https://dns.example.com:8080, "+
+				"or https://dns.corp/api-gw/bind9 for an API served below a path: "+err.Error(),
+		)
+	}
+
+	if endpoint != "" && apiKey == "" && token == "" && (username == "" || password == "") {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
 			"Missing Authentication",
-			"The provider requires either an API key or username/password for authentication. "+
-				"Set api_key or username/password in the configuration, or use environment variables.",
+			"The provider requires an API key, a bearer token or username/password for authentication. "+
+				"Set api_key, token or username/password in the configuration, or use environment variables.",
+		)
+	}
+
+	var tsig tsigKey
+	if config.DNSUpdate != nil {
+		tsig = resolveKey(&resp.Diagnostics, path.Root("dns_update"), config.DNSUpdate.KeyFile,
+			config.DNSUpdate.KeyName, config.DNSUpdate.KeySecret, config.DNSUpdate.KeyAlgorithm, "BIND9_TSIG_KEY_SECRET")
+		if tsig.name != "" && tsig.secret == "" {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("dns_update").AtName("key_secret"),
+				ErrCodeConfigInvalid,
+				"Missing TSIG Key Secret",
+				"A TSIG key name is set for dynamic updates, but no key secret. "+
+					"Set key_file or key_secret in the dns_update block, or use the BIND9_TSIG_KEY_SECRET environment variable.",
+			)
+		}
+		if _, ok := tsigAlgorithms[tsig.algorithm]; tsig.name != "" && !ok {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("dns_update").AtName("key_file"),
+				ErrCodeConfigInvalid,
+				"Unsupported TSIG Algorithm",
+				fmt.Sprintf("TSIG key %s uses algorithm %s, which is not supported for dynamic updates.", tsig.name, tsig.algorithm),
+			)
+		}
+	}
+
+	var queryKey tsigKey
+	if config.DNSQuery != nil {
+		queryKey = resolveKey(&resp.Diagnostics, path.Root("dns_query"), config.DNSQuery.KeyFile,
+			config.DNSQuery.KeyName, config.DNSQuery.KeySecret, config.DNSQuery.KeyAlgorithm, "BIND9_DNS_QUERY_KEY_SECRET")
+		if queryKey.name != "" && queryKey.secret == "" {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("dns_query").AtName("key_secret"),
+				ErrCodeConfigInvalid,
+				"Missing TSIG Key Secret",
+				"A TSIG key name is set for DNS queries, but no key secret. "+
+					"Set key_file or key_secret in the dns_query block, or use the BIND9_DNS_QUERY_KEY_SECRET environment variable.",
+			)
+		}
+		if _, ok := tsigAlgorithms[queryKey.algorithm]; queryKey.name != "" && !ok {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("dns_query").AtName("key_file"),
+				ErrCodeConfigInvalid,
+				"Unsupported TSIG Algorithm",
+				fmt.Sprintf("TSIG key %s uses algorithm %s, which is not supported for DNS queries.", queryKey.name, queryKey.algorithm),
+			)
+		}
+	}
+
+	var rndcKey tsigKey
+	if config.RNDC != nil {
+		rndcKey = resolveKey(&resp.Diagnostics, path.Root("rndc"), config.RNDC.KeyFile,
+			types.StringNull(), config.RNDC.KeySecret, config.RNDC.KeyAlgorithm, "BIND9_RNDC_KEY_SECRET")
+		if rndcKey.secret == "" && !resp.Diagnostics.HasError() {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("rndc").AtName("key_secret"),
+				ErrCodeConfigInvalid,
+				"Missing rndc Key Secret",
+				"The rndc control channel requires the key secret from rndc.key. "+
+					"Set key_file or key_secret in the rndc block, or use the BIND9_RNDC_KEY_SECRET environment variable.",
+			)
+		}
+	}
+
+	if endpoint == "" && !config.SuppressNotifyDuringApply.IsNull() && config.SuppressNotifyDuringApply.ValueBool() {
+		addCodedAttributeError(
+			&resp.Diagnostics,
+			path.Root("suppress_notify_during_apply"),
+			ErrCodeConfigInvalid,
+			"NOTIFY Suppression Requires the REST API",
+			"suppress_notify_during_apply toggles zone NOTIFY through the REST API. Set endpoint or remove this setting.",
 		)
 	}
 
@@ -180,28 +671,264 @@ func (p *Bind9Provider) Configure(ctx context.Context, req provider.ConfigureReq
 	}
 
 	// Create the API client
-	client, err := NewClient(endpoint, apiKey, username, password, insecure, timeout)
+	client, err := NewClient(endpoint, apiKey, username, password, insecure, timeout, p.version)
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Unable to Create BIND9 API Client",
-			"An unexpected error occurred when creating the BIND9 API client. "+
-				"Error: "+err.Error(),
+			"An unexpected error occurred when creating the BIND9 API client",
+			err,
 		)
 		return
 	}
 
-	tflog.Debug(ctx, "Created BIND9 client", map[string]any{"endpoint": endpoint})
+	if token != "" {
+		client.setToken(token)
+	}
+
+	if !config.TLSServerName.IsNull() || !config.TLSMinVersion.IsNull() {
+		minVersion := uint16(tls.VersionTLS12)
+		if !config.TLSMinVersion.IsNull() {
+			minVersion = tlsVersions[config.TLSMinVersion.ValueString()]
+		}
+		client.setTLSOptions(config.TLSServerName.ValueString(), minVersion)
+	}
+
+	if !config.MaxConcurrentRequests.IsNull() {
+		client.setMaxConcurrentRequests(config.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !config.MaxRetries.IsNull() {
+		client.setMaxRetries(config.MaxRetries.ValueInt64())
+	}
+
+	if !config.MaxIdleConnsPerHost.IsNull() || !config.KeepAlive.IsNull() || !config.IdleConnTimeout.IsNull() {
+		opts := defaultTransportOptions
+		if !config.MaxIdleConnsPerHost.IsNull() {
+			opts.maxIdleConnsPerHost = int(config.MaxIdleConnsPerHost.ValueInt64())
+		}
+		if !config.KeepAlive.IsNull() {
+			opts.keepAlive = time.Duration(config.KeepAlive.ValueInt64()) * time.Second
+			if opts.keepAlive == 0 {
+				opts.keepAlive = -1
+			}
+		}
+		if !config.IdleConnTimeout.IsNull() {
+			opts.idleConnTimeout = time.Duration(config.IdleConnTimeout.ValueInt64()) * time.Second
+		}
+		client.setTransportOptions(opts)
+	}
+
+	if !config.SuppressNotifyDuringApply.IsNull() && config.SuppressNotifyDuringApply.ValueBool() {
+		client.notifySquelch = newNotifySquelcher(client)
+	}
+
+	if !config.PrefetchRecords.IsNull() && config.PrefetchRecords.ValueBool() {
+		client.recordCache = newRecordCache(client)
+	}
+
+	if !config.RecordTransactions.IsNull() && config.RecordTransactions.ValueBool() {
+		client.transactions = newRecordTransactions(client)
+	}
+
+	if !config.LogRequestMetrics.IsNull() && config.LogRequestMetrics.ValueBool() {
+		client.metrics = newRequestMetrics(ctx, endpoint)
+	}
+
+	if config.DNSUpdate != nil {
+		client.dnsUpdate = newDNSUpdater(
+			config.DNSUpdate.Server.ValueString(),
+			tsig.name,
+			tsig.secret,
+			tsig.algorithm,
+			time.Duration(timeout)*time.Second,
+		)
+	}
+
+	if config.DNSQuery != nil {
+		client.dnsQuery = newDNSUpdater(
+			config.DNSQuery.Server.ValueString(),
+			queryKey.name,
+			queryKey.secret,
+			queryKey.algorithm,
+			time.Duration(timeout)*time.Second,
+		)
+		client.dnsQueryAlways = config.DNSQuery.Always.ValueBool()
+	}
+
+	if config.RNDC != nil {
+		client.rndc, err = newRNDCClient(config.RNDC.Server.ValueString(), rndcKey.secret, rndcKey.algorithm, time.Duration(timeout)*time.Second)
+		if err != nil {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("rndc"),
+				ErrCodeConfigInvalid,
+				"Invalid rndc Configuration",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	client.statisticsURL = strings.TrimSuffix(statisticsURL, "/")
+
+	if config.SSHTunnel != nil {
+		privateKey := os.Getenv("BIND9_SSH_PRIVATE_KEY")
+		if !config.SSHTunnel.PrivateKey.IsNull() {
+			privateKey = config.SSHTunnel.PrivateKey.ValueString()
+		}
+		tunnel, err := newSSHTunnel(sshTunnelConfig{
+			host:           config.SSHTunnel.Host.ValueString(),
+			user:           config.SSHTunnel.User.ValueString(),
+			privateKey:     privateKey,
+			privateKeyFile: config.SSHTunnel.PrivateKeyFile.ValueString(),
+			hostKey:        config.SSHTunnel.HostKey.ValueString(),
+			knownHostsFile: config.SSHTunnel.KnownHostsFile.ValueString(),
+			timeout:        time.Duration(timeout) * time.Second,
+		})
+		if err != nil {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("ssh_tunnel"),
+				ErrCodeConfigInvalid,
+				"Invalid SSH Tunnel Configuration",
+				err.Error(),
+			)
+			return
+		}
+		client.setSSHTunnel(tunnel)
+	}
+
+	if err := client.login(ctx); err != nil {
+		addAPIError(
+			&resp.Diagnostics,
+			"Unable to Create BIND9 API Client",
+			"An unexpected error occurred when creating the BIND9 API client",
+			err,
+		)
+		return
+	}
+
+	// Record defaults inherited by bind9_record resources
+	if !config.DefaultTTL.IsNull() {
+		client.defaultTTL = config.DefaultTTL.ValueInt64()
+	}
+	if !config.DefaultClass.IsNull() {
+		client.defaultClass = config.DefaultClass.ValueString()
+	}
+
+	// Advisory policy checked by resources at plan time
+	if config.Policy != nil {
+		if !config.Policy.MaxSOAMinimum.IsNull() {
+			client.policy.maxSOAMinimum = config.Policy.MaxSOAMinimum.ValueInt64()
+		}
+		if !config.Policy.MinRecordTTL.IsNull() {
+			client.policy.minRecordTTL = config.Policy.MinRecordTTL.ValueInt64()
+		}
+		if !config.Policy.MaxRecordTTL.IsNull() {
+			client.policy.maxRecordTTL = config.Policy.MaxRecordTTL.ValueInt64()
+		}
+	}
+
+	// Zone settings inherited by bind9_zone resources at plan time
+	client.zoneDefaults = config.ZoneDefaults
+
+	if client.hasAPI() {
+		// Fail fast on unreachable endpoints or rejected credentials
+		if config.SkipHealthCheck.IsNull() || !config.SkipHealthCheck.ValueBool() {
+			if err := client.Ping(ctx); err != nil {
+				addHealthCheckError(&resp.Diagnostics, endpoint, err)
+				return
+			}
+		}
+
+		// Discover which features the API server supports
+		if err := client.negotiateVersion(ctx); err != nil {
+			tflog.Warn(ctx, "Could not determine BIND9 API version", map[string]any{"error": err.Error()})
+		}
+	}
+
+	tflog.Debug(ctx, "Created BIND9 client", map[string]any{
+		"endpoint":       endpoint,
+		"server_version": client.ServerVersion(),
+	})
 
 	// Make the client available during DataSource and Resource type Configure methods
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// resolveKey combines a BIND key file with the key attributes of a provider
+// block, which take precedence over the file. The secret falls back to the
+// given environment variable, and the algorithm to hmac-sha256.
+func resolveKey(diags *diag.Diagnostics, block path.Path, keyFile, name, secret, algorithm types.String, secretEnv string) tsigKey {
+	key := tsigKey{
+		name:      name.ValueString(),
+		algorithm: "hmac-sha256",
+		secret:    os.Getenv(secretEnv),
+	}
+
+	if !keyFile.IsNull() {
+		fileKey, err := loadKeyFile(keyFile.ValueString(), name.ValueString())
+		if err != nil {
+			addCodedAttributeError(diags, block.AtName("key_file"), ErrCodeConfigInvalid, "Invalid Key File", err.Error())
+			return key
+		}
+		key = *fileKey
+	}
+
+	if !secret.IsNull() {
+		key.secret = secret.ValueString()
+	}
+	if !algorithm.IsNull() {
+		key.algorithm = algorithm.ValueString()
+	}
+
+	return key
+}
+
+// addHealthCheckError adds a diagnostic describing why the health check failed
+func addHealthCheckError(diags *diag.Diagnostics, endpoint string, err error) {
+	switch code := classifyError(err); code {
+	case ErrCodeAuthFailed:
+		addCodedError(diags, code, "BIND9 API Authentication Failed",
+			fmt.Sprintf("The BIND9 API at %s rejected the configured credentials: %s", endpoint, err))
+	case ErrCodeConnectionFailed:
+		addCodedError(diags, code, "Unable to Connect to BIND9 API",
+			fmt.Sprintf("Could not reach the BIND9 API at %s: %s", endpoint, err))
+	default:
+		addCodedError(diags, code, "BIND9 API Health Check Failed",
+			fmt.Sprintf("The BIND9 API at %s returned an error during the health check. "+
+				"Set skip_health_check = true to bypass this check: %s", endpoint, err))
+	}
+}
+
+// checkServerFeature adds an error diagnostic and returns false if the API
+// server does not support the given feature
+func checkServerFeature(client *Client, feature, name string, diags *diag.Diagnostics) bool {
+	if client.SupportsFeature(feature) {
+		return true
+	}
+
+	addCodedError(
+		diags,
+		ErrCodeFeatureUnsupported,
+		"Server Does Not Support "+name,
+		fmt.Sprintf("The BIND9 REST API server (version %s) does not support %s.", client.ServerVersion(), name),
+	)
+	return false
+}
+
 // Resources defines the resources implemented in the provider
 func (p *Bind9Provider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewZoneResource,
+		NewReverseZoneResource,
 		NewRecordResource,
+		NewRRsetEntryResource,
+		NewRecordRangeResource,
+		NewRecordsBatchResource,
+		NewPTRRecordResource,
 		NewDNSSECKeyResource,
 		NewACLResource,
 	}
@@ -214,6 +941,12 @@ func (p *Bind9Provider) DataSources(ctx context.Context) []func() datasource.Dat
 		NewZonesDataSource,
 		NewRecordDataSource,
 		NewRecordsDataSource,
+		NewTransferStatsDataSource,
+		NewServerStatsDataSource,
+		NewCacheStatsDataSource,
+		NewZoneStatsDataSource,
+		NewZonesStatsDataSource,
+		NewLimitsDataSource,
 	}
 }
 
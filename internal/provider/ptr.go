@@ -0,0 +1,227 @@
+// Reverse (PTR) records managed along with A and AAAA records
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/miekg/dns"
+)
+
+// ptrAddresses returns the addresses of a record whose PTR records the
+// resource manages, or nil if create_ptr is off or the record is not an
+// address record
+func ptrAddresses(model *RecordResourceModel, records []string) []string {
+	if !model.CreatePTR.ValueBool() {
+		return nil
+	}
+	switch model.Type.ValueString() {
+	case "A", "AAAA":
+		return records
+	}
+	return nil
+}
+
+// syncPTRRecords removes the PTR records of the addresses in old that are
+// not in new, and adds PTR records pointing at the record's name for the
+// addresses in new that are not in old. Each PTR record goes to the reverse
+// zone on the server that holds its in-addr.arpa or ip6.arpa name, found
+// by reverseZone.
+func (r *RecordResource) syncPTRRecords(ctx context.Context, model *RecordResourceModel, old, new []string, diags *diag.Diagnostics) {
+	var removals, additions []string
+	for _, addr := range old {
+		if !containsRdata(model.Type.ValueString(), new, addr, false) {
+			removals = append(removals, addr)
+		}
+	}
+	for _, addr := range new {
+		if !containsRdata(model.Type.ValueString(), old, addr, false) {
+			additions = append(additions, addr)
+		}
+	}
+	if len(removals) == 0 && len(additions) == 0 {
+		return
+	}
+
+	zones, err := r.client.ListZones(ctx, nil)
+	if err != nil {
+		addAPIError(diags, "Error Finding Reverse Zone", "Could not list zones to find the reverse zones of the PTR records", err)
+		return
+	}
+
+	target := recordFQDN(model.Zone.ValueString(), model.owner()) + "."
+	changes := make(map[string][]RecordChange)
+	var order []string
+	addChange := func(action, addr string) bool {
+		reverse, err := dns.ReverseAddr(canonicalAddress(addr))
+		if err != nil {
+			addCodedAttributeError(diags, path.Root("records"), ErrCodeConfigInvalid, "Invalid Address",
+				fmt.Sprintf("Could not build the reverse name of %q: %s", addr, err))
+			return false
+		}
+		zone, name := reverseZone(addr, zones)
+		if zone == "" {
+			if action == recordChangeDelete {
+				// The reverse zone is gone, and its records with it
+				tflog.Debug(ctx, "No reverse zone for PTR record", map[string]any{"name": reverse})
+				return true
+			}
+			addCodedAttributeError(diags, path.Root("create_ptr"), ErrCodeNotFound, "No Reverse Zone",
+				fmt.Sprintf("No zone on the server contains %s, the reverse name of %s. Create the reverse zone first, or turn off create_ptr.",
+					strings.TrimSuffix(reverse, "."), addr))
+			return false
+		}
+		if _, ok := changes[zone]; !ok {
+			order = append(order, zone)
+		}
+		change := RecordChange{
+			Action:      action,
+			RecordType:  "PTR",
+			Name:        name,
+			RecordClass: model.Class.ValueString(),
+			RData:       target,
+		}
+		if action == recordChangeCreate {
+			change.TTL = int(model.TTL.ValueInt64())
+			change.Data = buildRecordData("PTR", target)
+		}
+		changes[zone] = append(changes[zone], change)
+		return true
+	}
+	for _, addr := range removals {
+		if !addChange(recordChangeDelete, addr) {
+			return
+		}
+	}
+	for _, addr := range additions {
+		if !addChange(recordChangeCreate, addr) {
+			return
+		}
+	}
+
+	useTransaction := useRecordTransactions(r.client, diags)
+	if diags.HasError() {
+		return
+	}
+
+	for _, zone := range order {
+		if !r.applyPTRChanges(ctx, zone, changes[zone], useTransaction, diags) {
+			return
+		}
+	}
+}
+
+// applyPTRChanges applies the PTR record changes of one reverse zone. It
+// returns false if a change failed.
+func (r *RecordResource) applyPTRChanges(ctx context.Context, zone string, changes []RecordChange, useTransaction bool, diags *diag.Diagnostics) bool {
+	r.client.beginZoneChange(ctx, zone)
+	defer r.client.endZoneChange(ctx, zone)
+
+	if useTransaction {
+		if err := r.client.transactions.submit(ctx, zone, changes); err != nil {
+			addRecordAPIError(diags, "Error Updating PTR Records", "Could not update the PTR records in zone "+zone, err)
+			return false
+		}
+		return true
+	}
+
+	// PTR records are separate record sets, not guarded by the ETag of the
+	// forward record set
+	rrCtx := withETag(ctx, nil)
+	for _, change := range changes {
+		if change.Action == recordChangeDelete {
+			err := r.client.DeleteRecord(rrCtx, zone, change.Name, "PTR", change.RecordClass, change.RData)
+			if err != nil && !isNotFound(err) {
+				addRecordAPIError(diags, "Error Deleting PTR Record", fmt.Sprintf("Could not delete PTR record %s in zone %s", change.Name, zone), err)
+				return false
+			}
+			continue
+		}
+		createReq := &RecordCreateRequest{
+			RecordType:  "PTR",
+			Name:        change.Name,
+			TTL:         change.TTL,
+			RecordClass: change.RecordClass,
+			Data:        change.Data,
+			RData:       change.RData,
+		}
+		if _, err := r.client.CreateRecord(rrCtx, zone, createReq); err != nil {
+			addRecordAPIError(diags, "Error Creating PTR Record", fmt.Sprintf("Could not create PTR record %s in zone %s", change.Name, zone), err)
+			return false
+		}
+	}
+	return true
+}
+
+// classlessLabel matches the first label of an RFC 2317 classless reverse
+// zone name, in start/prefix (0/26) or start-end (0-63) form
+var classlessLabel = regexp.MustCompile(`^(\d+)([/-])(\d+)$`)
+
+// reverseZone returns the zone of zones that holds the PTR record of an IP
+// address, and the record's name in that zone, or "" if no zone does. An
+// RFC 2317 classless zone covering an IPv4 address is preferred over its
+// parent in-addr.arpa zone, which only has a CNAME into it.
+func reverseZone(addr string, zones []Zone) (zone, name string) {
+	ip, err := netip.ParseAddr(addr)
+	if err != nil {
+		return "", ""
+	}
+	ip = ip.Unmap()
+
+	if ip.Is4() {
+		octets := ip.As4()
+		parent := fmt.Sprintf("%d.%d.%d.in-addr.arpa", octets[2], octets[1], octets[0])
+		for _, z := range zones {
+			if !holdsRecords(z) {
+				continue
+			}
+			zoneName := strings.TrimSuffix(z.Name, ".")
+			label, rest, ok := strings.Cut(zoneName, ".")
+			if ok && strings.EqualFold(rest, parent) && inClasslessRange(label, octets[3]) {
+				return zoneName, strconv.Itoa(int(octets[3]))
+			}
+		}
+	}
+
+	reverse, err := dns.ReverseAddr(ip.String())
+	if err != nil {
+		return "", ""
+	}
+	zone = enclosingZone(reverse, zones)
+	if zone == "" {
+		return "", ""
+	}
+	name, _ = relativeRecordName(reverse, zone)
+	return zone, name
+}
+
+// inClasslessRange reports whether the last octet of an IPv4 address falls
+// in the range of a classless zone label
+func inClasslessRange(label string, octet byte) bool {
+	m := classlessLabel.FindStringSubmatch(label)
+	if m == nil {
+		return false
+	}
+	start, err1 := strconv.Atoi(m[1])
+	bound, err2 := strconv.Atoi(m[3])
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	end := bound
+	if m[2] == "/" {
+		if bound < 24 || bound > 32 {
+			return false
+		}
+		end = start + 1<<(32-bound) - 1
+	}
+	return int(octet) >= start && int(octet) <= end
+}
@@ -0,0 +1,924 @@
+// Record data parsing and formatting helpers
+
+package provider
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/miekg/dns"
+)
+
+// maxCharacterString is the length limit of a DNS character-string, in bytes
+const maxCharacterString = 255
+
+// splitRdataFields splits presentation-format rdata into fields, honoring
+// double-quoted character-strings and backslash escapes, including the \DDD
+// decimal form (RFC 1035 section 5.1). Quotes are removed from the returned
+// fields.
+func splitRdataFields(rdata string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes := false
+	inField := false
+
+	for i := 0; i < len(rdata); i++ {
+		ch := rdata[i]
+		switch {
+		case ch == '\\' && i+1 < len(rdata):
+			inField = true
+			if d, ok := decimalEscape(rdata[i+1:]); ok {
+				current.WriteByte(d)
+				i += 3
+				continue
+			}
+			current.WriteByte(rdata[i+1])
+			i++
+		case ch == '\\':
+			inField = true
+		case ch == '"':
+			inQuotes = !inQuotes
+			inField = true
+		case (ch == ' ' || ch == '\t') && !inQuotes:
+			if inField {
+				fields = append(fields, current.String())
+				current.Reset()
+				inField = false
+			}
+		default:
+			current.WriteByte(ch)
+			inField = true
+		}
+	}
+
+	if inField {
+		fields = append(fields, current.String())
+	}
+
+	return fields
+}
+
+// decimalEscape decodes the DDD of a \DDD escape at the start of s
+func decimalEscape(s string) (byte, bool) {
+	if len(s) < 3 {
+		return 0, false
+	}
+	n := 0
+	for _, c := range []byte(s[:3]) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	if n > 255 {
+		return 0, false
+	}
+	return byte(n), true
+}
+
+// quoteCharacterString formats a value as a quoted DNS character-string,
+// escaping embedded quotes and backslashes, and writing control characters
+// in \DDD form
+func quoteCharacterString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&b, "\\%03d", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// formatHINFO builds HINFO rdata from its CPU and OS fields
+func formatHINFO(cpu, os string) string {
+	return quoteCharacterString(cpu) + " " + quoteCharacterString(os)
+}
+
+// formatMX builds MX rdata from a preference and mail exchange
+func formatMX(preference int64, exchange string) string {
+	return fmt.Sprintf("%d %s", preference, exchange)
+}
+
+// formatSRV builds SRV rdata from its priority, weight, port and target
+func formatSRV(priority, weight, port int64, target string) string {
+	return fmt.Sprintf("%d %d %d %s", priority, weight, port, target)
+}
+
+// formatCAA builds CAA rdata from its flags, tag and value. The value is
+// quoted automatically.
+func formatCAA(flags int64, tag, value string) string {
+	return fmt.Sprintf("%d %s %s", flags, tag, quoteCharacterString(value))
+}
+
+// formatNAPTR builds NAPTR rdata from its fields. Flags, service and regexp
+// are quoted and escaped automatically; an empty replacement is written as
+// the root name.
+func formatNAPTR(order, preference int64, flags, service, re, replacement string) string {
+	if replacement == "" {
+		replacement = "."
+	}
+	return fmt.Sprintf("%d %d %s %s %s %s", order, preference,
+		quoteCharacterString(flags), quoteCharacterString(service), quoteCharacterString(re), replacement)
+}
+
+// validateNAPTR checks the flags, regexp and replacement of a NAPTR record
+// (RFC 3403 section 4.1): at most one of the terminal flags S, A and U, a
+// regexp of the form delim ERE delim replacement delim [i] whose
+// backreferences match groups of the ERE, and not both a regexp and a
+// replacement
+func validateNAPTR(flags, re, replacement string) error {
+	terminal := 0
+	for _, f := range strings.ToUpper(flags) {
+		if f == 'S' || f == 'A' || f == 'U' {
+			terminal++
+		}
+	}
+	if terminal > 1 {
+		return fmt.Errorf("flags %q combine more than one of the terminal flags S, A and U", flags)
+	}
+
+	if re != "" && replacement != "" && replacement != "." {
+		return errors.New("regexp and replacement cannot both be set; set replacement for a non-terminal lookup, or regexp to rewrite the name")
+	}
+	if re == "" {
+		return nil
+	}
+
+	delim := re[0]
+	if delim == '\\' || delim == 'i' || (delim >= '0' && delim <= '9') {
+		return fmt.Errorf("regexp %q must start with a delimiter other than a digit, i or backslash", re)
+	}
+	// Split at unescaped delimiters into the ERE, the replacement and the flags
+	var parts []string
+	start := 1
+	for i := 1; i < len(re); i++ {
+		switch re[i] {
+		case '\\':
+			i++
+		case delim:
+			parts = append(parts, re[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, re[start:])
+	if len(parts) != 3 || (parts[2] != "" && parts[2] != "i") {
+		return fmt.Errorf("regexp %q must have the form %cERE%creplacement%c, optionally followed by i", re, delim, delim, delim)
+	}
+
+	compiled, err := regexp.CompilePOSIX(parts[0])
+	if err != nil {
+		return fmt.Errorf("regexp %q has an invalid ERE: %s", re, err)
+	}
+	for i := 0; i < len(parts[1])-1; i++ {
+		if parts[1][i] != '\\' {
+			continue
+		}
+		if next := parts[1][i+1]; next >= '1' && next <= '9' && int(next-'0') > compiled.NumSubexp() {
+			return fmt.Errorf("regexp %q refers to group \\%c, but the ERE has %d groups", re, next, compiled.NumSubexp())
+		}
+		i++
+	}
+	return nil
+}
+
+// formatSSHFP builds SSHFP rdata from its algorithm, fingerprint type and
+// hex-encoded fingerprint, written in uppercase like BIND writes it
+func formatSSHFP(algorithm, fingerprintType int64, fingerprint string) string {
+	return fmt.Sprintf("%d %d %s", algorithm, fingerprintType, strings.ToUpper(fingerprint))
+}
+
+// formatDS builds DS or CDS rdata from its key tag, algorithm, digest type
+// and hex-encoded digest, written in uppercase like BIND writes it
+func formatDS(keyTag, algorithm, digestType int64, digest string) string {
+	return fmt.Sprintf("%d %d %d %s", keyTag, algorithm, digestType, strings.ToUpper(digest))
+}
+
+// dsDigestLengths maps DS digest types to the length of their digest in hex
+// digits (RFC 4034, RFC 4509, RFC 5933, RFC 6605)
+var dsDigestLengths = map[int64]int{
+	1: 40,
+	2: 64,
+	3: 64,
+	4: 96,
+}
+
+// validateDSDigest checks that a hex-encoded digest has the length of its
+// digest type. Unknown digest types, such as 0 in a CDS record that asks
+// the parent to delete the DS records (RFC 8078), are not checked.
+func validateDSDigest(digestType int64, digest string) error {
+	length, ok := dsDigestLengths[digestType]
+	if ok && len(digest) != length {
+		return fmt.Errorf("digest of type %d must be %d hex digits, got %d", digestType, length, len(digest))
+	}
+	return nil
+}
+
+// dsFromPublicKey returns the key tag and hex-encoded digest of the DNSKEY
+// record of owner with the given flags, algorithm and base64-encoded public
+// key, as the parent zone publishes them in a DS record
+func dsFromPublicKey(owner string, flags, algorithm, digestType int64, publicKey string) (int64, string, error) {
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: dns.Fqdn(owner), Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET},
+		Flags:     uint16(flags),
+		Protocol:  3,
+		Algorithm: uint8(algorithm),
+		PublicKey: strings.Join(strings.Fields(publicKey), ""),
+	}
+	if _, err := base64.StdEncoding.DecodeString(key.PublicKey); err != nil {
+		return 0, "", fmt.Errorf("could not decode the public key: %w", err)
+	}
+	ds := key.ToDS(uint8(digestType))
+	if ds == nil {
+		return 0, "", fmt.Errorf("cannot compute a digest of type %d; use 1 (SHA-1), 2 (SHA-256) or 4 (SHA-384)", digestType)
+	}
+	return int64(ds.KeyTag), strings.ToUpper(ds.Digest), nil
+}
+
+// formatDNSKEY builds DNSKEY or CDNSKEY rdata from its flags, protocol,
+// algorithm and base64-encoded public key, with the key in one field
+func formatDNSKEY(flags, protocol, algorithm int64, publicKey string) string {
+	return fmt.Sprintf("%d %d %d %s", flags, protocol, algorithm, strings.Join(strings.Fields(publicKey), ""))
+}
+
+// sshfpAlgorithms maps OpenSSH host key types to SSHFP algorithm numbers
+// (RFC 4255, RFC 6594, RFC 7479, RFC 8709)
+var sshfpAlgorithms = map[string]int64{
+	"ssh-rsa":             1,
+	"ssh-dss":             2,
+	"ecdsa-sha2-nistp256": 3,
+	"ecdsa-sha2-nistp384": 3,
+	"ecdsa-sha2-nistp521": 3,
+	"ssh-ed25519":         4,
+	"ssh-ed448":           6,
+}
+
+// sshfpFromPublicKey returns the SSHFP algorithm and hex-encoded fingerprint
+// of an OpenSSH public key line ("type base64-key [comment]"), hashed with
+// SHA-1 for fingerprint type 1 or SHA-256 for fingerprint type 2
+func sshfpFromPublicKey(publicKey string, fingerprintType int64) (int64, string, error) {
+	fields := strings.Fields(publicKey)
+	if len(fields) < 2 {
+		return 0, "", errors.New("public_key must be an OpenSSH public key of the form \"type base64-key [comment]\"")
+	}
+	algorithm, ok := sshfpAlgorithms[fields[0]]
+	if !ok {
+		return 0, "", fmt.Errorf("key type %q has no SSHFP algorithm", fields[0])
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return 0, "", fmt.Errorf("could not decode the public key: %w", err)
+	}
+	// The key blob starts with its type as a length-prefixed string
+	if len(blob) < 4 || int(binary.BigEndian.Uint32(blob)) > len(blob)-4 ||
+		string(blob[4:4+binary.BigEndian.Uint32(blob)]) != fields[0] {
+		return 0, "", fmt.Errorf("the public key is not a %s key", fields[0])
+	}
+
+	var sum []byte
+	switch fingerprintType {
+	case 1:
+		h := sha1.Sum(blob)
+		sum = h[:]
+	case 2:
+		h := sha256.Sum256(blob)
+		sum = h[:]
+	default:
+		return 0, "", fmt.Errorf("unsupported fingerprint type %d", fingerprintType)
+	}
+	return algorithm, strings.ToUpper(hex.EncodeToString(sum)), nil
+}
+
+// formatLOC builds LOC rdata (RFC 1876) from coordinates in decimal degrees
+// and an altitude, size and precisions in meters, in the format BIND writes
+// it. Sizes and precisions are stored as one digit times a power of ten
+// centimeters, so other values are rejected.
+func formatLOC(latitude, longitude, altitude, size, horizontal, vertical float64) (string, error) {
+	fields := []string{
+		locCoordinate(latitude, "N", "S"),
+		locCoordinate(longitude, "E", "W"),
+		locAltitude(int64(math.Round(altitude * 100))),
+	}
+	for _, v := range []struct {
+		name   string
+		meters float64
+	}{{"size", size}, {"horizontal_precision", horizontal}, {"vertical_precision", vertical}} {
+		cm := int64(math.Round(v.meters * 100))
+		mantissa := cm
+		for mantissa >= 10 && mantissa%10 == 0 {
+			mantissa /= 10
+		}
+		if mantissa > 9 {
+			return "", fmt.Errorf("%s %g m cannot be stored in a LOC record, which holds one significant digit in centimeters (e.g. 0.01, 0.5, 2, 30, 10000)", v.name, v.meters)
+		}
+		fields = append(fields, locSize(cm))
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// locCoordinate formats a coordinate in decimal degrees as degrees, minutes
+// and seconds with a hemisphere
+func locCoordinate(degrees float64, positive, negative string) string {
+	hemisphere := positive
+	if degrees < 0 {
+		hemisphere = negative
+		degrees = -degrees
+	}
+	// LOC records store thousandths of arc seconds
+	total := int64(math.Round(degrees * 3600000))
+	return fmt.Sprintf("%d %d %d.%03d %s", total/3600000, total%3600000/60000, total%60000/1000, total%1000, hemisphere)
+}
+
+// locAltitude formats an altitude in centimeters as meters with two decimals
+func locAltitude(cm int64) string {
+	sign := ""
+	if cm < 0 {
+		sign = "-"
+		cm = -cm
+	}
+	return fmt.Sprintf("%s%d.%02dm", sign, cm/100, cm%100)
+}
+
+// locSize formats a size or precision in centimeters as meters, with
+// decimals only if it is not a whole number of meters, like BIND does
+func locSize(cm int64) string {
+	if cm%100 == 0 {
+		return fmt.Sprintf("%dm", cm/100)
+	}
+	return fmt.Sprintf("%d.%02dm", cm/100, cm%100)
+}
+
+// locCanonical returns the encoded values of LOC rdata, or false
+// if rdata does not parse as LOC rdata. Optional fields default, and
+// altitudes and sizes may be written with or without decimals.
+func locCanonical(rdata string) (string, bool) {
+	fields := strings.Fields(rdata)
+	hasHemisphere := false
+	for _, f := range fields {
+		if f == "N" || f == "S" {
+			hasHemisphere = true
+			break
+		}
+	}
+	if !hasHemisphere {
+		return "", false
+	}
+	rr, err := dns.NewRR("loc.invalid. 0 IN LOC " + rdata)
+	if err != nil || rr == nil {
+		return "", false
+	}
+	loc, ok := rr.(*dns.LOC)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%d %d %d %d %d %d", loc.Latitude, loc.Longitude, loc.Altitude, loc.Size, loc.HorizPre, loc.VertPre), true
+}
+
+// formatTLSA builds TLSA rdata from its usage, selector, matching type and
+// hex-encoded certificate association data, written in uppercase like BIND
+// writes it
+func formatTLSA(usage, selector, matchingType int64, data string) string {
+	return fmt.Sprintf("%d %d %d %s", usage, selector, matchingType, strings.ToUpper(data))
+}
+
+// tlsaAssociationData computes the hex-encoded certificate association data
+// of a TLSA record from a PEM certificate (RFC 6698 section 2.1): the whole
+// certificate for selector 0 or its SubjectPublicKeyInfo for selector 1,
+// hashed with SHA-256 for matching type 1 or SHA-512 for matching type 2
+func tlsaAssociationData(certPEM string, selector, matchingType int64) (string, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(certPEM)))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return "", errors.New("certificate must be a PEM-encoded certificate (-----BEGIN CERTIFICATE-----)")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("could not parse certificate: %w", err)
+	}
+
+	data := cert.Raw
+	if selector == 1 {
+		data = cert.RawSubjectPublicKeyInfo
+	}
+	switch matchingType {
+	case 1:
+		sum := sha256.Sum256(data)
+		data = sum[:]
+	case 2:
+		sum := sha512.Sum512(data)
+		data = sum[:]
+	}
+	return strings.ToUpper(hex.EncodeToString(data)), nil
+}
+
+// paddedNumbersCanonical returns rdata with the zero padding removed from its
+// leading numeric fields, such as the priority of MX or the port of SRV
+// records, which zone file parsers may write as 010 for 10. Only fields of
+// up to five digits, the width of the 16-bit fields of record data, are
+// changed, and only if a field that is not a number follows, so that hex
+// data of digits alone keeps its leading zeros. The fields after the leading
+// numbers are kept as written.
+func paddedNumbersCanonical(rdata string) string {
+	var numbers []string
+	rest := strings.TrimLeftFunc(rdata, unicode.IsSpace)
+	for rest != "" {
+		end := strings.IndexFunc(rest, unicode.IsSpace)
+		if end < 0 {
+			end = len(rest)
+		}
+		field := rest[:end]
+		if !isDecimal(field) || len(field) > 5 {
+			break
+		}
+		if trimmed := strings.TrimLeft(field, "0"); trimmed != "" {
+			field = trimmed
+		} else {
+			field = "0"
+		}
+		numbers = append(numbers, field)
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+	}
+	// Rdata of numbers alone may be hex data of digits
+	if len(numbers) == 0 || rest == "" {
+		return rdata
+	}
+	return strings.Join(numbers, " ") + " " + rest
+}
+
+// hexDataCanonical returns rdata that ends in hex data after numeric fields,
+// such as the digest of TLSA, SSHFP and DS records, with the hex data joined
+// into one uppercase field. Servers split long hex data into several fields
+// and may write it in either case. Other rdata is returned unchanged.
+func hexDataCanonical(rdata string) string {
+	fields := strings.Fields(rdata)
+	n := 0
+	for n < len(fields) && isDecimal(fields[n]) {
+		n++
+	}
+	if n < 2 || n == len(fields) {
+		return rdata
+	}
+	for _, f := range fields[n:] {
+		if !isHex(f) {
+			return rdata
+		}
+	}
+	return strings.Join(fields[:n], " ") + " " + strings.ToUpper(strings.Join(fields[n:], ""))
+}
+
+// keyDataCanonical returns rdata that ends in base64 data after three
+// numeric fields, such as the public key of DNSKEY records, with the base64
+// data joined into one field. BIND splits long keys into several fields.
+// Other rdata is returned unchanged.
+func keyDataCanonical(rdata string) string {
+	fields := strings.Fields(rdata)
+	if len(fields) < 4 {
+		return rdata
+	}
+	for _, f := range fields[:3] {
+		if !isDecimal(f) {
+			return rdata
+		}
+	}
+	for _, f := range fields[3:] {
+		if !isBase64(f) {
+			return rdata
+		}
+	}
+	return strings.Join(fields[:3], " ") + " " + strings.Join(fields[3:], "")
+}
+
+// isBase64 reports whether s consists of base64 characters only
+func isBase64(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '+' || c == '/' || c == '=') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isDecimal reports whether s consists of decimal digits only
+func isDecimal(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isHex reports whether s consists of hex digits only
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// svcbKeys lists the SvcParamKeys of SVCB and HTTPS records (RFC 9460) in
+// key number order, which is the order BIND writes them in
+var svcbKeys = []string{"mandatory", "alpn", "no-default-alpn", "port", "ipv4hint", "ech", "ipv6hint"}
+
+// formatSVCB builds SVCB or HTTPS rdata from its priority, target and
+// parameters by key. Parameters are written in key order; a parameter with
+// an empty value, such as no-default-alpn, is written as the bare key.
+func formatSVCB(priority int64, target string, params map[string]string) string {
+	fields := []string{strconv.FormatInt(priority, 10), target}
+	for _, key := range svcbKeys {
+		value, ok := params[key]
+		switch {
+		case !ok:
+		case value == "":
+			fields = append(fields, key)
+		default:
+			fields = append(fields, key+"="+value)
+		}
+	}
+	return strings.Join(fields, " ")
+}
+
+// svcbCanonical returns SVCB or HTTPS rdata with SvcParams in a canonical
+// form, sorted by key with every value quoted, or false if rdata has no
+// parameters or does not parse as SVCB rdata
+func svcbCanonical(rdata string) (string, bool) {
+	if !strings.Contains(rdata, "=") {
+		return "", false
+	}
+	rr, err := dns.NewRR("svcb.invalid. 0 IN SVCB " + rdata)
+	if err != nil || rr == nil {
+		return "", false
+	}
+	svcb, ok := rr.(*dns.SVCB)
+	if !ok {
+		return "", false
+	}
+
+	values := append([]dns.SVCBKeyValue(nil), svcb.Value...)
+	sort.Slice(values, func(i, j int) bool { return values[i].Key() < values[j].Key() })
+	fields := []string{strconv.Itoa(int(svcb.Priority)), svcb.Target}
+	for _, v := range values {
+		fields = append(fields, fmt.Sprintf("%s=%q", v.Key(), v.String()))
+	}
+	return strings.Join(fields, " "), true
+}
+
+// txtRdata returns TXT rdata in presentation format. A value that starts
+// with a quote is taken as presentation format already; any other value is
+// taken as the literal text, which is quoted and escaped, and split into
+// character-strings of at most 255 bytes if it is longer (e.g. DKIM keys).
+func txtRdata(value string) string {
+	if strings.HasPrefix(value, `"`) {
+		return value
+	}
+	return quoteTXT(value)
+}
+
+// quoteTXT formats text as quoted character-strings of at most 255 bytes
+func quoteTXT(text string) string {
+	var chunks []string
+	for len(text) > maxCharacterString {
+		// Split between characters, not inside a multibyte one
+		n := maxCharacterString
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		chunks = append(chunks, quoteCharacterString(text[:n]))
+		text = text[n:]
+	}
+	chunks = append(chunks, quoteCharacterString(text))
+	return strings.Join(chunks, " ")
+}
+
+// txtText returns the text of TXT rdata: the character-strings of quoted
+// rdata unescaped and joined, as they are when the record is used, or an
+// unquoted value as is
+func txtText(rdata string) string {
+	if !strings.HasPrefix(rdata, `"`) {
+		return rdata
+	}
+	return strings.Join(splitRdataFields(rdata), "")
+}
+
+// writeRdata returns rdata in the form sent to the server, with TXT values
+// in presentation format
+func writeRdata(recordType, rdata string) string {
+	if strings.EqualFold(recordType, "TXT") {
+		return txtRdata(rdata)
+	}
+	return rdata
+}
+
+// readRdata returns rdata read from the server in the form the provider
+// compares and stores. Long certificates and keys, which BIND splits into
+// several fields, are joined, and the certificate type and algorithm of
+// CERT records are written as numbers.
+func readRdata(recordType, rdata string) string {
+	switch strings.ToUpper(recordType) {
+	case "CERT":
+		return certRdata(rdata)
+	case "OPENPGPKEY":
+		return strings.Join(strings.Fields(rdata), "")
+	case "SMIMEA", "ZONEMD":
+		return hexDataCanonical(rdata)
+	}
+	return rdata
+}
+
+// certRdata returns CERT rdata (RFC 4398) with the certificate type and
+// algorithm as numbers and the certificate in one field. Mnemonics that are
+// not known are kept.
+func certRdata(rdata string) string {
+	fields := strings.Fields(rdata)
+	if len(fields) < 4 {
+		return rdata
+	}
+	if certType, ok := dns.StringToCertType[strings.ToUpper(fields[0])]; ok {
+		fields[0] = strconv.Itoa(int(certType))
+	}
+	if algorithm, ok := dns.StringToAlgorithm[strings.ToUpper(fields[2])]; ok {
+		fields[2] = strconv.Itoa(int(algorithm))
+	}
+	return strings.Join(fields[:3], " ") + " " + strings.Join(fields[3:], "")
+}
+
+// parseHINFO extracts the CPU and OS fields from HINFO rdata
+func parseHINFO(rdata string) (cpu, os string, ok bool) {
+	fields := splitRdataFields(rdata)
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	return fields[0], fields[1], true
+}
+
+// formatRP builds RP rdata from a mailbox and TXT domain name. The mailbox
+// may be given as an email address (user@example.com), in which case it is
+// converted to domain-name form with dots in the local part escaped.
+func formatRP(mbox, txt string) string {
+	return mailboxToDname(mbox) + " " + fqdnOrRoot(txt)
+}
+
+// parseRP extracts the mailbox and TXT domain name from RP rdata
+func parseRP(rdata string) (mbox, txt string, ok bool) {
+	fields := strings.Fields(rdata)
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	return fields[0], fields[1], true
+}
+
+// mailboxToDname converts an email address to the domain-name form used in
+// SOA RNAME and RP mbox fields. Values already in domain-name form are
+// returned with a trailing dot.
+func mailboxToDname(mbox string) string {
+	at := strings.LastIndex(mbox, "@")
+	if at < 0 {
+		return fqdnOrRoot(mbox)
+	}
+	local := strings.ReplaceAll(mbox[:at], ".", `\.`)
+	return fqdnOrRoot(local + "." + mbox[at+1:])
+}
+
+// fqdnOrRoot returns the name with a trailing dot, or "." for an empty name
+func fqdnOrRoot(name string) string {
+	if name == "" || name == "." {
+		return "."
+	}
+	if !strings.HasSuffix(name, ".") {
+		return name + "."
+	}
+	return name
+}
+
+// trimNameDots removes the trailing dot from each unquoted field of rdata,
+// so fully qualified names compare equal to the same names written without
+// the dot. The root name "." and escaped dots are kept, and fields are
+// joined with single spaces.
+func trimNameDots(rdata string) string {
+	var fields []string
+	var current strings.Builder
+	inQuotes := false
+	escaped := false
+	quoted := false
+
+	flush := func() {
+		field := current.String()
+		if !quoted && len(field) > 1 && strings.HasSuffix(field, ".") && !strings.HasSuffix(field, `\.`) {
+			field = strings.TrimSuffix(field, ".")
+		}
+		fields = append(fields, field)
+		current.Reset()
+		quoted = false
+	}
+
+	for _, ch := range rdata {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case unicode.IsSpace(ch) && !inQuotes:
+			if current.Len() > 0 {
+				flush()
+			}
+			continue
+		}
+		current.WriteRune(ch)
+	}
+	if current.Len() > 0 {
+		flush()
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// canonicalAddress returns an IP address in canonical form, which is the
+// RFC 5952 form for IPv6 (lowercase, without leading zeros, with the longest
+// run of zero groups compressed). Other values are returned unchanged.
+func canonicalAddress(s string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return s
+	}
+	return addr.String()
+}
+
+// nameRdataTypes are the record types whose rdata consists of DNS names and
+// numbers only. Names are case-insensitive, so their rdata is always
+// compared regardless of case.
+var nameRdataTypes = map[string]bool{
+	"CNAME": true,
+	"DNAME": true,
+	"NS":    true,
+	"PTR":   true,
+	"MX":    true,
+	"SRV":   true,
+	"RP":    true,
+}
+
+// Record types whose rdata starts with numbers of up to 16 bits, which zone
+// file parsers may write zero-padded
+var paddedNumberTypes = map[string]bool{
+	"MX": true, "SRV": true, "NAPTR": true, "CAA": true, "URI": true, "CERT": true,
+	"SSHFP": true, "TLSA": true, "SMIMEA": true, "DS": true, "CDS": true,
+	"DNSKEY": true, "CDNSKEY": true, "SVCB": true, "HTTPS": true,
+}
+
+// Record types whose rdata ends in hex data after numeric fields
+var hexDataTypes = map[string]bool{
+	"SSHFP": true, "TLSA": true, "SMIMEA": true, "DS": true, "CDS": true, "ZONEMD": true,
+}
+
+// Record types whose rdata ends in a base64 public key after three numeric
+// fields
+var keyDataTypes = map[string]bool{
+	"DNSKEY": true, "CDNSKEY": true,
+}
+
+// rdataCaseSensitiveFor returns the rdata comparison mode for a record type:
+// the configured mode, except for types whose rdata holds only names
+func rdataCaseSensitiveFor(recordType string, caseSensitive bool) bool {
+	return caseSensitive && !nameRdataTypes[strings.ToUpper(recordType)]
+}
+
+// rdataEqual compares two rdata values of a record type, ignoring letter
+// case unless caseSensitive is set. Names that differ only in a trailing dot
+// are equal, and so are different spellings of the same IP address and SVCB
+// parameters written in another order or quoting. Hex data, such as TLSA
+// digests, is compared regardless of case and splitting into fields, DNSKEY
+// public keys regardless of splitting, and LOC data by value. The whitespace
+// between unquoted fields and, for the types in paddedNumberTypes, the zero
+// padding of leading numbers, such as the priority in "010  mail.example.com.",
+// are ignored. An unquoted value equals quoted TXT rdata with the same text,
+// which the server returns for values given unquoted. Each of these forms
+// only applies to the record types it is written for.
+func rdataEqual(recordType, a, b string, caseSensitive bool) bool {
+	recordType = strings.ToUpper(recordType)
+
+	if addrA, err := netip.ParseAddr(strings.TrimSpace(a)); err == nil {
+		if addrB, err := netip.ParseAddr(strings.TrimSpace(b)); err == nil {
+			return addrA == addrB
+		}
+	}
+
+	// SVCB and HTTPS parameters may be written in any order, and quoted
+	// or not
+	if recordType == "SVCB" || recordType == "HTTPS" {
+		if canonicalA, ok := svcbCanonical(a); ok {
+			if canonicalB, ok := svcbCanonical(b); ok {
+				a, b = canonicalA, canonicalB
+			}
+		}
+	}
+
+	if recordType == "LOC" {
+		if canonicalA, ok := locCanonical(a); ok {
+			if canonicalB, ok := locCanonical(b); ok {
+				return canonicalA == canonicalB
+			}
+		}
+	}
+
+	if hexDataTypes[recordType] {
+		a, b = hexDataCanonical(a), hexDataCanonical(b)
+	}
+	if keyDataTypes[recordType] {
+		a, b = keyDataCanonical(a), keyDataCanonical(b)
+	}
+
+	if quotedA, quotedB := strings.HasPrefix(a, `"`), strings.HasPrefix(b, `"`); quotedA != quotedB {
+		if quotedA {
+			a = txtText(a)
+		} else {
+			b = txtText(b)
+		}
+		if caseSensitive {
+			return a == b
+		}
+		return strings.EqualFold(a, b)
+	}
+
+	if paddedNumberTypes[recordType] {
+		a, b = paddedNumbersCanonical(a), paddedNumbersCanonical(b)
+	}
+	a, b = trimNameDots(a), trimNameDots(b)
+	if caseSensitive {
+		return a == b
+	}
+	return strings.EqualFold(a, b)
+}
+
+// containsRdata reports whether values contains an rdata value of a record
+// type equal to v
+func containsRdata(recordType string, values []string, v string, caseSensitive bool) bool {
+	for _, existing := range values {
+		if rdataEqual(recordType, existing, v, caseSensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// rdataSetsEqual reports whether two sets of rdata values of a record type
+// hold the same values
+func rdataSetsEqual(recordType string, a, b []string, caseSensitive bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, v := range a {
+		if !containsRdata(recordType, b, v, caseSensitive) {
+			return false
+		}
+	}
+	for _, v := range b {
+		if !containsRdata(recordType, a, v, caseSensitive) {
+			return false
+		}
+	}
+	return true
+}
+
+// preserveRdataSpelling replaces each value read from the server with the
+// equivalent prior value, so the configured spelling is kept in state
+func preserveRdataSpelling(recordType string, current, prior []string, caseSensitive bool) []string {
+	result := make([]string, len(current))
+	for i, v := range current {
+		result[i] = v
+		for _, p := range prior {
+			if rdataEqual(recordType, v, p, caseSensitive) {
+				result[i] = p
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+// Per-zone record cache and prefetching for refresh
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// prefetchParallelism is the number of zones listed concurrently by the
+// prefetcher. max_concurrent_requests, if lower, still applies.
+const prefetchParallelism = 8
+
+// recordCache serves record reads from whole-zone listings, so refreshing
+// thousands of bind9_record resources costs one request per zone instead of
+// one per record. The first read starts listing all zones in the background.
+type recordCache struct {
+	client *Client
+
+	prefetch sync.Once
+
+	mu    sync.Mutex
+	zones map[string]*zoneListing
+}
+
+// zoneListing holds the records of a zone once done is closed
+type zoneListing struct {
+	done    chan struct{}
+	records []Record
+	err     error
+}
+
+// newRecordCache creates an empty record cache for the client
+func newRecordCache(client *Client) *recordCache {
+	return &recordCache{
+		client: client,
+		zones:  make(map[string]*zoneListing),
+	}
+}
+
+// cacheKey normalizes a zone name for use as a cache key
+func cacheKey(zone string) string {
+	return strings.ToLower(strings.TrimSuffix(zone, "."))
+}
+
+// viewCacheKey is the cache key of a zone in the view of ctx
+func viewCacheKey(ctx context.Context, zone string) string {
+	return zoneID(viewFromContext(ctx), cacheKey(zone))
+}
+
+// listing returns the listing of a zone in the view of ctx, and whether the
+// caller created it and must load it
+func (rc *recordCache) listing(ctx context.Context, zone string) (*zoneListing, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	key := viewCacheKey(ctx, zone)
+	if l, ok := rc.zones[key]; ok {
+		return l, false
+	}
+	l := &zoneListing{done: make(chan struct{})}
+	rc.zones[key] = l
+	return l, true
+}
+
+// load fetches the records of a zone into its listing
+func (rc *recordCache) load(ctx context.Context, zone string, l *zoneListing) {
+	l.records, l.err = rc.client.GetRecords(ctx, zone, "", "", "")
+	close(l.done)
+}
+
+// invalidate drops the cached listing of a zone in the view of ctx after its
+// records changed
+func (rc *recordCache) invalidate(ctx context.Context, zone string) {
+	rc.mu.Lock()
+	delete(rc.zones, viewCacheKey(ctx, zone))
+	rc.mu.Unlock()
+}
+
+// prefetchAll lists the records of every zone in the view of ctx with
+// bounded parallelism. Zones already loaded or being loaded are skipped.
+func (rc *recordCache) prefetchAll(ctx context.Context) {
+	zones, err := rc.client.ListZones(ctx, nil)
+	if err != nil {
+		tflog.Warn(ctx, "Could not list zones for record prefetch", map[string]any{"error": err.Error()})
+		return
+	}
+
+	tflog.Debug(ctx, "Prefetching records", map[string]any{"zones": len(zones)})
+
+	names := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < prefetchParallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for zone := range names {
+				if l, created := rc.listing(ctx, zone); created {
+					rc.load(ctx, zone, l)
+				}
+			}
+		}()
+	}
+	for _, z := range zones {
+		names <- z.Name
+	}
+	close(names)
+	wg.Wait()
+}
+
+// records returns the records of a zone matching the filters, from the cache
+// when possible. A failed listing falls back to a filtered request.
+func (rc *recordCache) records(ctx context.Context, zone, recordType, name, class string) ([]Record, error) {
+	// The prefetch outlives the read that starts it
+	rc.prefetch.Do(func() {
+		go rc.prefetchAll(withETag(context.WithoutCancel(ctx), nil))
+	})
+
+	l, created := rc.listing(ctx, zone)
+	if created {
+		// A zone listing's ETag says nothing about a single RRset
+		rc.load(withETag(ctx, nil), zone, l)
+	} else {
+		select {
+		case <-l.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.err != nil {
+		return rc.client.GetRecords(ctx, zone, recordType, name, class)
+	}
+
+	var wantName string
+	if name != "" {
+		wantName = relativeName(zone, ownerName(zone, name))
+	}
+
+	var matched []Record
+	for _, r := range l.records {
+		if recordType != "" && !strings.EqualFold(r.Type, recordType) {
+			continue
+		}
+		if class != "" && !strings.EqualFold(recordClass(r), class) {
+			continue
+		}
+		if name != "" && !strings.EqualFold(relativeName(zone, ownerName(zone, r.Name)), wantName) {
+			continue
+		}
+		matched = append(matched, r)
+	}
+	return matched, nil
+}
+
+// ReadRecords retrieves records like GetRecords, serving them from the
+// per-zone cache when record prefetching is enabled. With dns_query, a
+// record set the API lists no records for (or every record set, with
+// always) is read with a DNS query instead, which sees the zone journal.
+func (c *Client) ReadRecords(ctx context.Context, zone, recordType, name, class string) ([]Record, error) {
+	var records []Record
+	var err error
+	if c.recordCache == nil {
+		records, err = c.GetRecords(ctx, zone, recordType, name, class)
+	} else {
+		records, err = c.recordCache.records(ctx, zone, recordType, name, class)
+	}
+	if err != nil || c.dnsQuery == nil || c.dnsUpdate != nil || name == "" || recordType == "" {
+		return records, err
+	}
+	if len(records) > 0 && !c.dnsQueryAlways {
+		return records, nil
+	}
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.dnsQuery.getRecords(ctx, zone, recordType, name, class)
+}
+
+// readsAreAuthoritative reports whether record reads see the records of the
+// zone journal, so that a record set read without records is really gone.
+// The REST API may list only the zone file of a dynamic zone.
+func (c *Client) readsAreAuthoritative() bool {
+	return c.dnsUpdate != nil || c.dnsQuery != nil
+}
@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -195,6 +194,10 @@ func (r *ACLResource) Configure(ctx context.Context, req resource.ConfigureReque
 
 // Create creates a new ACL
 func (r *ACLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_acl.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var plan ACLResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -203,6 +206,10 @@ func (r *ACLResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	if !checkServerFeature(r.client, FeatureACLs, "ACLs", &resp.Diagnostics) {
+		return
+	}
+
 	// Convert entries from types.List to []string
 	var entries []string
 	diags = plan.Entries.ElementsAs(ctx, &entries, false)
@@ -226,7 +233,7 @@ func (r *ACLResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Create ACL - pass struct directly, doRequest will marshal it
 	httpResp, err := r.client.doRequest(ctx, "POST", "/api/v1/acls", aclReq)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Could not create ACL: %s", err))
+		addAPIError(&resp.Diagnostics, "API Error", "Could not create ACL", err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -234,9 +241,11 @@ func (r *ACLResource) Create(ctx context.Context, req resource.CreateRequest, re
 	if httpResp.StatusCode != http.StatusCreated {
 		errBody := make([]byte, 1024)
 		n, _ := httpResp.Body.Read(errBody)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Creating ACL",
-			fmt.Sprintf("Could not create ACL: API error %d: %s", httpResp.StatusCode, string(errBody[:n])),
+			"Could not create ACL",
+			newAPIError(httpResp.StatusCode, errBody[:n]),
 		)
 		return
 	}
@@ -244,7 +253,7 @@ func (r *ACLResource) Create(ctx context.Context, req resource.CreateRequest, re
 	// Parse response
 	var aclResp ACLAPIResponse
 	if err := json.NewDecoder(httpResp.Body).Decode(&aclResp); err != nil {
-		resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Failed to parse response: %s", err))
+		addCodedError(&resp.Diagnostics, ErrCodeAPIError, "JSON Error", fmt.Sprintf("Failed to parse response: %s", err))
 		return
 	}
 
@@ -268,6 +277,9 @@ func (r *ACLResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 // Read reads the ACL state
 func (r *ACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_acl.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var state ACLResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -280,10 +292,14 @@ func (r *ACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 	tflog.Debug(ctx, "Reading ACL", map[string]interface{}{"name": name})
 
+	if !checkServerFeature(r.client, FeatureACLs, "ACLs", &resp.Diagnostics) {
+		return
+	}
+
 	// Get ACL from API
 	httpResp, err := r.client.doRequest(ctx, "GET", fmt.Sprintf("/api/v1/acls/%s", name), nil)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Could not read ACL: %s", err))
+		addAPIError(&resp.Diagnostics, "API Error", "Could not read ACL", err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -297,16 +313,18 @@ func (r *ACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	if httpResp.StatusCode != http.StatusOK {
 		errBody := make([]byte, 1024)
 		n, _ := httpResp.Body.Read(errBody)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Reading ACL",
-			fmt.Sprintf("Could not read ACL: API error %d: %s", httpResp.StatusCode, string(errBody[:n])),
+			"Could not read ACL",
+			newAPIError(httpResp.StatusCode, errBody[:n]),
 		)
 		return
 	}
 
 	var aclResp ACLAPIResponse
 	if err := json.NewDecoder(httpResp.Body).Decode(&aclResp); err != nil {
-		resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Failed to parse response: %s", err))
+		addCodedError(&resp.Diagnostics, ErrCodeAPIError, "JSON Error", fmt.Sprintf("Failed to parse response: %s", err))
 		return
 	}
 
@@ -330,6 +348,10 @@ func (r *ACLResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 
 // Update updates an existing ACL
 func (r *ACLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_acl.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var plan ACLResourceModel
 
 	diags := req.Plan.Get(ctx, &plan)
@@ -362,7 +384,7 @@ func (r *ACLResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// Update ACL - pass struct directly, doRequest will marshal it
 	httpResp, err := r.client.doRequest(ctx, "PUT", fmt.Sprintf("/api/v1/acls/%s", name), updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Could not update ACL: %s", err))
+		addAPIError(&resp.Diagnostics, "API Error", "Could not update ACL", err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -370,9 +392,11 @@ func (r *ACLResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	if httpResp.StatusCode != http.StatusOK {
 		errBody := make([]byte, 1024)
 		n, _ := httpResp.Body.Read(errBody)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Updating ACL",
-			fmt.Sprintf("Could not update ACL: API error %d: %s", httpResp.StatusCode, string(errBody[:n])),
+			"Could not update ACL",
+			newAPIError(httpResp.StatusCode, errBody[:n]),
 		)
 		return
 	}
@@ -380,7 +404,7 @@ func (r *ACLResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	// Parse response
 	var aclResp ACLAPIResponse
 	if err := json.NewDecoder(httpResp.Body).Decode(&aclResp); err != nil {
-		resp.Diagnostics.AddError("JSON Error", fmt.Sprintf("Failed to parse response: %s", err))
+		addCodedError(&resp.Diagnostics, ErrCodeAPIError, "JSON Error", fmt.Sprintf("Failed to parse response: %s", err))
 		return
 	}
 
@@ -403,6 +427,10 @@ func (r *ACLResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 // Delete deletes an ACL
 func (r *ACLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_acl.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var state ACLResourceModel
 
 	diags := req.State.Get(ctx, &state)
@@ -418,7 +446,7 @@ func (r *ACLResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	// Delete ACL
 	httpResp, err := r.client.doRequest(ctx, "DELETE", fmt.Sprintf("/api/v1/acls/%s", name), nil)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Could not delete ACL: %s", err))
+		addAPIError(&resp.Diagnostics, "API Error", "Could not delete ACL", err)
 		return
 	}
 	defer httpResp.Body.Close()
@@ -427,9 +455,11 @@ func (r *ACLResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 	if httpResp.StatusCode != http.StatusNoContent && httpResp.StatusCode != http.StatusNotFound {
 		errBody := make([]byte, 1024)
 		n, _ := httpResp.Body.Read(errBody)
-		resp.Diagnostics.AddError(
+		addAPIError(
+			&resp.Diagnostics,
 			"Error Deleting ACL",
-			fmt.Sprintf("Could not delete ACL: API error %d: %s", httpResp.StatusCode, string(errBody[:n])),
+			"Could not delete ACL",
+			newAPIError(httpResp.StatusCode, errBody[:n]),
 		)
 		return
 	}
@@ -437,11 +467,26 @@ func (r *ACLResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 
 // ImportState imports an existing ACL into Terraform state
 func (r *ACLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import by name
-	name := strings.TrimSpace(req.ID)
+	// Import by name, or name=...
+	values, err := aclImportID.parse(req.ID)
+	if err != nil {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
+			"Invalid Import ID",
+			fmt.Sprintf("%s (e.g., internal or name=internal)", err),
+		)
+		return
+	}
+	name := values["name"]
 
 	tflog.Debug(ctx, "Importing ACL", map[string]interface{}{"name": name})
 
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), name)...)
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
 }
+
+// aclImportID is the import ID format of bind9_acl
+var aclImportID = importID{
+	positional: []string{"name"},
+}
@@ -5,7 +5,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -191,6 +190,10 @@ func (r *DNSSECKeyResource) Configure(ctx context.Context, req resource.Configur
 
 // Create creates the resource
 func (r *DNSSECKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_dnssec_key.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var plan DNSSECKeyResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -203,6 +206,10 @@ func (r *DNSSECKeyResource) Create(ctx context.Context, req resource.CreateReque
 		"key_type": plan.KeyType.ValueString(),
 	})
 
+	if !checkServerFeature(r.client, FeatureDNSSEC, "DNSSEC", &resp.Diagnostics) {
+		return
+	}
+
 	createReq := &DNSSECKeyCreateRequest{
 		KeyType:   plan.KeyType.ValueString(),
 		Algorithm: int(plan.Algorithm.ValueInt64()),
@@ -215,10 +222,7 @@ func (r *DNSSECKeyResource) Create(ctx context.Context, req resource.CreateReque
 
 	key, err := r.client.CreateDNSSECKey(ctx, plan.Zone.ValueString(), createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating DNSSEC Key",
-			"Could not create DNSSEC key: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Creating DNSSEC Key", "Could not create DNSSEC key", err)
 		return
 	}
 
@@ -255,6 +259,9 @@ func (r *DNSSECKeyResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state
 func (r *DNSSECKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_dnssec_key.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var state DNSSECKeyResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -267,16 +274,17 @@ func (r *DNSSECKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 		"key_tag": state.KeyTag.ValueInt64(),
 	})
 
+	if !checkServerFeature(r.client, FeatureDNSSEC, "DNSSEC", &resp.Diagnostics) {
+		return
+	}
+
 	keys, err := r.client.ListDNSSECKeys(ctx, state.Zone.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError(
-			"Error Reading DNSSEC Key",
-			"Could not read DNSSEC keys: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Reading DNSSEC Key", "Could not read DNSSEC keys", err)
 		return
 	}
 
@@ -316,6 +324,10 @@ func (r *DNSSECKeyResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource
 func (r *DNSSECKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_dnssec_key.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	// DNSSEC keys are immutable - no update needed
 	var plan DNSSECKeyResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -337,6 +349,10 @@ func (r *DNSSECKeyResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource
 func (r *DNSSECKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_dnssec_key.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var state DNSSECKeyResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -351,11 +367,8 @@ func (r *DNSSECKeyResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	err := r.client.DeleteDNSSECKey(ctx, state.Zone.ValueString(), int(state.KeyTag.ValueInt64()))
 	if err != nil {
-		if !strings.Contains(err.Error(), "404") {
-			resp.Diagnostics.AddError(
-				"Error Deleting DNSSEC Key",
-				"Could not delete DNSSEC key: "+err.Error(),
-			)
+		if !isNotFound(err) {
+			addAPIError(&resp.Diagnostics, "Error Deleting DNSSEC Key", "Could not delete DNSSEC key", err)
 			return
 		}
 	}
@@ -0,0 +1,623 @@
+// PTR Record Resource
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                   = &PTRRecordResource{}
+	_ resource.ResourceWithImportState    = &PTRRecordResource{}
+	_ resource.ResourceWithModifyPlan     = &PTRRecordResource{}
+	_ resource.ResourceWithValidateConfig = &PTRRecordResource{}
+)
+
+// NewPTRRecordResource creates a new PTR record resource
+func NewPTRRecordResource() resource.Resource {
+	return &PTRRecordResource{}
+}
+
+// PTRRecordResource manages the PTR record of an IP address, in the reverse
+// zone found from the address
+type PTRRecordResource struct {
+	client *Client
+}
+
+// PTRRecordResourceModel describes the resource data model
+type PTRRecordResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	IPAddress types.String `tfsdk:"ip_address"`
+	PTRDName  types.String `tfsdk:"ptrdname"`
+	Zone      types.String `tfsdk:"zone"`
+	View      types.String `tfsdk:"view"`
+	Name      types.String `tfsdk:"name"`
+	TTL       types.Int64  `tfsdk:"ttl"`
+	Class     types.String `tfsdk:"class"`
+}
+
+// Metadata returns the resource type name
+func (r *PTRRecordResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ptr_record"
+}
+
+// Schema defines the schema for the resource
+func (r *PTRRecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the PTR record of an IP address on BIND9 server.",
+		MarkdownDescription: `
+Manages the PTR (reverse DNS) record of an IP address on a BIND9 server. The reverse zone and
+the record name are derived from the address, including RFC 2317 classless reverse zones such as
+` + "`0/26.2.0.192.in-addr.arpa`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "bind9_ptr_record" "server" {
+  ip_address = "192.0.2.10"
+  ptrdname   = "server.example.com"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Record identifier (the IP address)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "IPv4 or IPv6 address the PTR record is for. Compared by value, so 2001:db8::1 and 2001:0db8:0:0::1 are equal.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					ipAddressString(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ptrdname": schema.StringAttribute{
+				Description: "Fully qualified name the address points to (e.g., server.example.com). A trailing dot is added when sending it. Compared case-insensitively, ignoring a trailing dot.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "Reverse zone of the record. Found from the zones on the server when not set. Compared case-insensitively, ignoring a trailing dot.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"view": recordViewAttribute(),
+			"name": schema.StringAttribute{
+				Description: "Record name within the reverse zone",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live in seconds. Defaults to the provider default_ttl (3600 if unset).",
+				Optional:    true,
+				Computed:    true,
+			},
+			"class": schema.StringAttribute{
+				Description: "Record class (IN, CH, HS). Must match the zone class. Defaults to the provider default_class (IN if unset).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *PTRRecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that ip_address is an IP address
+func (r *PTRRecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ip types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ip_address"), &ip)...)
+	if resp.Diagnostics.HasError() || !isKnownString(ip) {
+		return
+	}
+	if _, err := netip.ParseAddr(ip.ValueString()); err != nil {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("ip_address"), ErrCodeConfigInvalid, "Invalid IP Address",
+			fmt.Sprintf("%q is not an IPv4 or IPv6 address.", ip.ValueString()))
+	}
+}
+
+// ModifyPlan applies the provider-level record defaults to ttl and class,
+// and finds the reverse zone and record name of the address
+func (r *PTRRecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = planView(ctx, r.client, req, &resp.Diagnostics)
+
+	var ttl types.Int64
+	var class, ip, zone types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ip_address"), &ip)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ttl.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), r.client.defaultTTL)...)
+	}
+	if class.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("class"), r.client.defaultClass)...)
+	}
+
+	// The zone and name are found at apply time once the address and
+	// zone are known
+	if ip.IsUnknown() || zone.IsUnknown() {
+		if zone.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), types.StringUnknown())...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), types.StringUnknown())...)
+		return
+	}
+
+	zoneName, name := r.resolveZone(ctx, ip.ValueString(), zone.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the prior spelling of a zone that only differs in case, and
+	// replace the record if it now belongs to another zone
+	if !req.State.Raw.IsNull() {
+		var state PTRRecordResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if sameDNSName(state.Zone.ValueString(), zoneName) {
+			zoneName = state.Zone.ValueString()
+		} else {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("zone"))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), zoneName)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), canonicalAddress(ip.ValueString()))...)
+}
+
+// resolveZone returns the reverse zone and record name of an address. With
+// zone set, it checks that the zone exists and covers the address; without,
+// it finds the zone among the zones on the server.
+func (r *PTRRecordResource) resolveZone(ctx context.Context, ip, zone string, diags *diag.Diagnostics) (string, string) {
+	if zone == "" {
+		zones, err := r.client.ListZones(ctx, nil)
+		if err != nil {
+			addAPIError(diags, "Error Finding Reverse Zone",
+				"Could not list zones to find the reverse zone of "+ip+"; set zone explicitly if the zone list is unavailable", err)
+			return "", ""
+		}
+		zoneName, name := reverseZone(ip, zones)
+		if zoneName == "" {
+			addCodedAttributeError(diags, path.Root("ip_address"), ErrCodeNotFound, "No Reverse Zone",
+				fmt.Sprintf("No zone on the server holds the reverse name of %s. Create the reverse zone first, or set zone.", ip))
+		}
+		return zoneName, name
+	}
+
+	zoneName, name := reverseZone(ip, []Zone{{Name: zone}})
+	if zoneName == "" {
+		addCodedAttributeError(diags, path.Root("zone"), ErrCodeConfigInvalid, "Address Outside Zone",
+			fmt.Sprintf("The reverse name of %s is not within zone %s.", ip, zone))
+		return "", ""
+	}
+	if r.client.hasAPI() {
+		if _, err := r.client.lookupZone(ctx, zone); err != nil {
+			if isNotFound(err) {
+				addCodedAttributeError(diags, path.Root("zone"), ErrCodeNotFound, "Reverse Zone Not Found",
+					fmt.Sprintf("Zone %s does not exist on the server. Create the zone first.", zone))
+				return "", ""
+			}
+			addAPIError(diags, "Error Finding Reverse Zone", "Could not read zone "+zone, err)
+			return "", ""
+		}
+	}
+	return zoneName, name
+}
+
+// ptrTarget returns the record data of a PTR record pointing at name, which
+// is always fully qualified
+func ptrTarget(name string) string {
+	return strings.TrimSuffix(name, ".") + "."
+}
+
+// Create creates the resource
+func (r *PTRRecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_ptr_record.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan PTRRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	// The zone or address was not known at plan time
+	if !isKnownString(plan.Zone) || !isKnownString(plan.Name) {
+		zone := ""
+		if isKnownString(plan.Zone) {
+			zone = plan.Zone.ValueString()
+		}
+		zoneName, name := r.resolveZone(ctx, plan.IPAddress.ValueString(), zone, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.Zone = types.StringValue(zoneName)
+		plan.Name = types.StringValue(name)
+	}
+
+	tflog.Debug(ctx, "Creating PTR record", map[string]any{
+		"ip_address": plan.IPAddress.ValueString(),
+		"zone":       plan.Zone.ValueString(),
+		"name":       plan.Name.ValueString(),
+	})
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	// Records must be in the same class as their zone
+	if r.client.hasAPI() {
+		zone, err := r.client.lookupZone(ctx, plan.Zone.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Creating PTR Record", "Could not read zone "+plan.Zone.ValueString(), err)
+			return
+		}
+		if zone.Class != "" && !strings.EqualFold(zone.Class, plan.Class.ValueString()) {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("class"),
+				ErrCodeConfigInvalid,
+				"Record Class Does Not Match Zone",
+				fmt.Sprintf("Zone %s is in class %s, but the record is in class %s. Set class = %q on the record.",
+					plan.Zone.ValueString(), strings.ToUpper(zone.Class), plan.Class.ValueString(), strings.ToUpper(zone.Class)),
+			)
+			return
+		}
+	}
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rdata := ptrTarget(plan.PTRDName.ValueString())
+	detail := fmt.Sprintf("Could not create PTR record %s in zone %s", plan.Name.ValueString(), plan.Zone.ValueString())
+	if useTransaction {
+		change := RecordChange{
+			Action:      recordChangeCreate,
+			RecordType:  "PTR",
+			Name:        plan.Name.ValueString(),
+			TTL:         int(plan.TTL.ValueInt64()),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData("PTR", rdata),
+			RData:       rdata,
+		}
+		if err := r.client.transactions.submit(ctx, plan.Zone.ValueString(), []RecordChange{change}); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Creating PTR Record", detail, err)
+			return
+		}
+	} else {
+		createReq := &RecordCreateRequest{
+			RecordType:  "PTR",
+			Name:        plan.Name.ValueString(),
+			TTL:         int(plan.TTL.ValueInt64()),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData("PTR", rdata),
+			RData:       rdata,
+		}
+		if _, err := r.client.CreateRecord(withETag(ctx, nil), plan.Zone.ValueString(), createReq); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Creating PTR Record", detail, err)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(zoneID(plan.View.ValueString(), canonicalAddress(plan.IPAddress.ValueString())))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state
+func (r *PTRRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_ptr_record.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var state PTRRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	resp.Diagnostics.Append(r.client.restoreSquelch(ctx, resp.Private)...)
+
+	// Imported records only have the address, and possibly the zone
+	if state.Zone.IsNull() || state.Name.IsNull() {
+		zoneName, name := r.resolveZone(ctx, state.IPAddress.ValueString(), state.Zone.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.Zone = types.StringValue(zoneName)
+		state.Name = types.StringValue(name)
+	}
+	class := state.Class.ValueString()
+	if class == "" {
+		class = r.client.defaultClass
+	}
+
+	records, err := r.client.ReadRecords(withETag(ctx, nil), state.Zone.ValueString(), "PTR", state.Name.ValueString(), class)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Reading PTR Record", "Could not read record", err)
+		return
+	}
+
+	if len(records) == 0 {
+		if state.PTRDName.IsNull() {
+			addCodedError(&resp.Diagnostics, ErrCodeNotFound, "PTR Record Not Found",
+				fmt.Sprintf("Zone %s has no PTR record for %s.", state.Zone.ValueString(), state.IPAddress.ValueString()))
+			return
+		}
+		if r.client.readsAreAuthoritative() {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		// As for bind9_record, records of dynamic zones may still be in the
+		// journal and not be listed yet
+		tflog.Warn(ctx, "API returned no records, but record may exist in zone journal. Keeping state.", map[string]any{
+			"zone": state.Zone.ValueString(),
+			"name": state.Name.ValueString(),
+		})
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
+	// Keep the configured spelling of the target if the server still has
+	// it, and show the server's value otherwise
+	found := &records[0]
+	for i := range records {
+		if sameDNSName(records[i].RData, state.PTRDName.ValueString()) {
+			found = &records[i]
+			break
+		}
+	}
+	if !sameDNSName(found.RData, state.PTRDName.ValueString()) {
+		state.PTRDName = types.StringValue(strings.TrimSuffix(found.RData, "."))
+	}
+
+	state.TTL = types.Int64Value(found.TTL)
+	state.Class = types.StringValue(class)
+	state.ID = types.StringValue(zoneID(state.View.ValueString(), canonicalAddress(state.IPAddress.ValueString())))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource, replacing the target or TTL of the record
+func (r *PTRRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_ptr_record.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan, state PTRRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	oldRdata := ptrTarget(state.PTRDName.ValueString())
+	newRdata := ptrTarget(plan.PTRDName.ValueString())
+	if sameDNSName(oldRdata, newRdata) && plan.TTL.Equal(state.TTL) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone, name, class := plan.Zone.ValueString(), plan.Name.ValueString(), plan.Class.ValueString()
+	ttl := int(plan.TTL.ValueInt64())
+	detail := fmt.Sprintf("Could not update PTR record %s in zone %s", name, zone)
+	rrCtx := withETag(ctx, nil)
+
+	switch {
+	case useTransaction:
+		changes := []RecordChange{
+			{Action: recordChangeDelete, RecordType: "PTR", Name: name, RecordClass: class, RData: oldRdata},
+			{Action: recordChangeCreate, RecordType: "PTR", Name: name, TTL: ttl, RecordClass: class, Data: buildRecordData("PTR", newRdata), RData: newRdata},
+		}
+		if err := r.client.transactions.submit(ctx, zone, changes); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Updating PTR Record", detail, err)
+			return
+		}
+	case r.client.canReplaceRRset():
+		replaceReq := &RRsetReplaceRequest{
+			TTL:         ttl,
+			RecordClass: class,
+			Records:     []map[string]interface{}{buildRecordData("PTR", newRdata)},
+			RData:       []string{newRdata},
+		}
+		if err := r.client.ReplaceRRset(rrCtx, zone, name, "PTR", replaceReq); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Updating PTR Record", detail, err)
+			return
+		}
+	default:
+		err := r.client.DeleteRecord(rrCtx, zone, name, "PTR", class, oldRdata)
+		if err != nil && !isNotFound(err) {
+			addRecordAPIError(&resp.Diagnostics, "Error Updating PTR Record", detail, err)
+			return
+		}
+		createReq := &RecordCreateRequest{
+			RecordType:  "PTR",
+			Name:        name,
+			TTL:         ttl,
+			RecordClass: class,
+			Data:        buildRecordData("PTR", newRdata),
+			RData:       newRdata,
+		}
+		if _, err := r.client.CreateRecord(rrCtx, zone, createReq); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Updating PTR Record", detail, err)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource
+func (r *PTRRecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_ptr_record.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var state PTRRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	tflog.Debug(ctx, "Deleting PTR record", map[string]any{
+		"ip_address": state.IPAddress.ValueString(),
+		"zone":       state.Zone.ValueString(),
+		"name":       state.Name.ValueString(),
+	})
+
+	r.client.beginZoneChange(ctx, state.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, state.Zone.ValueString())
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rdata := ptrTarget(state.PTRDName.ValueString())
+	var err error
+	if useTransaction {
+		change := RecordChange{
+			Action:      recordChangeDelete,
+			RecordType:  "PTR",
+			Name:        state.Name.ValueString(),
+			RecordClass: state.Class.ValueString(),
+			RData:       rdata,
+		}
+		err = r.client.transactions.submit(ctx, state.Zone.ValueString(), []RecordChange{change})
+	} else {
+		err = r.client.DeleteRecord(withETag(ctx, nil), state.Zone.ValueString(), state.Name.ValueString(), "PTR", state.Class.ValueString(), rdata)
+	}
+	if err != nil {
+		// The record, or its whole zone, is already gone
+		if isNotFound(err) {
+			tflog.Debug(ctx, "PTR record already deleted or zone removed", map[string]any{
+				"zone":  state.Zone.ValueString(),
+				"name":  state.Name.ValueString(),
+				"error": err.Error(),
+			})
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Deleting PTR Record", "Could not delete record", err)
+	}
+}
+
+// ImportState imports an existing resource
+func (r *PTRRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: ip_address, or ip_address=...[,zone=...][,class=...][,view=...]
+	values, err := ptrRecordImportID.parse(req.ID)
+	if err == nil {
+		if _, parseErr := netip.ParseAddr(values["ip_address"]); parseErr != nil {
+			err = fmt.Errorf("%q is not an IP address", values["ip_address"])
+		}
+	}
+	if err != nil {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
+			"Invalid Import ID",
+			fmt.Sprintf("%s (e.g., 192.0.2.10)", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("ip_address"), values["ip_address"])...)
+	if zone := values["zone"]; zone != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), zone)...)
+	}
+	if class := values["class"]; class != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("class"), strings.ToUpper(class))...)
+	}
+	if view := values["view"]; view != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view"), view)...)
+	}
+}
+
+// ptrRecordImportID is the import ID format of bind9_ptr_record
+var ptrRecordImportID = importID{
+	positional: []string{"ip_address"},
+	optional:   []string{"zone", "class", "view"},
+}
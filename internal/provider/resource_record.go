@@ -4,26 +4,39 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ resource.Resource                = &RecordResource{}
-	_ resource.ResourceWithImportState = &RecordResource{}
+	_ resource.Resource                   = &RecordResource{}
+	_ resource.ResourceWithImportState    = &RecordResource{}
+	_ resource.ResourceWithModifyPlan     = &RecordResource{}
+	_ resource.ResourceWithUpgradeState   = &RecordResource{}
+	_ resource.ResourceWithValidateConfig = &RecordResource{}
 )
 
 // NewRecordResource creates a new record resource
@@ -40,11 +53,21 @@ type RecordResource struct {
 type RecordResourceModel struct {
 	ID      types.String `tfsdk:"id"`
 	Zone    types.String `tfsdk:"zone"`
+	View    types.String `tfsdk:"view"`
 	Name    types.String `tfsdk:"name"`
+	FQDN    types.String `tfsdk:"fqdn"`
 	Type    types.String `tfsdk:"type"`
 	TTL     types.Int64  `tfsdk:"ttl"`
 	Class   types.String `tfsdk:"class"`
-	Records types.List   `tfsdk:"records"`
+	Records types.Set    `tfsdk:"records"`
+	Comment types.String `tfsdk:"comment"`
+
+	RdataCaseSensitive types.Bool `tfsdk:"rdata_case_sensitive"`
+	AllowOverwrite     types.Bool `tfsdk:"allow_overwrite"`
+	CreatePTR          types.Bool `tfsdk:"create_ptr"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	IgnoreRdataChanges types.Bool `tfsdk:"ignore_rdata_changes"`
+	TTLConsistent      types.Bool `tfsdk:"ttl_consistent"`
 	
 	// Type-specific fields (for convenience)
 	Address    types.String `tfsdk:"address"`     // A, AAAA
@@ -56,6 +79,117 @@ type RecordResourceModel struct {
 	Flags      types.Int64  `tfsdk:"flags"`       // CAA
 	Tag        types.String `tfsdk:"tag"`         // CAA
 	Value      types.String `tfsdk:"value"`       // CAA
+	CPU        types.String `tfsdk:"cpu"`         // HINFO
+	OS         types.String `tfsdk:"os"`          // HINFO
+	Mbox       types.String `tfsdk:"mbox"`        // RP
+	TXTDname   types.String `tfsdk:"txt_dname"`   // RP
+
+	// Structured record data blocks
+	MX    types.Set `tfsdk:"mx"`
+	SRV   types.Set `tfsdk:"srv"`
+	CAA   types.Set `tfsdk:"caa"`
+	HTTPS types.Set `tfsdk:"https"`
+	SVCB  types.Set `tfsdk:"svcb"`
+	TLSA  types.Set `tfsdk:"tlsa"`
+	NAPTR types.Set `tfsdk:"naptr"`
+	SSHFP types.Set `tfsdk:"sshfp"`
+	LOC   types.Set `tfsdk:"loc"`
+
+	DS      types.Set `tfsdk:"ds"`
+	CDS     types.Set `tfsdk:"cds"`
+	DNSKEY  types.Set `tfsdk:"dnskey"`
+	CDNSKEY types.Set `tfsdk:"cdnskey"`
+
+	WaitFor types.Object `tfsdk:"wait_for"`
+}
+
+// MXBlockModel describes an mx block
+type MXBlockModel struct {
+	Preference types.Int64  `tfsdk:"preference"`
+	Exchange   types.String `tfsdk:"exchange"`
+}
+
+// SRVBlockModel describes an srv block
+type SRVBlockModel struct {
+	Priority types.Int64  `tfsdk:"priority"`
+	Weight   types.Int64  `tfsdk:"weight"`
+	Port     types.Int64  `tfsdk:"port"`
+	Target   types.String `tfsdk:"target"`
+}
+
+// CAABlockModel describes a caa block
+type CAABlockModel struct {
+	Flags types.Int64  `tfsdk:"flags"`
+	Tag   types.String `tfsdk:"tag"`
+	Value types.String `tfsdk:"value"`
+}
+
+// TLSABlockModel describes a tlsa block
+type TLSABlockModel struct {
+	Usage           types.Int64  `tfsdk:"usage"`
+	Selector        types.Int64  `tfsdk:"selector"`
+	MatchingType    types.Int64  `tfsdk:"matching_type"`
+	CertificateData types.String `tfsdk:"certificate_data"`
+	Certificate     types.String `tfsdk:"certificate"`
+}
+
+// NAPTRBlockModel describes a naptr block
+type NAPTRBlockModel struct {
+	Order       types.Int64  `tfsdk:"order"`
+	Preference  types.Int64  `tfsdk:"preference"`
+	Flags       types.String `tfsdk:"flags"`
+	Service     types.String `tfsdk:"service"`
+	Regexp      types.String `tfsdk:"regexp"`
+	Replacement types.String `tfsdk:"replacement"`
+}
+
+// SSHFPBlockModel describes an sshfp block
+type SSHFPBlockModel struct {
+	Algorithm       types.Int64  `tfsdk:"algorithm"`
+	FingerprintType types.Int64  `tfsdk:"fingerprint_type"`
+	Fingerprint     types.String `tfsdk:"fingerprint"`
+	PublicKey       types.String `tfsdk:"public_key"`
+}
+
+// LOCBlockModel describes a loc block
+type LOCBlockModel struct {
+	Latitude            types.Float64 `tfsdk:"latitude"`
+	Longitude           types.Float64 `tfsdk:"longitude"`
+	Altitude            types.Float64 `tfsdk:"altitude"`
+	Size                types.Float64 `tfsdk:"size"`
+	HorizontalPrecision types.Float64 `tfsdk:"horizontal_precision"`
+	VerticalPrecision   types.Float64 `tfsdk:"vertical_precision"`
+}
+
+// DSBlockModel describes a ds or cds block
+type DSBlockModel struct {
+	KeyTag     types.Int64  `tfsdk:"key_tag"`
+	Algorithm  types.Int64  `tfsdk:"algorithm"`
+	DigestType types.Int64  `tfsdk:"digest_type"`
+	Digest     types.String `tfsdk:"digest"`
+	PublicKey  types.String `tfsdk:"public_key"`
+	Flags      types.Int64  `tfsdk:"flags"`
+}
+
+// DNSKEYBlockModel describes a dnskey or cdnskey block
+type DNSKEYBlockModel struct {
+	Flags     types.Int64  `tfsdk:"flags"`
+	Protocol  types.Int64  `tfsdk:"protocol"`
+	Algorithm types.Int64  `tfsdk:"algorithm"`
+	PublicKey types.String `tfsdk:"public_key"`
+}
+
+// SVCBBlockModel describes an https or svcb block
+type SVCBBlockModel struct {
+	Priority      types.Int64  `tfsdk:"priority"`
+	Target        types.String `tfsdk:"target"`
+	Mandatory     types.List   `tfsdk:"mandatory"`
+	ALPN          types.List   `tfsdk:"alpn"`
+	NoDefaultALPN types.Bool   `tfsdk:"no_default_alpn"`
+	Port          types.Int64  `tfsdk:"port"`
+	IPv4Hint      types.List   `tfsdk:"ipv4hint"`
+	IPv6Hint      types.List   `tfsdk:"ipv6hint"`
+	ECH           types.String `tfsdk:"ech"`
 }
 
 // Metadata returns the resource type name
@@ -66,6 +200,8 @@ func (r *RecordResource) Metadata(ctx context.Context, req resource.MetadataRequ
 // Schema defines the schema for the resource
 func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changed records from a list to a set
+		Version:     1,
 		Description: "Manages a DNS record on BIND9 server.",
 		MarkdownDescription: `
 Manages DNS records on a BIND9 server. Supports all common record types.
@@ -155,26 +291,69 @@ resource "bind9_record" "caa" {
   records = ["0 issue \"letsencrypt.org\""]
 }
 ` + "```" + `
+
+### HINFO Record
+
+` + "```hcl" + `
+resource "bind9_record" "host_info" {
+  zone = "example.com"
+  name = "server1"
+  type = "HINFO"
+  ttl  = 3600
+  cpu  = "INTEL-X86_64"
+  os   = "Debian GNU/Linux"
+}
+` + "```" + `
+
+### RP Record
+
+` + "```hcl" + `
+resource "bind9_record" "contact" {
+  zone      = "example.com"
+  name      = "@"
+  type      = "RP"
+  ttl       = 3600
+  mbox      = "hostmaster@example.com"
+  txt_dname = "contact.example.com"
+}
+` + "```" + `
 `,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description: "Record identifier (zone/name/type)",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					useStateUnlessChanged(path.Root("zone")),
 				},
 			},
 			"zone": schema.StringAttribute{
-				Description: "Zone name (e.g., example.com)",
-				Required:    true,
+				Description: "Zone name (e.g., example.com). Compared case-insensitively, ignoring a trailing dot. Required unless fqdn is set, in which case it defaults to the closest enclosing zone on the server.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					dnsNameString(),
+					stringplanmodifier.UseStateForUnknown(),
+					zoneRequiresReplace(func() *Client { return r.client }),
 				},
 			},
+			"view": recordViewAttribute(),
 			"name": schema.StringAttribute{
-				Description: "Record name (e.g., www, @, _sip._tcp)",
-				Required:    true,
+				Description: "Record name (e.g., www, @, _sip._tcp). Compared case-insensitively, ignoring a trailing dot; \"@\", \"\" and the zone's fully qualified name all name the zone apex. Default: \"@\", or the name of fqdn when that is set.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					recordNameString(),
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				Description: "Fully qualified record name (e.g., www.example.com). Can be set instead of name, and zone, to have the provider find the enclosing zone. Compared case-insensitively, ignoring a trailing dot.",
+				Optional:    true,
+				Computed:    true,
 				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -188,26 +367,80 @@ resource "bind9_record" "caa" {
 					stringvalidator.OneOf(
 						"A", "AAAA", "CNAME", "MX", "TXT", "NS", "PTR", "SOA",
 						"SRV", "CAA", "NAPTR", "HTTPS", "SVCB", "TLSA", "SSHFP",
-						"DNSKEY", "DS", "LOC", "HINFO", "RP", "DNAME", "URI",
+						"DNSKEY", "DS", "CDNSKEY", "CDS", "LOC", "HINFO", "RP", "DNAME", "URI",
+						"CERT", "SMIMEA", "OPENPGPKEY", "CSYNC", "ZONEMD",
 					),
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				Description: "Time to live in seconds",
+				Description: "Time to live in seconds. Defaults to the provider default_ttl (3600 if unset).",
 				Optional:    true,
 				Computed:    true,
-				Default:     int64default.StaticInt64(3600),
 			},
 			"class": schema.StringAttribute{
-				Description: "Record class (IN, CH, HS)",
+				Description: "Record class (IN, CH, HS). Must match the zone class. Defaults to the provider default_class (IN if unset).",
 				Optional:    true,
 				Computed:    true,
-				Default:     stringdefault.StaticString("IN"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
 			},
-			"records": schema.ListAttribute{
-				Description: "Record data values",
-				Required:    true,
+			"records": schema.SetAttribute{
+				Description: "Record data values. Order does not matter, since servers return the records of a set in varying (e.g. rotated) order. Names that differ only in a trailing dot compare as equal. May be omitted when the structured attributes of HINFO or RP records, or one of the record data blocks (mx, srv, caa, https, svcb, tlsa, naptr, sshfp, loc, ds, cds, dnskey, cdnskey), are set.",
+				Optional:    true,
+				Computed:    true,
 				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Set{
+					rdataComparison(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment stored with the record set by the API, e.g. the owning team or ticket, shown to operators browsing the zone file. Needs the REST API feature record_comments.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+					stringvalidator.RegexMatches(commentPattern, "must be a single line of printable characters"),
+				},
+			},
+			"rdata_case_sensitive": schema.BoolAttribute{
+				Description: "Compare record data case-sensitively. Set to false to ignore case-only differences between configured and server values. The names in CNAME, DNAME, NS, PTR, MX, SRV and RP records are always compared case-insensitively. Default: true",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"allow_overwrite": schema.BoolAttribute{
+				Description: "Take over a record set of the same zone, name and type that already exists on the server when creating the resource, replacing its values with the configured ones. Without it, the configured values are added to the existing set. Default: false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Refuse to delete the record set, for records that must never be removed by accident, such as the apex NS and MX records. While set, destroying or replacing the resource fails; set it to false and apply before removing the resource. Default: false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"ignore_rdata_changes": schema.BoolAttribute{
+				Description: "Manage the existence and TTL of the record set, but not its values after creation, for records rotated by external systems such as ACME clients or dynamic DNS agents. The configured values are written when the record set is created; afterwards, neither values changed on the server nor changes to the configured values produce a diff. Default: false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"create_ptr": schema.BoolAttribute{
+				Description: "For A and AAAA records, also manage a PTR record pointing at this name for each address, in the reverse zone on the server that contains its in-addr.arpa or ip6.arpa name. Default: false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"ttl_consistent": schema.BoolAttribute{
+				Description: "Whether all records of the RRset had the same TTL when last read. False when the TTL of some records was changed outside Terraform.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			// Convenience attributes for common record types
 			"address": schema.StringAttribute{
@@ -255,83 +488,1634 @@ resource "bind9_record" "caa" {
 				Optional:    true,
 				Computed:    true,
 			},
+			"cpu": schema.StringAttribute{
+				Description: "CPU type for HINFO records. Quoted automatically.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"os": schema.StringAttribute{
+				Description: "Operating system for HINFO records. Quoted automatically.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"mbox": schema.StringAttribute{
+				Description: "Responsible person mailbox for RP records. Accepts an email address (user@example.com) or domain-name form (user.example.com.).",
+				Optional:    true,
+				Computed:    true,
+			},
+			"txt_dname": schema.StringAttribute{
+				Description: "Domain name of a TXT record with contact details for RP records. Default: . (none)",
+				Optional:    true,
+				Computed:    true,
+			},
 		},
+		Blocks: map[string]schema.Block{
+			"wait_for": schema.SingleNestedBlock{
+				Description: "Wait after create and update until the given authoritative nameservers answer with the new record data, e.g. before an ACME DNS-01 validation.",
+				Attributes: map[string]schema.Attribute{
+					"nameservers": schema.ListAttribute{
+						Description: "Nameservers to query, as host or host:port (port 53 by default). Queries are not recursive, so each must be authoritative for the zone.",
+						ElementType: types.StringType,
+						Required:    true,
+						Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+					},
+					"timeout": schema.Int64Attribute{
+						Description: "Seconds to wait for all nameservers before the apply fails. Default: 300",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"interval": schema.Int64Attribute{
+						Description: "Seconds between query rounds. Default: 5",
+						Optional:    true,
+						Validators:  []validator.Int64{int64validator.AtLeast(1)},
+					},
+				},
+			},
+			"mx": schema.SetNestedBlock{
+				Description: "MX record value, set instead of records. Repeat the block for each mail exchange.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"preference": schema.Int64Attribute{
+							Description: "Preference; lower values are tried first",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+						},
+						"exchange": schema.StringAttribute{
+							Description: "Mail exchange host name, with a trailing dot for a fully qualified name",
+							Required:    true,
+							Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+					},
+				},
+			},
+			"srv": schema.SetNestedBlock{
+				Description: "SRV record value, set instead of records. Repeat the block for each target.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"priority": schema.Int64Attribute{
+							Description: "Priority; lower values are tried first",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+						},
+						"weight": schema.Int64Attribute{
+							Description: "Relative weight among targets of the same priority",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+						},
+						"port": schema.Int64Attribute{
+							Description: "Port of the service",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+						},
+						"target": schema.StringAttribute{
+							Description: "Target host name, with a trailing dot for a fully qualified name, or . if the service is not available",
+							Required:    true,
+							Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+					},
+				},
+			},
+			"caa": schema.SetNestedBlock{
+				Description: "CAA record value, set instead of records. Repeat the block for each property.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"flags": schema.Int64Attribute{
+							Description: "Flags; 128 marks the property as critical",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 255)},
+						},
+						"tag": schema.StringAttribute{
+							Description: "Property tag (issue, issuewild, iodef, etc.)",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(caaTagPattern, "must contain only letters and digits"),
+							},
+						},
+						"value": schema.StringAttribute{
+							Description: "Property value, e.g. letsencrypt.org. Quoted automatically.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"https":   svcbBlock("HTTPS"),
+			"svcb":    svcbBlock("SVCB"),
+			"ds":      dsBlock("DS"),
+			"cds":     dsBlock("CDS"),
+			"dnskey":  dnskeyBlock("DNSKEY"),
+			"cdnskey": dnskeyBlock("CDNSKEY"),
+			"tlsa": schema.SetNestedBlock{
+				Description: "TLSA record value, set instead of records. Repeat the block for each certificate association.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"usage": schema.Int64Attribute{
+							Description: "Certificate usage: 0 (PKIX-TA), 1 (PKIX-EE), 2 (DANE-TA) or 3 (DANE-EE)",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 3)},
+						},
+						"selector": schema.Int64Attribute{
+							Description: "Selector: 0 (full certificate) or 1 (SubjectPublicKeyInfo)",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 1)},
+						},
+						"matching_type": schema.Int64Attribute{
+							Description: "Matching type: 0 (exact match), 1 (SHA-256) or 2 (SHA-512)",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 2)},
+						},
+						"certificate_data": schema.StringAttribute{
+							Description: "Certificate association data, hex-encoded. Set this or certificate.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(hexPattern, "must be hex-encoded"),
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("certificate")),
+							},
+						},
+						"certificate": schema.StringAttribute{
+							Description: "PEM-encoded certificate to compute the certificate association data from, according to selector and matching_type. Set this or certificate_data.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"naptr": schema.SetNestedBlock{
+				Description: "NAPTR record value, set instead of records. Repeat the block for each rule.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"order": schema.Int64Attribute{
+							Description: "Order in which rules are processed; lower values first",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+						},
+						"preference": schema.Int64Attribute{
+							Description: "Preference among rules of the same order; lower values first",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+						},
+						"flags": schema.StringAttribute{
+							Description: "Flags, e.g. U for a terminal rule whose regexp gives a URI, or S and A for a terminal rule whose replacement is looked up as SRV or address records. Default: empty",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(naptrFlagsPattern, "must contain only letters and digits"),
+							},
+						},
+						"service": schema.StringAttribute{
+							Description: "Service parameters, e.g. E2U+sip or SIP+D2U. Default: empty",
+							Optional:    true,
+						},
+						"regexp": schema.StringAttribute{
+							Description: "Substitution expression in the form delim ERE delim replacement delim [i], e.g. !^.*$!sip:info@example.com!. Quoted and escaped automatically. Set this or replacement.",
+							Optional:    true,
+						},
+						"replacement": schema.StringAttribute{
+							Description: "Domain name to look up next, with a trailing dot for a fully qualified name. Set this or regexp.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"sshfp": schema.SetNestedBlock{
+				Description: "SSHFP record value, set instead of records. Repeat the block for each host key and fingerprint type.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"algorithm": schema.Int64Attribute{
+							Description: "Host key algorithm: 1 (RSA), 2 (DSA), 3 (ECDSA), 4 (Ed25519) or 6 (Ed448). Required with fingerprint; taken from the key type with public_key.",
+							Optional:    true,
+							Validators:  []validator.Int64{int64validator.OneOf(1, 2, 3, 4, 6)},
+						},
+						"fingerprint_type": schema.Int64Attribute{
+							Description: "Fingerprint type: 1 (SHA-1) or 2 (SHA-256)",
+							Required:    true,
+							Validators:  []validator.Int64{int64validator.Between(1, 2)},
+						},
+						"fingerprint": schema.StringAttribute{
+							Description: "Fingerprint of the host key, hex-encoded. Set this or public_key.",
+							Optional:    true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(hexPattern, "must be hex-encoded"),
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("public_key")),
+							},
+						},
+						"public_key": schema.StringAttribute{
+							Description: "Host public key in OpenSSH format (e.g., the contents of /etc/ssh/ssh_host_ed25519_key.pub), to compute the fingerprint from. Set this or fingerprint.",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"loc": schema.SetNestedBlock{
+				Description: "LOC record value, set instead of records. Coordinates are given in decimal degrees and converted to degrees, minutes and seconds.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"latitude": schema.Float64Attribute{
+							Description: "Latitude in decimal degrees; negative values are south",
+							Required:    true,
+							Validators:  []validator.Float64{float64validator.Between(-90, 90)},
+						},
+						"longitude": schema.Float64Attribute{
+							Description: "Longitude in decimal degrees; negative values are west",
+							Required:    true,
+							Validators:  []validator.Float64{float64validator.Between(-180, 180)},
+						},
+						"altitude": schema.Float64Attribute{
+							Description: "Altitude in meters above the WGS 84 reference spheroid. Default: 0",
+							Optional:    true,
+							Validators:  []validator.Float64{float64validator.Between(-100000, 42849672.95)},
+						},
+						"size": schema.Float64Attribute{
+							Description: "Diameter in meters of a sphere enclosing the location. Default: 1",
+							Optional:    true,
+							Validators:  []validator.Float64{float64validator.Between(0, 90000000)},
+						},
+						"horizontal_precision": schema.Float64Attribute{
+							Description: "Horizontal precision in meters. Default: 10000",
+							Optional:    true,
+							Validators:  []validator.Float64{float64validator.Between(0, 90000000)},
+						},
+						"vertical_precision": schema.Float64Attribute{
+							Description: "Vertical precision in meters. Default: 10",
+							Optional:    true,
+							Validators:  []validator.Float64{float64validator.Between(0, 90000000)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// svcbBlock returns the schema of the https and svcb blocks, which describe
+// the same record data (RFC 9460)
+func svcbBlock(recordType string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		Description: recordType + " record value, set instead of records. Repeat the block for each service endpoint.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"priority": schema.Int64Attribute{
+					Description: "SvcPriority; 0 makes the record an alias to target, lower values are tried first otherwise",
+					Required:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+				},
+				"target": schema.StringAttribute{
+					Description: "TargetName, with a trailing dot for a fully qualified name, or . for the owner name itself",
+					Required:    true,
+					Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+				"mandatory": schema.ListAttribute{
+					Description: "Keys of the parameters clients must support to use the record, e.g. [\"alpn\"]",
+					ElementType: types.StringType,
+					Optional:    true,
+					Validators: []validator.List{
+						listvalidator.ValueStringsAre(stringvalidator.OneOf("alpn", "no-default-alpn", "port", "ipv4hint", "ech", "ipv6hint")),
+					},
+				},
+				"alpn": schema.ListAttribute{
+					Description: "Supported application protocols, e.g. [\"h2\", \"h3\"]",
+					ElementType: types.StringType,
+					Optional:    true,
+					Validators: []validator.List{
+						listvalidator.ValueStringsAre(stringvalidator.RegexMatches(alpnIDPattern, "must be printable ASCII without spaces, commas, quotes or backslashes")),
+					},
+				},
+				"no_default_alpn": schema.BoolAttribute{
+					Description: "Whether the endpoint does not support the default protocol of the scheme (http/1.1 for HTTPS)",
+					Optional:    true,
+				},
+				"port": schema.Int64Attribute{
+					Description: "Port of the service, if not the default port of the scheme",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+				},
+				"ipv4hint": schema.ListAttribute{
+					Description: "IPv4 addresses of the target, which clients may use before resolving it",
+					ElementType: types.StringType,
+					Optional:    true,
+				},
+				"ipv6hint": schema.ListAttribute{
+					Description: "IPv6 addresses of the target, which clients may use before resolving it",
+					ElementType: types.StringType,
+					Optional:    true,
+				},
+				"ech": schema.StringAttribute{
+					Description: "Encrypted ClientHello configuration list, base64-encoded",
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+// dsBlock returns the schema of the ds and cds blocks, which describe
+// records of the given type
+func dsBlock(recordType string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		Description: recordType + " record value, set instead of records. Repeat the block for each key and digest type.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"key_tag": schema.Int64Attribute{
+					Description: "Key tag of the DNSKEY record. Required with digest; computed with public_key, which it must match if set.",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+				},
+				"algorithm": schema.Int64Attribute{
+					Description: "DNSSEC algorithm of the key, e.g. 13 (ECDSAP256SHA256)",
+					Required:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 255)},
+				},
+				"digest_type": schema.Int64Attribute{
+					Description: "Digest type: 1 (SHA-1), 2 (SHA-256) or 4 (SHA-384)",
+					Required:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 255)},
+				},
+				"digest": schema.StringAttribute{
+					Description: "Digest of the DNSKEY record, hex-encoded. Set this or public_key.",
+					Optional:    true,
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(hexPattern, "must be hex-encoded"),
+						stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("public_key")),
+					},
+				},
+				"public_key": schema.StringAttribute{
+					Description: "Base64-encoded public key of the child zone's key (e.g., bind9_dnssec_key.ksk.public_key), to compute the key tag and digest from. The record name must be the child zone's name. Set this or digest.",
+					Optional:    true,
+				},
+				"flags": schema.Int64Attribute{
+					Description: "Flags of the DNSKEY record, used with public_key. Default: 257 (a key signing key)",
+					Optional:    true,
+					Validators: []validator.Int64{
+						int64validator.Between(0, 65535),
+						int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("public_key")),
+					},
+				},
+			},
+		},
+	}
+}
+
+// dnskeyBlock returns the schema of the dnskey and cdnskey blocks, which
+// describe records of the given type
+func dnskeyBlock(recordType string) schema.SetNestedBlock {
+	return schema.SetNestedBlock{
+		Description: recordType + " record value, set instead of records. Repeat the block for each key.",
+		NestedObject: schema.NestedBlockObject{
+			Attributes: map[string]schema.Attribute{
+				"flags": schema.Int64Attribute{
+					Description: "Flags, e.g. 256 for a zone signing key or 257 for a key signing key",
+					Required:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 65535)},
+				},
+				"protocol": schema.Int64Attribute{
+					Description: "Protocol, which must be 3. Default: 3",
+					Optional:    true,
+					Validators:  []validator.Int64{int64validator.OneOf(3)},
+				},
+				"algorithm": schema.Int64Attribute{
+					Description: "DNSSEC algorithm of the key, e.g. 13 (ECDSAP256SHA256)",
+					Required:    true,
+					Validators:  []validator.Int64{int64validator.Between(0, 255)},
+				},
+				"public_key": schema.StringAttribute{
+					Description: "Base64-encoded public key (e.g., bind9_dnssec_key.ksk.public_key)",
+					Required:    true,
+					Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+			},
+		},
+	}
+}
+
+// commentPattern matches a record comment, which is written on the record's
+// line of the zone file
+var commentPattern = regexp.MustCompile(`^[^\x00-\x1f\x7f]*$`)
+
+// hexPattern matches hex-encoded data
+var hexPattern = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// naptrFlagsPattern matches the flags of a NAPTR record (RFC 3403)
+var naptrFlagsPattern = regexp.MustCompile(`^[a-zA-Z0-9]*$`)
+
+// alpnIDPattern matches an ALPN protocol ID that can be written in an alpn
+// parameter without escaping
+var alpnIDPattern = regexp.MustCompile(`^[!#-+\--\[\]-~]+$`)
+
+// caaTagPattern matches a CAA property tag (RFC 8659)
+var caaTagPattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// recordBlocks maps the structured record data blocks to the record type
+// they describe
+var recordBlocks = map[string]string{
+	"mx":    "MX",
+	"srv":   "SRV",
+	"caa":   "CAA",
+	"https": "HTTPS",
+	"svcb":  "SVCB",
+	"tlsa":  "TLSA",
+	"naptr": "NAPTR",
+	"sshfp": "SSHFP",
+	"loc":   "LOC",
+
+	"ds":      "DS",
+	"cds":     "CDS",
+	"dnskey":  "DNSKEY",
+	"cdnskey": "CDNSKEY",
+}
+
+// ValidateConfig checks that structured record data blocks match the record
+// type and are not combined with records, and that records holds no value
+// twice
+func (r *RecordResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType types.String
+	var records types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("records"), &records)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for block, blockType := range recordBlocks {
+		var values types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(block), &values)...)
+		if resp.Diagnostics.HasError() || values.IsNull() || (!values.IsUnknown() && len(values.Elements()) == 0) {
+			continue
+		}
+
+		if isKnownString(recordType) && recordType.ValueString() != blockType {
+			addCodedAttributeError(&resp.Diagnostics, path.Root(block), ErrCodeConfigInvalid,
+				"Invalid Record Data Block",
+				fmt.Sprintf("%s blocks describe %s records, but type is %s.", block, blockType, recordType.ValueString()))
+		}
+		if !records.IsNull() {
+			addCodedAttributeError(&resp.Diagnostics, path.Root(block), ErrCodeConfigInvalid,
+				"Conflicting Record Data",
+				fmt.Sprintf("Set either records or %s blocks, not both.", block))
+		}
+	}
+
+	if isKnownString(recordType) && !records.IsNull() && !records.IsUnknown() {
+		var caseSensitive types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rdata_case_sensitive"), &caseSensitive)...)
+		checkDuplicateRecords(recordType.ValueString(), records, caseSensitive, &resp.Diagnostics)
+	}
+
+	var createPTR types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("create_ptr"), &createPTR)...)
+	if createPTR.ValueBool() && isKnownString(recordType) && recordType.ValueString() != "A" && recordType.ValueString() != "AAAA" {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("create_ptr"), ErrCodeConfigInvalid,
+			"Invalid create_ptr",
+			fmt.Sprintf("create_ptr applies to A and AAAA records, but type is %s.", recordType.ValueString()))
+	}
+}
+
+// checkDuplicateRecords fails on values of records that are the same record
+// data, such as names that differ only in a trailing dot or two spellings of
+// an IP address, which would be stored as one value. Values that are kept
+// apart only because they are compared case-sensitively get a warning.
+func checkDuplicateRecords(recordType string, records types.Set, caseSensitive types.Bool, diags *diag.Diagnostics) {
+	var values []string
+	for _, elem := range records.Elements() {
+		if s, ok := elem.(types.String); ok && isKnownString(s) {
+			values = append(values, s.ValueString())
+		}
+	}
+
+	sensitive := rdataCaseSensitiveFor(recordType, caseSensitive.IsNull() || caseSensitive.IsUnknown() || caseSensitive.ValueBool())
+	for i, v := range values {
+		for _, earlier := range values[:i] {
+			switch {
+			case rdataEqual(recordType, earlier, v, sensitive):
+				addCodedAttributeError(diags, path.Root("records"), ErrCodeConfigInvalid, "Duplicate Record Values",
+					fmt.Sprintf("%q and %q are the same record data: names that differ only in case or a trailing dot, different spellings of an IP address, "+
+						"and, with rdata_case_sensitive set to false, values that differ only in case are one value. Remove one of them.", earlier, v))
+			case !caseSensitive.IsUnknown() && rdataEqual(recordType, earlier, v, false):
+				diags.AddAttributeWarning(path.Root("records"), "Record Values Differ Only in Case",
+					fmt.Sprintf("%q and %q differ only in letter case. They are managed as two values because rdata_case_sensitive is true; "+
+						"if they are meant to be one value, remove one of them.", earlier, v))
+			default:
+				continue
+			}
+			break
+		}
+	}
+}
+
+// UpgradeState migrates state written by earlier schema versions
+func (r *RecordResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored records as a list
+		0: {StateUpgrader: upgradeRecordStateV0},
+	}
+}
+
+// upgradeRecordStateV0 turns the records list of version 0 state into a set.
+// Both are JSON arrays in state, so only duplicate values, which a set cannot
+// hold, are dropped.
+func upgradeRecordStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Record State",
+			"The prior state of the bind9_record resource is not in JSON format, which Terraform 0.12 and later write.")
+		return
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Record State", "Could not parse the prior state: "+err.Error())
+		return
+	}
+
+	var records []string
+	if raw, ok := state["records"]; ok {
+		if err := json.Unmarshal(raw, &records); err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Record State", "Could not parse the prior records: "+err.Error())
+			return
+		}
+	}
+	if records != nil {
+		// Record data is compared in the form of its type
+		var recordType string
+		if raw, ok := state["type"]; ok {
+			_ = json.Unmarshal(raw, &recordType)
+		}
+		unique := make([]string, 0, len(records))
+		for _, rdata := range records {
+			if !containsRdata(recordType, unique, rdata, true) {
+				unique = append(unique, rdata)
+			}
+		}
+		raw, err := json.Marshal(unique)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Record State", err.Error())
+			return
+		}
+		state["records"] = raw
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Record State", err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan applies the provider-level record defaults to ttl and class
+// when they are omitted from the configuration, and checks the TTL against
+// the provider policy
+func (r *RecordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = planView(ctx, r.client, req, &resp.Diagnostics)
+
+	r.planZoneAndName(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.checkRecordLimit(ctx, req, resp)
+	r.checkPlannedCNAME(ctx, req, resp)
+	r.checkRRsetOwner(ctx, req, resp)
+	r.checkComment(ctx, req, resp)
+	r.planBlockRecords(ctx, req, resp)
+	r.planIgnoredRdata(ctx, req, resp)
+
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	var class types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ttl.IsNull() {
+		ttl = types.Int64Value(r.client.defaultTTL)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), ttl)...)
+	}
+	if class.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("class"), r.client.defaultClass)...)
+	}
+
+	// Check the TTL against the advisory policy
+	if ttl.IsUnknown() {
+		return
+	}
+	policy := r.client.policy
+	if policy.minRecordTTL > 0 && ttl.ValueInt64() < policy.minRecordTTL {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ttl"),
+			"Record TTL Below Policy Minimum",
+			fmt.Sprintf("The TTL %d is below the minimum of %d set in the provider policy. "+
+				"Very short TTLs increase query load on the server.", ttl.ValueInt64(), policy.minRecordTTL),
+		)
+	}
+	if policy.maxRecordTTL > 0 && ttl.ValueInt64() > policy.maxRecordTTL {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ttl"),
+			"Record TTL Above Policy Maximum",
+			fmt.Sprintf("The TTL %d is above the maximum of %d set in the provider policy. "+
+				"Long TTLs delay the propagation of changes.", ttl.ValueInt64(), policy.maxRecordTTL),
+		)
+	}
+}
+
+// planIgnoredRdata keeps the values in state, as last read from the server,
+// when ignore_rdata_changes is set, so that neither values rotated on the
+// server nor changed configured values produce a diff
+func (r *RecordResource) planIgnoredRdata(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var ignore types.Bool
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("ignore_rdata_changes"), &ignore)...)
+	var prior types.Set
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("records"), &prior)...)
+	if resp.Diagnostics.HasError() || !ignore.ValueBool() || prior.IsNull() || len(prior.Elements()) == 0 {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), prior)...)
+}
+
+// checkRecordLimit warns when the records planned for creation in a zone
+// would exceed the server's per-zone record limit
+func (r *RecordResource) checkRecordLimit(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() {
+		return
+	}
+
+	var zone, name, recordType types.String
+	var records types.Set
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("records"), &records)...)
+	if resp.Diagnostics.HasError() || zone.IsUnknown() || name.IsUnknown() || recordType.IsUnknown() {
+		return
+	}
+
+	// A record set without a records list holds one record
+	count := int64(1)
+	if !records.IsNull() && !records.IsUnknown() {
+		count = int64(len(records.Elements()))
+	}
+
+	key := strings.ToLower(name.ValueString() + "/" + recordType.ValueString())
+	total, limit, err := r.client.planRecordCreate(ctx, zone.ValueString(), key, count)
+	if err != nil {
+		tflog.Warn(ctx, "Could not check record limit", map[string]any{"error": err.Error()})
+		return
+	}
+	if limit > 0 && total > limit {
+		resp.Diagnostics.AddAttributeWarning(path.Root("records"), "Record Limit Exceeded",
+			quotaWarningDetail("records in zone "+zone.ValueString(), total, limit))
+	}
+}
+
+// updateRecordValues changes removed values of a record set to added ones in
+// place, pairing them in order, and returns the number of pairs changed. It
+// stops at the first pair the server cannot change in place, leaving the rest
+// to be deleted and created.
+func (r *RecordResource) updateRecordValues(ctx context.Context, plan *RecordResourceModel, removed, added []string, diags *diag.Diagnostics) int {
+	if !r.client.canUpdateRecord() {
+		return 0
+	}
+
+	updated := 0
+	for updated < len(removed) && updated < len(added) {
+		err := r.client.UpdateRecord(ctx, plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString(), &RecordUpdateRequest{
+			RData:       removed[updated],
+			TTL:         int(plan.TTL.ValueInt64()),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData(plan.Type.ValueString(), added[updated]),
+		})
+		switch {
+		case err == nil:
+			updated++
+		case isUnsupportedOperation(err):
+			tflog.Debug(ctx, "Record update not available, deleting and creating values", map[string]any{"error": err.Error()})
+			return updated
+		default:
+			addRecordAPIError(diags, "Error Updating Record", fmt.Sprintf("Could not change value %q of record %s %s", removed[updated], plan.owner(), plan.Type.ValueString()), err)
+			return updated
+		}
+	}
+	return updated
+}
+
+// checkRRsetOwner fails the plan if another bind9_record resource of the
+// configuration manages the same record set
+func (r *RecordResource) checkRRsetOwner(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zone, name, recordType, class types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	if resp.Diagnostics.HasError() || !isKnownString(zone) || !isKnownString(name) || !isKnownString(recordType) || class.IsUnknown() {
+		return
+	}
+	if class.IsNull() {
+		class = types.StringValue(r.client.defaultClass)
+	}
+
+	create := req.State.Raw.IsNull()
+	replace := !create && r.plansReplacement(ctx, req, resp)
+	planRRsetOwner(ctx, r.client, zone.ValueString(), name.ValueString(), recordType.ValueString(), class.ValueString(), replace, create, &resp.Diagnostics)
+}
+
+// plansReplacement reports whether a plan with prior state changes one of
+// the attributes that replace the resource. The resource's ModifyPlan runs
+// before the attribute plan modifiers report the replacement, so the
+// attributes are compared here.
+func (r *RecordResource) plansReplacement(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) bool {
+	var planZone, stateZone types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("zone"), &planZone)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("zone"), &stateZone)...)
+	if !sameDNSName(planZone.ValueString(), stateZone.ValueString()) &&
+		!r.client.zoneRenames.renamed(stateZone.ValueString(), planZone.ValueString()) {
+		return true
+	}
+
+	for _, name := range []string{"view", "name", "fqdn", "type", "class"} {
+		var planned, prior types.String
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+		if !planned.IsUnknown() && !planned.Equal(prior) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkComment fails the plan if a comment is set but the server cannot
+// store it
+func (r *RecordResource) checkComment(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var comment types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("comment"), &comment)...)
+	if resp.Diagnostics.HasError() || comment.IsNull() || r.client.supportsRecordComments() {
+		return
+	}
+
+	detail := fmt.Sprintf("The BIND9 REST API server (version %s) does not support record comments.", r.client.ServerVersion())
+	if r.client.dnsUpdate != nil {
+		detail = "Records are managed with dns_update, and DNS UPDATE messages cannot carry comments."
+	}
+	addCodedAttributeError(&resp.Diagnostics, path.Root("comment"), ErrCodeFeatureUnsupported, "Record Comments Not Supported", detail)
+}
+
+// syncComment stores the planned comment of a record set, or removes the
+// comment when it is no longer configured
+func (r *RecordResource) syncComment(ctx context.Context, plan *RecordResourceModel, diags *diag.Diagnostics) {
+	err := r.client.UpdateRRsetComment(withETag(ctx, nil), plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString(), &RRsetCommentUpdateRequest{
+		Comment:     plan.Comment.ValueString(),
+		RecordClass: plan.Class.ValueString(),
+	})
+	if err != nil {
+		addRecordAPIError(diags, "Error Setting Record Comment",
+			fmt.Sprintf("Could not set the comment of record %s %s", plan.owner(), plan.Type.ValueString()), err)
+	}
+}
+
+// checkPlannedCNAME checks a record set planned for creation for CNAME
+// conflicts at its name
+func (r *RecordResource) checkPlannedCNAME(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() {
+		return
+	}
+
+	var zone, name, recordType, class types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	if resp.Diagnostics.HasError() || !isKnownString(zone) || !isKnownString(name) || !isKnownString(recordType) || class.IsUnknown() {
+		return
+	}
+	if class.IsNull() {
+		class = types.StringValue(r.client.defaultClass)
+	}
+
+	planCNAMEConflict(ctx, r.client, zone.ValueString(), name.ValueString(), class.ValueString(), recordType.ValueString(), &resp.Diagnostics)
+}
+
+// planZoneAndName plans zone, name and fqdn from whichever of them are
+// configured. With only fqdn set, the zone is the closest enclosing zone on
+// the server.
+func (r *RecordResource) planZoneAndName(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var fqdn, zone, name types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("fqdn"), &fqdn)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if fqdn.IsNull() {
+		if zone.IsNull() {
+			addCodedError(&resp.Diagnostics, ErrCodeConfigInvalid, "Missing Record Zone",
+				"Set either zone, or fqdn.")
+			return
+		}
+		// Records without a name are at the zone apex
+		if name.IsNull() {
+			name = types.StringValue("@")
+			var state RecordResourceModel
+			if !req.State.Raw.IsNull() {
+				resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				if sameOwnerName(state.Zone.ValueString(), state.Name.ValueString(), "@") {
+					name = state.Name
+				} else {
+					resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name"))
+				}
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), name)...)
+		}
+		if !zone.IsUnknown() && !name.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("fqdn"), recordFQDN(zone.ValueString(), name.ValueString()))...)
+		}
+		return
+	}
+
+	if !name.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("name"), ErrCodeConfigInvalid, "Conflicting Record Name",
+			"name cannot be set together with fqdn. Remove one of them.")
+		return
+	}
+
+	// Zone and name become known once fqdn is
+	if fqdn.IsUnknown() || zone.IsUnknown() {
+		if zone.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), types.StringUnknown())...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), types.StringUnknown())...)
+		return
+	}
+
+	zoneName := zone.ValueString()
+	if zone.IsNull() {
+		zones, err := r.client.ListZones(ctx, nil)
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Finding Record Zone",
+				"Could not list zones to find the zone of "+fqdn.ValueString()+"; set zone explicitly if the zone list is unavailable", err)
+			return
+		}
+		zoneName = enclosingZone(fqdn.ValueString(), zones)
+		if zoneName == "" {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("fqdn"), ErrCodeNotFound, "No Enclosing Zone",
+				fmt.Sprintf("No zone on the server contains %s. Create the zone first, or set zone.", fqdn.ValueString()))
+			return
+		}
+	}
+
+	relative, ok := relativeRecordName(fqdn.ValueString(), zoneName)
+	if !ok {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("fqdn"), ErrCodeConfigInvalid, "Record Outside Zone",
+			fmt.Sprintf("%s is not within zone %s.", fqdn.ValueString(), zoneName))
+		return
+	}
+
+	// Keep the prior spelling of names that only differ in case, and
+	// replace the record if it now resolves to another zone or name
+	var state RecordResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if sameDNSName(state.Zone.ValueString(), zoneName) {
+			zoneName = state.Zone.ValueString()
+		} else {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("zone"))
+		}
+		if sameOwnerName(zoneName, state.Name.ValueString(), relative) {
+			relative = state.Name.ValueString()
+		} else {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name"))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("zone"), zoneName)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), relative)...)
+}
+
+// recordFQDN returns the fully qualified name of a record, without the
+// trailing dot
+func recordFQDN(zone, name string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	switch {
+	case name == "@" || name == "":
+		return zone
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	default:
+		return name + "." + zone
+	}
+}
+
+// enclosingZone returns the longest zone name that is a suffix of fqdn, or ""
+// if none is. Zones that cannot hold records are skipped.
+func enclosingZone(fqdn string, zones []Zone) string {
+	name := strings.ToLower(strings.TrimSuffix(fqdn, "."))
+	best := ""
+	for _, z := range zones {
+		if !holdsRecords(z) {
+			continue
+		}
+		zoneName := strings.TrimSuffix(z.Name, ".")
+		lower := strings.ToLower(zoneName)
+		if (name == lower || strings.HasSuffix(name, "."+lower)) && len(zoneName) > len(best) {
+			best = zoneName
+		}
+	}
+	return best
+}
+
+// holdsRecords reports whether records can be managed in a zone. Forward,
+// hint and stub zones only point at other servers, and a redirect zone only
+// answers for names that do not exist.
+func holdsRecords(z Zone) bool {
+	switch strings.ToLower(z.Type) {
+	case "forward", "hint", "stub", "static-stub", "redirect":
+		return false
+	}
+	return true
+}
+
+// relativeRecordName returns the record name of fqdn relative to zone, or
+// false if fqdn is not within the zone
+func relativeRecordName(fqdn, zone string) (string, bool) {
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	zone = strings.TrimSuffix(zone, ".")
+	if strings.EqualFold(fqdn, zone) {
+		return "@", true
+	}
+	suffix := "." + zone
+	if len(fqdn) > len(suffix) && strings.EqualFold(fqdn[len(fqdn)-len(suffix):], suffix) {
+		return fqdn[:len(fqdn)-len(suffix)], true
+	}
+	return "", false
+}
+
+// Create creates the resource
+func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_record.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan RecordResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	tflog.Debug(ctx, "Creating record", map[string]any{
+		"zone": plan.Zone.ValueString(),
+		"name": plan.owner(),
+		"type": plan.Type.ValueString(),
+	})
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	// Records must be in the same class as their zone. Without the REST API
+	// the server rejects updates to a zone of another class.
+	if r.client.hasAPI() {
+		zone, err := r.client.lookupZone(ctx, plan.Zone.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Creating Record", "Could not read zone "+plan.Zone.ValueString(), err)
+			return
+		}
+		if zone.Class != "" && !strings.EqualFold(zone.Class, plan.Class.ValueString()) {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("class"),
+				ErrCodeConfigInvalid,
+				"Record Class Does Not Match Zone",
+				fmt.Sprintf("Zone %s is in class %s, but the record is in class %s. Set class = %q on the record.",
+					plan.Zone.ValueString(), strings.ToUpper(zone.Class), plan.Class.ValueString(), strings.ToUpper(zone.Class)),
+			)
+			return
+		}
+	}
+
+	// Get records from list or structured attributes
+	records, diags := r.resolveRecords(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkCNAMEConflict(ctx, r.client, plan.Zone.ValueString(), plan.owner(), plan.Class.ValueString(), plan.Type.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	etag := &etagTracker{}
+
+	// With allow_overwrite, an existing RRset is taken over: its values
+	// that are not planned are removed, and planned values it already
+	// has are kept
+	toCreate := records
+	var toDelete []string
+	var existing []Record
+	if plan.AllowOverwrite.ValueBool() {
+		var err error
+		existing, err = r.client.ReadRecords(withETag(ctx, etag), plan.Zone.ValueString(), plan.Type.ValueString(), plan.owner(), plan.Class.ValueString())
+		if err != nil && !isNotFound(err) {
+			addAPIError(&resp.Diagnostics, "Error Creating Record",
+				fmt.Sprintf("Could not read the existing record set %s %s to overwrite it", plan.owner(), plan.Type.ValueString()), err)
+			return
+		}
+		if len(existing) > 0 {
+			tflog.Info(ctx, "Overwriting existing record set", map[string]any{
+				"zone":   plan.Zone.ValueString(),
+				"name":   plan.owner(),
+				"type":   plan.Type.ValueString(),
+				"values": len(existing),
+			})
+		}
+		toDelete, toCreate = overwriteRecords(plan.Type.ValueString(), existing, records, plan.TTL.ValueInt64(), r.rdataCaseSensitive(&plan))
+	}
+
+	if useTransaction {
+		// All values are committed together with the concurrent changes
+		// of other resources in the zone
+		var changes []RecordChange
+		for _, rdata := range toDelete {
+			changes = append(changes, r.recordChange(recordChangeDelete, &plan, rdata, etag.etag))
+		}
+		for _, rdata := range toCreate {
+			changes = append(changes, r.recordChange(recordChangeCreate, &plan, rdata, etag.etag))
+		}
+		if err := r.client.transactions.submit(ctx, plan.Zone.ValueString(), changes); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Creating Record", fmt.Sprintf("Could not create record %s %s", plan.owner(), plan.Type.ValueString()), err)
+			return
+		}
+	} else {
+		// Create each record, following the RRset's ETag from one request to the next
+		rrCtx := withETag(ctx, etag)
+		var deleted []Record
+		for _, rdata := range toDelete {
+			err := r.client.DeleteRecord(rrCtx, plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString(), plan.Class.ValueString(), rdata)
+			if err != nil && !isNotFound(err) {
+				addRecordAPIError(&resp.Diagnostics, "Error Creating Record", fmt.Sprintf("Could not remove existing value %q of record %s %s", rdata, plan.owner(), plan.Type.ValueString()), err)
+				r.rollbackCreate(rrCtx, &plan, nil, deleted, resp)
+				return
+			}
+			for _, rec := range existing {
+				if rec.RData == rdata {
+					deleted = append(deleted, rec)
+					break
+				}
+			}
+		}
+		var created []string
+		for _, rdata := range toCreate {
+			createReq := &RecordCreateRequest{
+				RecordType:  plan.Type.ValueString(),
+				Name:        plan.owner(),
+				TTL:         int(plan.TTL.ValueInt64()),
+				RecordClass: plan.Class.ValueString(),
+				Data:        buildRecordData(plan.Type.ValueString(), rdata),
+				RData:       rdata,
+			}
+
+			_, err := r.client.CreateRecord(rrCtx, plan.Zone.ValueString(), createReq)
+			if err != nil {
+				addRecordAPIError(&resp.Diagnostics, "Error Creating Record", fmt.Sprintf("Could not create record %s %s", plan.owner(), plan.Type.ValueString()), err)
+				r.rollbackCreate(rrCtx, &plan, created, deleted, resp)
+				return
+			}
+			created = append(created, rdata)
+		}
+	}
+
+	// Set ID
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", zoneID(plan.View.ValueString(), plan.Zone.ValueString()), plan.owner(), plan.Type.ValueString()))
+	if plan.FQDN.IsUnknown() || plan.FQDN.IsNull() {
+		plan.FQDN = types.StringValue(recordFQDN(plan.Zone.ValueString(), plan.owner()))
+	}
+
+	// Set computed convenience attributes based on record type and data
+	r.setComputedAttributes(&plan, records)
+	plan.TTLConsistent = types.BoolValue(true)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, etag)...)
+
+	if !plan.Comment.IsNull() {
+		r.syncComment(ctx, &plan, &resp.Diagnostics)
+	}
+
+	// The PTR records are added once the names they point at exist
+	r.syncPTRRecords(ctx, &plan, nil, ptrAddresses(&plan, records), &resp.Diagnostics)
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	r.waitForPropagation(ctx, &plan, records, &resp.Diagnostics)
+}
+
+// rollbackCreate removes the values a failed Create has already added, and
+// adds back the values of an existing RRset that allow_overwrite deleted,
+// with their original TTL, so a new apply finds the server as it was.
+// Values that cannot be removed are saved in state, which Terraform marks
+// tainted because of the error, so the next apply removes them instead of
+// adding them a second time.
+func (r *RecordResource) rollbackCreate(ctx context.Context, plan *RecordResourceModel, created []string, deleted []Record, resp *resource.CreateResponse) {
+	var remaining []string
+	for i := len(created) - 1; i >= 0; i-- {
+		err := r.client.DeleteRecord(ctx, plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString(), plan.Class.ValueString(), created[i])
+		if err != nil && !isNotFound(err) {
+			tflog.Warn(ctx, "Could not roll back created record", map[string]any{"rdata": created[i], "error": err.Error()})
+			remaining = append(remaining, created[i])
+		}
+	}
+
+	var lost []string
+	for _, rec := range deleted {
+		createReq := &RecordCreateRequest{
+			RecordType:  plan.Type.ValueString(),
+			Name:        plan.owner(),
+			TTL:         int(rec.TTL),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData(plan.Type.ValueString(), rec.RData),
+			RData:       rec.RData,
+		}
+		if _, err := r.client.CreateRecord(ctx, plan.Zone.ValueString(), createReq); err != nil {
+			tflog.Warn(ctx, "Could not restore overwritten record", map[string]any{"rdata": rec.RData, "error": err.Error()})
+			lost = append(lost, rec.RData)
+		}
+	}
+	if len(lost) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Overwritten Record Values Not Restored",
+			fmt.Sprintf("The existing values %s of %s %s were removed by allow_overwrite before the error and could not be added back. "+
+				"Add them again by hand, or include them in records and apply.",
+				strings.Join(lost, ", "), plan.owner(), plan.Type.ValueString()),
+		)
+	}
+
+	if len(remaining) == 0 {
+		return
+	}
+
+	recordsSet, diags := types.SetValueFrom(ctx, types.StringType, remaining)
+	resp.Diagnostics.Append(diags...)
+	plan.Records = recordsSet
+	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", zoneID(plan.View.ValueString(), plan.Zone.ValueString()), plan.owner(), plan.Type.ValueString()))
+	if plan.FQDN.IsUnknown() || plan.FQDN.IsNull() {
+		plan.FQDN = types.StringValue(recordFQDN(plan.Zone.ValueString(), plan.owner()))
+	}
+	r.setComputedAttributes(plan, remaining)
+	plan.TTLConsistent = types.BoolValue(true)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	resp.Diagnostics.AddWarning(
+		"Partially Created Record Kept in State",
+		fmt.Sprintf("The values %s of %s %s were created before the error and could not be removed again. "+
+			"They are saved in state as a tainted resource, which the next apply replaces.",
+			strings.Join(remaining, ", "), plan.owner(), plan.Type.ValueString()),
+	)
+}
+
+// overwriteRecords returns the values of an existing RRset to delete and the
+// planned values to create, so that the set ends up with exactly the planned
+// values. Existing values are kept only if the set already has the planned
+// TTL, since the values of a set share one TTL.
+func overwriteRecords(recordType string, existing []Record, planned []string, ttl int64, caseSensitive bool) (toDelete, toCreate []string) {
+	keep := true
+	var current []string
+	for _, rec := range existing {
+		keep = keep && rec.TTL == ttl
+		current = append(current, rec.RData)
+	}
+
+	for _, rdata := range current {
+		if !keep || !containsRdata(recordType, planned, rdata, caseSensitive) {
+			toDelete = append(toDelete, rdata)
+		}
+	}
+	for _, rdata := range planned {
+		if !keep || !containsRdata(recordType, current, rdata, caseSensitive) {
+			toCreate = append(toCreate, rdata)
+		}
+	}
+	return toDelete, toCreate
+}
+
+// resolveRecords returns the record data values from the records attribute, or
+// builds them from the structured attributes for types that support it. The
+// model's records attribute is updated with the resolved values.
+func (r *RecordResource) resolveRecords(ctx context.Context, model *RecordResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var records []string
+
+	if !model.Records.IsNull() && !model.Records.IsUnknown() {
+		diags.Append(model.Records.ElementsAs(ctx, &records, false)...)
+		return records, diags
+	}
+
+	switch model.Type.ValueString() {
+	case "MX", "SRV", "CAA", "HTTPS", "SVCB", "TLSA", "NAPTR", "SSHFP", "LOC", "DS", "CDS", "DNSKEY", "CDNSKEY":
+		blockValues, _, d := blockRecords(ctx, model)
+		diags.Append(d...)
+		records = blockValues
+	case "HINFO":
+		if isKnownString(model.CPU) && isKnownString(model.OS) {
+			records = []string{formatHINFO(model.CPU.ValueString(), model.OS.ValueString())}
+		}
+	case "RP":
+		if isKnownString(model.Mbox) {
+			txt := ""
+			if isKnownString(model.TXTDname) {
+				txt = model.TXTDname.ValueString()
+			}
+			records = []string{formatRP(model.Mbox.ValueString(), txt)}
+		}
+	}
+
+	if len(records) == 0 {
+		addCodedAttributeError(
+			&diags,
+			path.Root("records"),
+			ErrCodeConfigInvalid,
+			"Missing Record Data",
+			"The records attribute must be set, unless the record type supports structured attributes "+
+				"(HINFO: cpu and os, RP: mbox and optionally txt_dname) or blocks (mx, srv, caa, https, svcb, tlsa, naptr, sshfp, loc, ds, cds, dnskey, cdnskey).",
+		)
+		return nil, diags
+	}
+
+	recordsSet, d := types.SetValueFrom(ctx, types.StringType, records)
+	diags.Append(d...)
+	model.Records = recordsSet
+
+	return records, diags
+}
+
+// blockRecords builds the record data values from the record data blocks
+// matching the record type. ok is false while any block value is unknown.
+func blockRecords(ctx context.Context, model *RecordResourceModel) (records []string, ok bool, diags diag.Diagnostics) {
+	var blocks types.Set
+	switch model.Type.ValueString() {
+	case "MX":
+		blocks = model.MX
+	case "SRV":
+		blocks = model.SRV
+	case "CAA":
+		blocks = model.CAA
+	case "HTTPS":
+		blocks = model.HTTPS
+	case "SVCB":
+		blocks = model.SVCB
+	case "TLSA":
+		blocks = model.TLSA
+	case "NAPTR":
+		blocks = model.NAPTR
+	case "SSHFP":
+		blocks = model.SSHFP
+	case "LOC":
+		blocks = model.LOC
+	case "DS":
+		blocks = model.DS
+	case "CDS":
+		blocks = model.CDS
+	case "DNSKEY":
+		blocks = model.DNSKEY
+	case "CDNSKEY":
+		blocks = model.CDNSKEY
+	default:
+		return nil, true, diags
+	}
+	if blocks.IsNull() {
+		return nil, true, diags
+	}
+	if blocks.IsUnknown() {
+		return nil, false, diags
+	}
+
+	switch model.Type.ValueString() {
+	case "MX":
+		var values []MXBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Preference.IsUnknown() || v.Exchange.IsUnknown() {
+				return nil, false, diags
+			}
+			records = append(records, formatMX(v.Preference.ValueInt64(), v.Exchange.ValueString()))
+		}
+	case "SRV":
+		var values []SRVBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Priority.IsUnknown() || v.Weight.IsUnknown() || v.Port.IsUnknown() || v.Target.IsUnknown() {
+				return nil, false, diags
+			}
+			records = append(records, formatSRV(v.Priority.ValueInt64(), v.Weight.ValueInt64(), v.Port.ValueInt64(), v.Target.ValueString()))
+		}
+	case "CAA":
+		var values []CAABlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Flags.IsUnknown() || v.Tag.IsUnknown() || v.Value.IsUnknown() {
+				return nil, false, diags
+			}
+			records = append(records, formatCAA(v.Flags.ValueInt64(), v.Tag.ValueString(), v.Value.ValueString()))
+		}
+	case "HTTPS", "SVCB":
+		var values []SVCBBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			rdata, known, d := svcbBlockRecord(ctx, v)
+			diags.Append(d...)
+			if !known {
+				return nil, false, diags
+			}
+			records = append(records, rdata)
+		}
+	case "TLSA":
+		var values []TLSABlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Usage.IsUnknown() || v.Selector.IsUnknown() || v.MatchingType.IsUnknown() || v.CertificateData.IsUnknown() || v.Certificate.IsUnknown() {
+				return nil, false, diags
+			}
+			data := v.CertificateData.ValueString()
+			if !v.Certificate.IsNull() {
+				var err error
+				data, err = tlsaAssociationData(v.Certificate.ValueString(), v.Selector.ValueInt64(), v.MatchingType.ValueInt64())
+				if err != nil {
+					addCodedAttributeError(&diags, path.Root("tlsa"), ErrCodeConfigInvalid, "Invalid TLSA Certificate", err.Error())
+					return nil, true, diags
+				}
+			}
+			records = append(records, formatTLSA(v.Usage.ValueInt64(), v.Selector.ValueInt64(), v.MatchingType.ValueInt64(), data))
+		}
+	case "NAPTR":
+		var values []NAPTRBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Order.IsUnknown() || v.Preference.IsUnknown() || v.Flags.IsUnknown() || v.Service.IsUnknown() || v.Regexp.IsUnknown() || v.Replacement.IsUnknown() {
+				return nil, false, diags
+			}
+			flags, service, re, replacement := v.Flags.ValueString(), v.Service.ValueString(), v.Regexp.ValueString(), v.Replacement.ValueString()
+			if err := validateNAPTR(flags, re, replacement); err != nil {
+				addCodedAttributeError(&diags, path.Root("naptr"), ErrCodeConfigInvalid, "Invalid NAPTR Rule", err.Error())
+				return nil, true, diags
+			}
+			records = append(records, formatNAPTR(v.Order.ValueInt64(), v.Preference.ValueInt64(), flags, service, re, replacement))
+		}
+	case "SSHFP":
+		var values []SSHFPBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Algorithm.IsUnknown() || v.FingerprintType.IsUnknown() || v.Fingerprint.IsUnknown() || v.PublicKey.IsUnknown() {
+				return nil, false, diags
+			}
+			algorithm, fingerprint := v.Algorithm.ValueInt64(), v.Fingerprint.ValueString()
+			if !v.PublicKey.IsNull() {
+				keyAlgorithm, keyFingerprint, err := sshfpFromPublicKey(v.PublicKey.ValueString(), v.FingerprintType.ValueInt64())
+				if err == nil && !v.Algorithm.IsNull() && algorithm != keyAlgorithm {
+					err = fmt.Errorf("algorithm is %d, but the public key is of algorithm %d", algorithm, keyAlgorithm)
+				}
+				if err != nil {
+					addCodedAttributeError(&diags, path.Root("sshfp"), ErrCodeConfigInvalid, "Invalid SSH Public Key", err.Error())
+					return nil, true, diags
+				}
+				algorithm, fingerprint = keyAlgorithm, keyFingerprint
+			} else if v.Algorithm.IsNull() {
+				addCodedAttributeError(&diags, path.Root("sshfp"), ErrCodeConfigInvalid, "Missing SSHFP Algorithm",
+					"algorithm must be set together with fingerprint.")
+				return nil, true, diags
+			}
+			records = append(records, formatSSHFP(algorithm, v.FingerprintType.ValueInt64(), fingerprint))
+		}
+	case "LOC":
+		var values []LOCBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Latitude.IsUnknown() || v.Longitude.IsUnknown() || v.Altitude.IsUnknown() ||
+				v.Size.IsUnknown() || v.HorizontalPrecision.IsUnknown() || v.VerticalPrecision.IsUnknown() {
+				return nil, false, diags
+			}
+			optional := func(value types.Float64, def float64) float64 {
+				if value.IsNull() {
+					return def
+				}
+				return value.ValueFloat64()
+			}
+			rdata, err := formatLOC(v.Latitude.ValueFloat64(), v.Longitude.ValueFloat64(), optional(v.Altitude, 0),
+				optional(v.Size, 1), optional(v.HorizontalPrecision, 10000), optional(v.VerticalPrecision, 10))
+			if err != nil {
+				addCodedAttributeError(&diags, path.Root("loc"), ErrCodeConfigInvalid, "Invalid LOC Value", err.Error())
+				return nil, true, diags
+			}
+			records = append(records, rdata)
+		}
+	case "DS", "CDS":
+		block := strings.ToLower(model.Type.ValueString())
+		var values []DSBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.KeyTag.IsUnknown() || v.Algorithm.IsUnknown() || v.DigestType.IsUnknown() ||
+				v.Digest.IsUnknown() || v.PublicKey.IsUnknown() || v.Flags.IsUnknown() {
+				return nil, false, diags
+			}
+			keyTag, digest := v.KeyTag.ValueInt64(), v.Digest.ValueString()
+			if !v.PublicKey.IsNull() {
+				// The digest covers the owner name of the DNSKEY record
+				if !isKnownString(model.Zone) || !isKnownString(model.Name) {
+					return nil, false, diags
+				}
+				flags := int64(257)
+				if !v.Flags.IsNull() {
+					flags = v.Flags.ValueInt64()
+				}
+				owner := recordFQDN(model.Zone.ValueString(), model.owner())
+				keyKeyTag, keyDigest, err := dsFromPublicKey(owner, flags, v.Algorithm.ValueInt64(), v.DigestType.ValueInt64(), v.PublicKey.ValueString())
+				if err == nil && !v.KeyTag.IsNull() && keyTag != keyKeyTag {
+					err = fmt.Errorf("key_tag is %d, but the public key has key tag %d", keyTag, keyKeyTag)
+				}
+				if err != nil {
+					addCodedAttributeError(&diags, path.Root(block), ErrCodeConfigInvalid, "Invalid DNSSEC Public Key", err.Error())
+					return nil, true, diags
+				}
+				keyTag, digest = keyKeyTag, keyDigest
+			} else if v.KeyTag.IsNull() {
+				addCodedAttributeError(&diags, path.Root(block), ErrCodeConfigInvalid, "Missing Key Tag",
+					"key_tag must be set together with digest.")
+				return nil, true, diags
+			} else if err := validateDSDigest(v.DigestType.ValueInt64(), digest); err != nil {
+				addCodedAttributeError(&diags, path.Root(block), ErrCodeConfigInvalid, "Invalid Digest", err.Error())
+				return nil, true, diags
+			}
+			records = append(records, formatDS(keyTag, v.Algorithm.ValueInt64(), v.DigestType.ValueInt64(), digest))
+		}
+	case "DNSKEY", "CDNSKEY":
+		block := strings.ToLower(model.Type.ValueString())
+		var values []DNSKEYBlockModel
+		diags.Append(blocks.ElementsAs(ctx, &values, false)...)
+		for _, v := range values {
+			if v.Flags.IsUnknown() || v.Protocol.IsUnknown() || v.Algorithm.IsUnknown() || v.PublicKey.IsUnknown() {
+				return nil, false, diags
+			}
+			protocol := int64(3)
+			if !v.Protocol.IsNull() {
+				protocol = v.Protocol.ValueInt64()
+			}
+			publicKey := strings.Join(strings.Fields(v.PublicKey.ValueString()), "")
+			if _, err := base64.StdEncoding.DecodeString(publicKey); err != nil {
+				addCodedAttributeError(&diags, path.Root(block), ErrCodeConfigInvalid, "Invalid DNSSEC Public Key",
+					fmt.Sprintf("Could not decode the public key: %s", err))
+				return nil, true, diags
+			}
+			records = append(records, formatDNSKEY(v.Flags.ValueInt64(), protocol, v.Algorithm.ValueInt64(), publicKey))
+		}
 	}
+	return records, true, diags
 }
 
-// Configure adds the provider configured client to the resource
-func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
-	if req.ProviderData == nil {
-		return
+// svcbBlockRecord builds the record data of an https or svcb block. known is
+// false while any of its values is unknown.
+func svcbBlockRecord(ctx context.Context, v SVCBBlockModel) (rdata string, known bool, diags diag.Diagnostics) {
+	if v.Priority.IsUnknown() || v.Target.IsUnknown() || v.Mandatory.IsUnknown() || v.ALPN.IsUnknown() ||
+		v.NoDefaultALPN.IsUnknown() || v.Port.IsUnknown() || v.IPv4Hint.IsUnknown() || v.IPv6Hint.IsUnknown() || v.ECH.IsUnknown() {
+		return "", false, diags
 	}
 
-	client, ok := req.ProviderData.(*Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
-		)
-		return
+	params := make(map[string]string)
+	list := func(key string, values types.List, format func([]string) string) {
+		if values.IsNull() {
+			return
+		}
+		var items []string
+		diags.Append(values.ElementsAs(ctx, &items, false)...)
+		if len(items) > 0 {
+			params[key] = format(items)
+		}
+	}
+	addresses := func(items []string) string {
+		for i := range items {
+			items[i] = canonicalAddress(items[i])
+		}
+		return strings.Join(items, ",")
+	}
+	list("mandatory", v.Mandatory, func(items []string) string { return strings.Join(items, ",") })
+	list("alpn", v.ALPN, func(items []string) string { return `"` + strings.Join(items, ",") + `"` })
+	list("ipv4hint", v.IPv4Hint, addresses)
+	list("ipv6hint", v.IPv6Hint, addresses)
+	if v.NoDefaultALPN.ValueBool() {
+		params["no-default-alpn"] = ""
+	}
+	if !v.Port.IsNull() {
+		params["port"] = strconv.FormatInt(v.Port.ValueInt64(), 10)
+	}
+	if !v.ECH.IsNull() {
+		params["ech"] = v.ECH.ValueString()
 	}
 
-	r.client = client
+	return formatSVCB(v.Priority.ValueInt64(), v.Target.ValueString(), params), true, diags
 }
 
-// Create creates the resource
-func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+// planBlockRecords plans the records built from record data blocks, so
+// the plan shows the resulting record data instead of an unknown value
+func (r *RecordResource) planBlockRecords(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
 	var plan RecordResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	var configRecords types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("records"), &configRecords)...)
+	if resp.Diagnostics.HasError() || !configRecords.IsNull() || !isKnownString(plan.Type) {
 		return
 	}
 
-	tflog.Debug(ctx, "Creating record", map[string]any{
-		"zone": plan.Zone.ValueString(),
-		"name": plan.Name.ValueString(),
-		"type": plan.Type.ValueString(),
-	})
-
-	// Get records from list
-	var records []string
-	diags = plan.Records.ElementsAs(ctx, &records, false)
+	records, ok, diags := blockRecords(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	if !ok || len(records) == 0 || resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create each record
-	for _, rdata := range records {
-		createReq := &RecordCreateRequest{
-			RecordType:  plan.Type.ValueString(),
-			Name:        plan.Name.ValueString(),
-			TTL:         int(plan.TTL.ValueInt64()),
-			RecordClass: plan.Class.ValueString(),
-			Data:        r.buildRecordData(plan.Type.ValueString(), rdata),
-		}
-
-		_, err := r.client.CreateRecord(ctx, plan.Zone.ValueString(), createReq)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Creating Record",
-				fmt.Sprintf("Could not create record %s %s: %s", plan.Name.ValueString(), plan.Type.ValueString(), err.Error()),
-			)
-			return
+	// Keep the prior spelling when the server returns the same values
+	if !req.State.Raw.IsNull() {
+		var prior types.Set
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("records"), &prior)...)
+		var priorRecords []string
+		if !prior.IsNull() && !prior.IsUnknown() {
+			resp.Diagnostics.Append(prior.ElementsAs(ctx, &priorRecords, false)...)
+			if rdataSetsEqual(plan.Type.ValueString(), priorRecords, records, r.rdataCaseSensitive(&plan)) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), prior)...)
+				return
+			}
 		}
 	}
 
-	// Set ID
-	plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", plan.Zone.ValueString(), plan.Name.ValueString(), plan.Type.ValueString()))
+	recordsSet, diags := types.SetValueFrom(ctx, types.StringType, records)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), recordsSet)...)
+}
 
-	// Set computed convenience attributes based on record type and data
-	r.setComputedAttributes(&plan, records)
+// rdataCaseSensitive returns the rdata comparison mode, defaulting to
+// case-sensitive. Record data that holds only names is never compared
+// case-sensitively.
+func (r *RecordResource) rdataCaseSensitive(model *RecordResourceModel) bool {
+	configured := model.RdataCaseSensitive.IsNull() || model.RdataCaseSensitive.IsUnknown() || model.RdataCaseSensitive.ValueBool()
+	return rdataCaseSensitiveFor(model.Type.ValueString(), configured)
+}
 
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
+// owner returns the record name sent to the API. The zone apex, which may be
+// configured as "@", "" or the zone's fully qualified name, is always "@".
+func (m *RecordResourceModel) owner() string {
+	return apexName(m.Zone.ValueString(), m.Name.ValueString())
+}
+
+// apexName returns name, or "@" if name is the apex of zone
+func apexName(zone, name string) string {
+	if name == "" || sameDNSName(recordFQDN(zone, name), zone) {
+		return "@"
+	}
+	return name
+}
+
+// isKnownString reports whether a string value is set and known
+func isKnownString(v types.String) bool {
+	return !v.IsNull() && !v.IsUnknown()
 }
 
 // setComputedAttributes sets the computed convenience attributes based on record type
 func (r *RecordResource) setComputedAttributes(model *RecordResourceModel, records []string) {
+	// Remember configured RP values so equivalent spellings are preserved
+	priorMbox := model.Mbox
+	priorTXTDname := model.TXTDname
+
 	// Set all computed attributes to empty/zero values (not null, which stays "unknown")
 	model.Address = types.StringValue("")
 	model.Target = types.StringValue("")
@@ -342,6 +2126,10 @@ func (r *RecordResource) setComputedAttributes(model *RecordResourceModel, recor
 	model.Flags = types.Int64Value(0)
 	model.Tag = types.StringValue("")
 	model.Value = types.StringValue("")
+	model.CPU = types.StringValue("")
+	model.OS = types.StringValue("")
+	model.Mbox = types.StringValue("")
+	model.TXTDname = types.StringValue("")
 
 	if len(records) == 0 {
 		return
@@ -356,7 +2144,7 @@ func (r *RecordResource) setComputedAttributes(model *RecordResourceModel, recor
 	case "CNAME", "DNAME", "NS", "PTR":
 		model.Target = types.StringValue(rdata)
 	case "TXT":
-		model.Text = types.StringValue(strings.Trim(rdata, "\""))
+		model.Text = types.StringValue(txtText(rdata))
 	case "MX":
 		parts := strings.SplitN(rdata, " ", 2)
 		if len(parts) == 2 {
@@ -388,6 +2176,22 @@ func (r *RecordResource) setComputedAttributes(model *RecordResourceModel, recor
 			model.Tag = types.StringValue(parts[1])
 			model.Value = types.StringValue(strings.Trim(parts[2], "\""))
 		}
+	case "HINFO":
+		if cpu, os, ok := parseHINFO(rdata); ok {
+			model.CPU = types.StringValue(cpu)
+			model.OS = types.StringValue(os)
+		}
+	case "RP":
+		if mbox, txt, ok := parseRP(rdata); ok {
+			model.Mbox = types.StringValue(mbox)
+			if isKnownString(priorMbox) && mailboxToDname(priorMbox.ValueString()) == mbox {
+				model.Mbox = priorMbox
+			}
+			model.TXTDname = types.StringValue(txt)
+			if isKnownString(priorTXTDname) && fqdnOrRoot(priorTXTDname.ValueString()) == txt {
+				model.TXTDname = priorTXTDname
+			}
+		}
 	}
 }
 
@@ -399,12 +2203,12 @@ func parseInt64(s string) (int64, error) {
 }
 
 // buildRecordData constructs the data map for creating a record
-func (r *RecordResource) buildRecordData(recordType, rdata string) map[string]interface{} {
+func buildRecordData(recordType, rdata string) map[string]interface{} {
 	data := make(map[string]interface{})
 
 	switch recordType {
 	case "A", "AAAA":
-		data["address"] = rdata
+		data["address"] = canonicalAddress(rdata)
 	case "CNAME", "DNAME":
 		data["target"] = rdata
 	case "NS":
@@ -419,7 +2223,13 @@ func (r *RecordResource) buildRecordData(recordType, rdata string) map[string]in
 			data["exchange"] = parts[1]
 		}
 	case "TXT":
-		data["text"] = strings.Trim(rdata, "\"")
+		// Text that does not fit one character-string goes as several
+		// character-strings in presentation format
+		if text := txtText(rdata); len(text) > maxCharacterString {
+			data["text"] = quoteTXT(text)
+		} else {
+			data["text"] = text
+		}
 	case "SRV":
 		// Parse "priority weight port target" format
 		parts := strings.SplitN(rdata, " ", 4)
@@ -437,6 +2247,22 @@ func (r *RecordResource) buildRecordData(recordType, rdata string) map[string]in
 			data["tag"] = parts[1]
 			data["value"] = strings.Trim(parts[2], "\"")
 		}
+	case "HINFO":
+		// Parse "cpu os" format, each field optionally quoted
+		if cpu, os, ok := parseHINFO(rdata); ok {
+			data["cpu"] = cpu
+			data["os"] = os
+		} else {
+			data["rdata"] = rdata
+		}
+	case "RP":
+		// Parse "mbox txt" format
+		if mbox, txt, ok := parseRP(rdata); ok {
+			data["mbox"] = mailboxToDname(mbox)
+			data["txt"] = fqdnOrRoot(txt)
+		} else {
+			data["rdata"] = rdata
+		}
 	default:
 		data["rdata"] = rdata
 	}
@@ -446,6 +2272,9 @@ func (r *RecordResource) buildRecordData(recordType, rdata string) map[string]in
 
 // Read refreshes the Terraform state
 func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_record.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
 	var state RecordResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -453,22 +2282,34 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx = withView(ctx, state.View.ValueString())
+
+	resp.Diagnostics.Append(r.client.restoreSquelch(ctx, resp.Private)...)
+
 	tflog.Debug(ctx, "Reading record", map[string]any{
 		"zone": state.Zone.ValueString(),
-		"name": state.Name.ValueString(),
+		"name": state.owner(),
 		"type": state.Type.ValueString(),
 	})
 
-	records, err := r.client.GetRecords(ctx, state.Zone.ValueString(), state.Type.ValueString(), state.Name.ValueString())
+	etag := &etagTracker{}
+	records, err := r.client.ReadRecords(withETag(ctx, etag), state.Zone.ValueString(), state.Type.ValueString(), state.owner(), state.Class.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+		if isNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError(
-			"Error Reading Record",
-			"Could not read record: "+err.Error(),
-		)
+		addRecordAPIError(&resp.Diagnostics, "Error Reading Record", "Could not read record", err)
+		return
+	}
+
+	if len(records) == 0 && r.client.readsAreAuthoritative() {
+		tflog.Debug(ctx, "Record no longer exists", map[string]any{
+			"zone": state.Zone.ValueString(),
+			"name": state.owner(),
+			"type": state.Type.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
 		return
 	}
 
@@ -478,7 +2319,7 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		// likely still exists. Trust the Create operation succeeded.
 		tflog.Warn(ctx, "API returned no records, but record may exist in zone journal. Keeping state.", map[string]any{
 			"zone": state.Zone.ValueString(),
-			"name": state.Name.ValueString(),
+			"name": state.owner(),
 			"type": state.Type.ValueString(),
 		})
 		// Keep existing state - don't remove or modify
@@ -487,30 +2328,107 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	// Update state with records
+	r.setReadRecords(ctx, &state, records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, etag)...)
+}
+
+// setReadRecords updates the model with the records read from the server,
+// keeping the configured spelling of values that are equivalent under the
+// comparison mode
+func (r *RecordResource) setReadRecords(ctx context.Context, state *RecordResourceModel, records []Record, diags *diag.Diagnostics) {
 	var recordValues []string
 	for _, rec := range records {
 		recordValues = append(recordValues, rec.RData)
 	}
+	var priorValues []string
+	if !state.Records.IsNull() {
+		diags.Append(state.Records.ElementsAs(ctx, &priorValues, false)...)
+	}
+	recordValues = preserveRdataSpelling(state.Type.ValueString(), recordValues, priorValues, r.rdataCaseSensitive(state))
 
-	recordsList, diags := types.ListValueFrom(ctx, types.StringType, recordValues)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	recordsSet, d := types.SetValueFrom(ctx, types.StringType, recordValues)
+	diags.Append(d...)
+	if diags.HasError() {
 		return
 	}
 
-	state.Records = recordsList
+	state.Records = recordsSet
 	state.TTL = types.Int64Value(int64(records[0].TTL))
+	if state.FQDN.IsNull() {
+		state.FQDN = types.StringValue(recordFQDN(state.Zone.ValueString(), state.owner()))
+	}
+	state.TTLConsistent = types.BoolValue(true)
+	if detail := mixedTTLs(records); detail != "" {
+		state.TTLConsistent = types.BoolValue(false)
+		diags.AddAttributeWarning(
+			path.Root("ttl"),
+			"Inconsistent TTLs in Record Set",
+			fmt.Sprintf("The records of %s %s in zone %s do not share one TTL (%s), which RFC 2181 forbids; "+
+				"resolvers may use any of them. The TTL of some records was likely changed outside Terraform. "+
+				"Replace the resource (terraform apply -replace) to rewrite all records with ttl = %d.",
+				state.owner(), state.Type.ValueString(), state.Zone.ValueString(), detail, state.TTL.ValueInt64()),
+		)
+	}
+	state.Class = types.StringValue(strings.ToUpper(recordClass(records[0])))
+	if state.RdataCaseSensitive.IsNull() {
+		state.RdataCaseSensitive = types.BoolValue(true)
+	}
+	if r.client.supportsRecordComments() {
+		state.Comment = types.StringNull()
+		for _, rec := range records {
+			if rec.Comment != "" {
+				state.Comment = types.StringValue(rec.Comment)
+				break
+			}
+		}
+	}
+	// Imported state has no value for these yet
+	if state.AllowOverwrite.IsNull() {
+		state.AllowOverwrite = types.BoolValue(false)
+	}
+	if state.CreatePTR.IsNull() {
+		state.CreatePTR = types.BoolValue(false)
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+	if state.IgnoreRdataChanges.IsNull() {
+		state.IgnoreRdataChanges = types.BoolValue(false)
+	}
 
 	// Set computed convenience attributes
-	r.setComputedAttributes(&state, recordValues)
+	r.setComputedAttributes(state, recordValues)
+}
 
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+// mixedTTLs describes the TTLs of an RRset whose records do not all share
+// the same TTL, or returns "" if they do
+func mixedTTLs(records []Record) string {
+	var parts []string
+	mixed := false
+	for _, rec := range records {
+		if rec.TTL != records[0].TTL {
+			mixed = true
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d", rec.RData, rec.TTL))
+	}
+	if !mixed {
+		return ""
+	}
+	return strings.Join(parts, ", ")
 }
 
 // Update updates the resource
 func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_record.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var plan RecordResourceModel
 	var state RecordResourceModel
 	
@@ -522,76 +2440,264 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	ctx = withView(ctx, plan.View.ValueString())
+
 	tflog.Debug(ctx, "Updating record", map[string]any{
 		"zone": plan.Zone.ValueString(),
-		"name": plan.Name.ValueString(),
+		"name": plan.owner(),
 		"type": plan.Type.ValueString(),
 	})
 
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
 	// Get old and new records
-	var oldRecords, newRecords []string
+	var oldRecords []string
 	diags = state.Records.ElementsAs(ctx, &oldRecords, false)
 	resp.Diagnostics.Append(diags...)
-	diags = plan.Records.ElementsAs(ctx, &newRecords, false)
+	newRecords, diags := r.resolveRecords(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Delete old records that are no longer present
-	for _, oldRdata := range oldRecords {
-		found := false
-		for _, newRdata := range newRecords {
-			if oldRdata == newRdata {
-				found = true
-				break
-			}
-		}
-		if !found {
-			err := r.client.DeleteRecord(ctx, plan.Zone.ValueString(), plan.Name.ValueString(), plan.Type.ValueString(), oldRdata)
-			if err != nil {
-				tflog.Warn(ctx, "Could not delete old record", map[string]any{"error": err.Error()})
-			}
-		}
+	caseSensitive := r.rdataCaseSensitive(&plan)
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Add new records that don't exist
-	for _, newRdata := range newRecords {
-		found := false
+	// Changes are guarded by the RRset's ETag from the last read. A record
+	// set whose zone was renamed has not been read under the new name yet.
+	etag := loadETag(ctx, req.Private)
+	if !plan.Zone.Equal(state.Zone) {
+		etag = &etagTracker{}
+	}
+	ttlOnly := rdataSetsEqual(plan.Type.ValueString(), oldRecords, newRecords, caseSensitive) && !plan.TTL.Equal(state.TTL)
+	if ttlOnly && !useTransaction {
+		// Only the TTL changed
+		if !r.updateTTL(ctx, &plan, newRecords, etag, &resp.Diagnostics) {
+			return
+		}
+	} else if useTransaction {
+		// The RRset must still exist, so that a set removed out of band
+		// fails the transaction instead of being recreated with only the
+		// added values. When only the TTL changed, every value is removed
+		// and added again with the new TTL in the same transaction.
+		var changes []RecordChange
 		for _, oldRdata := range oldRecords {
-			if oldRdata == newRdata {
-				found = true
-				break
+			if ttlOnly || !containsRdata(plan.Type.ValueString(), newRecords, oldRdata, caseSensitive) {
+				change := r.recordChange(recordChangeDelete, &plan, oldRdata, etag.etag)
+				change.RequireRRset = true
+				changes = append(changes, change)
 			}
 		}
-		if !found {
-			createReq := &RecordCreateRequest{
-				RecordType:  plan.Type.ValueString(),
-				Name:        plan.Name.ValueString(),
+		for _, newRdata := range newRecords {
+			if ttlOnly || !containsRdata(plan.Type.ValueString(), oldRecords, newRdata, caseSensitive) {
+				changes = append(changes, r.recordChange(recordChangeCreate, &plan, newRdata, etag.etag))
+			}
+		}
+		if err := r.client.transactions.submit(ctx, plan.Zone.ValueString(), changes); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Updating Record", "Could not update record", err)
+			return
+		}
+		// The transaction returns no ETag; the next read records it
+		etag.etag = ""
+	} else {
+		rrCtx := withETag(ctx, etag)
+
+		// Replace the whole set in one request where the server supports
+		// it, which also applies a changed TTL to the values kept
+		replaced := false
+		changed := !rdataSetsEqual(plan.Type.ValueString(), oldRecords, newRecords, caseSensitive) || !plan.TTL.Equal(state.TTL)
+		if r.client.canReplaceRRset() && changed {
+			replaceReq := &RRsetReplaceRequest{
 				TTL:         int(plan.TTL.ValueInt64()),
 				RecordClass: plan.Class.ValueString(),
-				Data:        r.buildRecordData(plan.Type.ValueString(), newRdata),
 			}
-			_, err := r.client.CreateRecord(ctx, plan.Zone.ValueString(), createReq)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Error Updating Record",
-					fmt.Sprintf("Could not create record: %s", err.Error()),
-				)
+			for _, rdata := range newRecords {
+				replaceReq.Records = append(replaceReq.Records, buildRecordData(plan.Type.ValueString(), rdata))
+				replaceReq.RData = append(replaceReq.RData, rdata)
+			}
+			err := r.client.ReplaceRRset(rrCtx, plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString(), replaceReq)
+			switch {
+			case err == nil:
+				replaced = true
+			case isUnsupportedOperation(err) && r.client.dnsUpdate == nil:
+				tflog.Debug(ctx, "Record set replace not available, updating values one by one", map[string]any{"error": err.Error()})
+			default:
+				addRecordAPIError(&resp.Diagnostics, "Error Updating Record", "Could not replace record set", err)
+				return
+			}
+		}
+
+		if !replaced {
+			// Change removed values to added ones in place where the server
+			// supports it, so the set is never without them
+			var removed, added []string
+			for _, oldRdata := range oldRecords {
+				if !containsRdata(plan.Type.ValueString(), newRecords, oldRdata, caseSensitive) {
+					removed = append(removed, oldRdata)
+				}
+			}
+			for _, newRdata := range newRecords {
+				if !containsRdata(plan.Type.ValueString(), oldRecords, newRdata, caseSensitive) {
+					added = append(added, newRdata)
+				}
+			}
+			updated := r.updateRecordValues(rrCtx, &plan, removed, added, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
 				return
 			}
+			removed, added = removed[updated:], added[updated:]
+
+			// Delete old records that are no longer present
+			for _, oldRdata := range removed {
+				err := r.client.DeleteRecord(rrCtx, plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString(), plan.Class.ValueString(), oldRdata)
+				if err != nil {
+					if isPreconditionFailed(err) {
+						addRecordAPIError(&resp.Diagnostics, "Error Updating Record", "Could not delete old record", err)
+						return
+					}
+					tflog.Warn(ctx, "Could not delete old record", map[string]any{"error": err.Error()})
+				}
+			}
+
+			// Add new records that don't exist
+			for _, newRdata := range added {
+				createReq := &RecordCreateRequest{
+					RecordType:  plan.Type.ValueString(),
+					Name:        plan.owner(),
+					TTL:         int(plan.TTL.ValueInt64()),
+					RecordClass: plan.Class.ValueString(),
+					Data:        buildRecordData(plan.Type.ValueString(), newRdata),
+					RData:       newRdata,
+				}
+				_, err := r.client.CreateRecord(rrCtx, plan.Zone.ValueString(), createReq)
+				if err != nil {
+					addRecordAPIError(&resp.Diagnostics, "Error Updating Record", "Could not create record", err)
+					return
+				}
+			}
 		}
 	}
 
 	// Set computed convenience attributes
 	r.setComputedAttributes(&plan, newRecords)
+	if plan.TTLConsistent.IsUnknown() {
+		plan.TTLConsistent = types.BoolValue(true)
+	}
+	if plan.ID.IsUnknown() {
+		plan.ID = types.StringValue(fmt.Sprintf("%s/%s/%s", zoneID(plan.View.ValueString(), plan.Zone.ValueString()), plan.owner(), plan.Type.ValueString()))
+	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, etag)...)
+
+	// Values added to the set get the comment too
+	if !plan.Comment.Equal(state.Comment) || (!plan.Comment.IsNull() && !rdataSetsEqual(plan.Type.ValueString(), oldRecords, newRecords, caseSensitive)) {
+		r.syncComment(ctx, &plan, &resp.Diagnostics)
+	}
+
+	r.syncPTRRecords(ctx, &plan, ptrAddresses(&state, oldRecords), ptrAddresses(&plan, newRecords), &resp.Diagnostics)
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	r.waitForPropagation(ctx, &plan, newRecords, &resp.Diagnostics)
+}
+
+// updateTTL applies a changed TTL to the values of a record set in a single
+// change where the server allows it, so the values stay in place and the
+// zone serial is bumped once. It is not used with record transactions,
+// which carry the change themselves. It returns false if the update failed.
+func (r *RecordResource) updateTTL(ctx context.Context, plan *RecordResourceModel, records []string, etag *etagTracker, diags *diag.Diagnostics) bool {
+	rrCtx := withETag(ctx, etag)
+	zone, name, recordType := plan.Zone.ValueString(), plan.owner(), plan.Type.ValueString()
+	ttl := int(plan.TTL.ValueInt64())
+
+	if r.client.dnsUpdate == nil {
+		err := r.client.UpdateRRsetTTL(rrCtx, zone, name, recordType, &RRsetTTLUpdateRequest{TTL: ttl, RecordClass: plan.Class.ValueString()})
+		if err == nil {
+			return true
+		}
+		if !isUnsupportedOperation(err) {
+			addRecordAPIError(diags, "Error Updating Record", "Could not update the TTL of the record set", err)
+			return false
+		}
+		tflog.Debug(ctx, "Record set TTL update not available", map[string]any{"error": err.Error()})
+	}
+
+	if r.client.canReplaceRRset() {
+		replaceReq := &RRsetReplaceRequest{TTL: ttl, RecordClass: plan.Class.ValueString()}
+		for _, rdata := range records {
+			replaceReq.Records = append(replaceReq.Records, buildRecordData(recordType, rdata))
+			replaceReq.RData = append(replaceReq.RData, rdata)
+		}
+		err := r.client.ReplaceRRset(rrCtx, zone, name, recordType, replaceReq)
+		if err == nil {
+			return true
+		}
+		if !isUnsupportedOperation(err) || r.client.dnsUpdate != nil {
+			addRecordAPIError(diags, "Error Updating Record", "Could not update the TTL of the record set", err)
+			return false
+		}
+	}
+
+	// Without either endpoint, each value is removed and added again with
+	// the new TTL
+	tflog.Debug(ctx, "Re-adding record values to change their TTL", map[string]any{"zone": zone, "name": name, "type": recordType})
+	for _, rdata := range records {
+		err := r.client.DeleteRecord(rrCtx, zone, name, recordType, plan.Class.ValueString(), rdata)
+		if err != nil && !isNotFound(err) {
+			addRecordAPIError(diags, "Error Updating Record", "Could not remove record to change its TTL", err)
+			return false
+		}
+		createReq := &RecordCreateRequest{
+			RecordType:  recordType,
+			Name:        name,
+			TTL:         ttl,
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData(recordType, rdata),
+			RData:       rdata,
+		}
+		if _, err := r.client.CreateRecord(rrCtx, zone, createReq); err != nil {
+			addRecordAPIError(diags, "Error Updating Record", "Could not add record with the new TTL", err)
+			return false
+		}
+	}
+	return true
+}
+
+// waitForPropagation waits until the nameservers of the wait_for block answer
+// with the records. It runs after the state is saved, so a record that does
+// not propagate in time is still tracked.
+func (r *RecordResource) waitForPropagation(ctx context.Context, model *RecordResourceModel, records []string, diags *diag.Diagnostics) {
+	if diags.HasError() || model.WaitFor.IsNull() || model.WaitFor.IsUnknown() {
+		return
+	}
+
+	var waitFor WaitForModel
+	diags.Append(model.WaitFor.As(ctx, &waitFor, basetypes.ObjectAsOptions{})...)
+	wait, d := newPropagationWait(ctx, waitFor)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	err := waitForPropagation(ctx, wait, model.Zone.ValueString(), model.owner(), model.Type.ValueString(), model.Class.ValueString(), records)
+	if err != nil {
+		addCodedError(diags, ErrCodeNotPropagated, "Record Not Propagated", err.Error())
+	}
 }
 
 // Delete deletes the resource
 func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_record.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var state RecordResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -599,12 +2705,25 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx = withView(ctx, state.View.ValueString())
+
+	if state.DeletionProtection.ValueBool() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("deletion_protection"), ErrCodeConfigInvalid, "Record Deletion Protected",
+			fmt.Sprintf("Record %s %s in zone %s has deletion_protection set and was not deleted. "+
+				"To delete it, set deletion_protection to false and apply, then destroy or remove the resource.",
+				state.owner(), state.Type.ValueString(), state.Zone.ValueString()))
+		return
+	}
+
 	tflog.Debug(ctx, "Deleting record", map[string]any{
 		"zone": state.Zone.ValueString(),
-		"name": state.Name.ValueString(),
+		"name": state.owner(),
 		"type": state.Type.ValueString(),
 	})
 
+	r.client.beginZoneChange(ctx, state.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, state.Zone.ValueString())
+
 	// Get records to delete
 	var records []string
 	diags = state.Records.ElementsAs(ctx, &records, false)
@@ -613,51 +2732,199 @@ func (r *RecordResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// Delete each record
+	// Remove the PTR records first, so none is left pointing at a name
+	// that no longer exists
+	r.syncPTRRecords(ctx, &state, ptrAddresses(&state, records), nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Delete each record, guarded by the RRset's ETag from the last read
+	etag := loadETag(ctx, req.Private)
+	if useTransaction {
+		var changes []RecordChange
+		for _, rdata := range records {
+			changes = append(changes, r.recordChange(recordChangeDelete, &state, rdata, etag.etag))
+		}
+		err := r.client.transactions.submit(ctx, state.Zone.ValueString(), changes)
+		if err != nil && !isNotFound(err) {
+			addRecordAPIError(&resp.Diagnostics, "Error Deleting Record", "Could not delete record", err)
+		}
+		return
+	}
+
+	rrCtx := withETag(ctx, etag)
 	for _, rdata := range records {
-		err := r.client.DeleteRecord(ctx, state.Zone.ValueString(), state.Name.ValueString(), state.Type.ValueString(), rdata)
+		err := r.client.DeleteRecord(rrCtx, state.Zone.ValueString(), state.owner(), state.Type.ValueString(), state.Class.ValueString(), rdata)
 		if err != nil {
-			errStr := strings.ToLower(err.Error())
-			// Treat these errors as success - the record is effectively deleted:
-			// - 404/not found: record doesn't exist
-			// - REFUSED: zone was already deleted (BIND9 auto-removes records with zone)
-			// - zone not found: zone was already deleted
-			if strings.Contains(errStr, "404") ||
-				strings.Contains(errStr, "not found") ||
-				strings.Contains(errStr, "refused") ||
-				strings.Contains(errStr, "no matching zone") {
+			// The record, or its whole zone, is already gone
+			if isNotFound(err) {
 				tflog.Debug(ctx, "Record already deleted or zone removed", map[string]any{
 					"zone":  state.Zone.ValueString(),
-					"name":  state.Name.ValueString(),
+					"name":  state.owner(),
 					"type":  state.Type.ValueString(),
 					"error": err.Error(),
 				})
 				continue
 			}
-			resp.Diagnostics.AddError(
-				"Error Deleting Record",
-				fmt.Sprintf("Could not delete record: %s", err.Error()),
-			)
+			addRecordAPIError(&resp.Diagnostics, "Error Deleting Record", "Could not delete record", err)
 			return
 		}
 	}
 }
 
+// useRecordTransactions reports whether record changes are sent as part of a
+// zone transaction, adding an error if the API server does not support them
+func useRecordTransactions(client *Client, diags *diag.Diagnostics) bool {
+	if client.transactions == nil {
+		return false
+	}
+	if client.dnsUpdate == nil {
+		return checkServerFeature(client, FeatureTransactions, "Record Transactions", diags)
+	}
+	return true
+}
+
+// recordChange builds the transaction change adding or removing one value of
+// a record set
+func (r *RecordResource) recordChange(action string, model *RecordResourceModel, rdata, ifMatch string) RecordChange {
+	change := RecordChange{
+		Action:      action,
+		RecordType:  model.Type.ValueString(),
+		Name:        model.owner(),
+		RecordClass: model.Class.ValueString(),
+		RData:       rdata,
+		IfMatch:     ifMatch,
+	}
+	if action == recordChangeCreate {
+		change.TTL = int(model.TTL.ValueInt64())
+		change.Data = buildRecordData(model.Type.ValueString(), rdata)
+	}
+	return change
+}
+
 // ImportState imports an existing resource
 func (r *RecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import format: zone/name/type
-	parts := strings.Split(req.ID, "/")
-	if len(parts) != 3 {
-		resp.Diagnostics.AddError(
+	// Import format: zone/name/type[/rdata], or zone=...,name=...,type=...[,rdata=...][,class=...][,view=...]
+	values, err := recordImportID.parse(req.ID)
+	if err != nil {
+		if rdataValues, rdataErr := recordRdataImportID.parse(req.ID); rdataErr == nil {
+			values, err = rdataValues, nil
+		}
+	}
+	if err != nil {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
 			"Invalid Import ID",
-			"Import ID must be in format: zone/name/type (e.g., example.com/www/A)",
+			fmt.Sprintf("%s (e.g., example.com/www/A, example.com/www/A/192.0.2.10 or zone=example.com,name=www,type=A,class=IN)", err),
 		)
 		return
 	}
 
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), parts[0])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), parts[2])...)
+	id := fmt.Sprintf("%s/%s/%s", zoneID(values["view"], values["zone"]), values["name"], values["type"])
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), values["zone"])...)
+	if view := values["view"]; view != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view"), view)...)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), values["name"])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), values["type"])...)
+	if class := values["class"]; class != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("class"), strings.ToUpper(class))...)
+	}
+	if resp.Diagnostics.HasError() || r.client == nil {
+		return
+	}
+
+	// Read the record set now, so a missing set fails the import and the
+	// first plan compares against a fully populated state
+	var state RecordResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, values["view"])
+	etag := &etagTracker{}
+	records, err := r.client.ReadRecords(withETag(ctx, etag), values["zone"], values["type"], values["name"], values["class"])
+	if err != nil {
+		if isNotFound(err) {
+			addCodedError(&resp.Diagnostics, ErrCodeNotFound, "Zone Not Found",
+				fmt.Sprintf("Could not import %s: zone %s does not exist on the server.", id, values["zone"]))
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Importing Record", "Could not read the record set to import", err)
+		return
+	}
+	if len(records) == 0 {
+		addCodedError(&resp.Diagnostics, ErrCodeNotFound, "Record Set Not Found",
+			fmt.Sprintf("Could not import %s: zone %s has no %s records named %s.", id, values["zone"], values["type"], values["name"]))
+		return
+	}
+
+	if rdata := values["rdata"]; rdata != "" {
+		if !importRdataChecked(values, records, &resp.Diagnostics) {
+			return
+		}
+		// Keep the spelling of the ID, as for a configured value
+		prior, diags := types.SetValueFrom(ctx, types.StringType, []string{rdata})
+		resp.Diagnostics.Append(diags...)
+		state.Records = prior
+	}
+
+	r.setReadRecords(ctx, &state, records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(storeETag(ctx, resp.Private, etag)...)
+}
+
+// importRdataChecked checks that the record set named by an import ID with
+// record data holds that value and no other. A bind9_record resource owns
+// every value of its set, so a value shared with other resources is
+// imported as a bind9_rrset_entry instead.
+func importRdataChecked(values map[string]string, records []Record, diags *diag.Diagnostics) bool {
+	zone, name, recordType, rdata := values["zone"], values["name"], strings.ToUpper(values["type"]), values["rdata"]
+
+	var current []string
+	for _, rec := range records {
+		current = append(current, rec.RData)
+	}
+	if !containsRdata(recordType, current, rdata, rdataCaseSensitiveFor(recordType, false)) {
+		addCodedError(diags, ErrCodeNotFound, "Record Not Found",
+			fmt.Sprintf("The %s record set %s in zone %s has no value %q.", recordType, name, zone, rdata))
+		return false
+	}
+	if len(current) > 1 {
+		addCodedError(diags, ErrCodeConflict, "Record Set Has Other Values",
+			fmt.Sprintf("The %s record set %s in zone %s has %d values. A bind9_record resource owns every value of its set and "+
+				"would remove the others, so import the value with bind9_rrset_entry and the same ID, or import the whole set "+
+				"with the ID %s/%s/%s.", recordType, name, zone, len(current), zone, name, values["type"]))
+		return false
+	}
+	return true
+}
+
+// recordImportID is the import ID format of bind9_record
+var recordImportID = importID{
+	positional: []string{"zone", "name", "type"},
+	optional:   []string{"rdata", "class", "view"},
+}
+
+// recordRdataImportID is the positional import ID format of bind9_record
+// that names one value of the record set. Record data may contain slashes,
+// so it takes the rest of the ID.
+var recordRdataImportID = importID{
+	positional:   []string{"zone", "name", "type", "rdata"},
+	optional:     []string{"class", "view"},
+	trailingRest: true,
 }
 
@@ -0,0 +1,669 @@
+// Record Range Resource
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxRangeRecords is the largest number of records a record range may expand to
+const maxRangeRecords = 65536
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource               = &RecordRangeResource{}
+	_ resource.ResourceWithModifyPlan = &RecordRangeResource{}
+)
+
+// NewRecordRangeResource creates a new record range resource
+func NewRecordRangeResource() resource.Resource {
+	return &RecordRangeResource{}
+}
+
+// RecordRangeResource manages the records a numeric range expands to, like
+// the $GENERATE directive of BIND zone files
+type RecordRangeResource struct {
+	client *Client
+}
+
+// RecordRangeResourceModel describes the resource data model
+type RecordRangeResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Zone          types.String `tfsdk:"zone"`
+	View          types.String `tfsdk:"view"`
+	Start         types.Int64  `tfsdk:"start"`
+	Stop          types.Int64  `tfsdk:"stop"`
+	Step          types.Int64  `tfsdk:"step"`
+	NameTemplate  types.String `tfsdk:"name_template"`
+	Type          types.String `tfsdk:"type"`
+	RDataTemplate types.String `tfsdk:"rdata_template"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+	Class         types.String `tfsdk:"class"`
+	Records       types.Map    `tfsdk:"records"`
+}
+
+// Metadata returns the resource type name
+func (r *RecordRangeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_record_range"
+}
+
+// Schema defines the schema for the resource
+func (r *RecordRangeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the DNS records a numeric range expands to, like $GENERATE in zone files.",
+		MarkdownDescription: `
+Manages the DNS records a numeric range expands to, like the ` + "`$GENERATE`" + ` directive of BIND
+zone files. Each number of the range becomes one record, created as its own record set
+through the API, while Terraform tracks the whole range as one resource.
+
+In the templates, ` + "`$`" + ` stands for the number and ` + "`${offset,width,base}`" + ` formats it.
+Terraform interpolates ` + "`${`" + `, so write the modifier form as ` + "`$${...}`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "bind9_record_range" "hosts" {
+  zone           = "example.com"
+  start          = 1
+  stop           = 254
+  name_template  = "host-$"
+  type           = "A"
+  rdata_template = "10.0.0.$"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Range identifier (zone/type/start-stop/name_template)",
+				Computed:    true,
+			},
+			"zone": schema.StringAttribute{
+				Description: "Zone name (e.g., example.com). Compared case-insensitively, ignoring a trailing dot.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+					zoneRequiresReplace(func() *Client { return r.client }),
+				},
+			},
+			"view": recordViewAttribute(),
+			"start": schema.Int64Attribute{
+				Description: "First number of the range",
+				Required:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"stop": schema.Int64Attribute{
+				Description: "Last number of the range, inclusive",
+				Required:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"step": schema.Int64Attribute{
+				Description: "Increment between numbers of the range. Default: 1",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"name_template": schema.StringAttribute{
+				Description: "Template of the record names, relative to the zone (e.g., host-$)",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Record type of the generated records (A, AAAA, CNAME, DNAME, NS, PTR, TXT)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("A", "AAAA", "CNAME", "DNAME", "NS", "PTR", "TXT"),
+				},
+			},
+			"rdata_template": schema.StringAttribute{
+				Description: "Template of the record data (e.g., 10.0.0.$)",
+				Required:    true,
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live in seconds. Defaults to the provider default_ttl (3600 if unset).",
+				Optional:    true,
+				Computed:    true,
+			},
+			"class": schema.StringAttribute{
+				Description: "Record class (IN, CH, HS). Must match the zone class. Defaults to the provider default_class (IN if unset).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+			"records": schema.MapAttribute{
+				Description: "The generated records, as record data by record name",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *RecordRangeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan applies the provider-level record defaults to ttl and class,
+// and expands the range so the plan lists the generated records
+func (r *RecordRangeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = planView(ctx, r.client, req, &resp.Diagnostics)
+
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	var class types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ttl.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), r.client.defaultTTL)...)
+	}
+	if class.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("class"), r.client.defaultClass)...)
+	}
+
+	var plan RecordRangeResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Zone.IsUnknown() || plan.Start.IsUnknown() || plan.Stop.IsUnknown() || plan.Step.IsUnknown() ||
+		plan.NameTemplate.IsUnknown() || plan.RDataTemplate.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	records, diags := expandRecordRange(&plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the spelling in state of values the server returned equivalently
+	if !req.State.Raw.IsNull() {
+		var state RecordRangeResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		prior := make(map[string]string)
+		if !state.Records.IsNull() && !state.Records.IsUnknown() {
+			resp.Diagnostics.Append(state.Records.ElementsAs(ctx, &prior, false)...)
+		}
+		for name, rdata := range records {
+			if p, ok := prior[name]; ok && rdataEqual(plan.Type.ValueString(), p, rdata, false) {
+				records[name] = p
+			}
+		}
+	}
+
+	recordsMap, diags := types.MapValueFrom(ctx, types.StringType, records)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("records"), recordsMap)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), recordRangeID(&plan))...)
+}
+
+// expandRecordRange returns the records of a range, as record data by name
+func expandRecordRange(model *RecordRangeResourceModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	start, stop, step := model.Start.ValueInt64(), model.Stop.ValueInt64(), model.Step.ValueInt64()
+	if step < 1 {
+		step = 1
+	}
+
+	if stop < start {
+		addCodedAttributeError(&diags, path.Root("stop"), ErrCodeConfigInvalid, "Invalid Record Range",
+			fmt.Sprintf("stop (%d) must not be less than start (%d).", stop, start))
+		return nil, diags
+	}
+	if count := (stop-start)/step + 1; count > maxRangeRecords {
+		addCodedAttributeError(&diags, path.Root("stop"), ErrCodeConfigInvalid, "Record Range Too Large",
+			fmt.Sprintf("The range expands to %d records, more than the limit of %d. Split it into several resources.", count, maxRangeRecords))
+		return nil, diags
+	}
+
+	records := make(map[string]string)
+	for n := start; n <= stop; n += step {
+		name, err := expandGenerateTemplate(model.NameTemplate.ValueString(), n)
+		if err != nil {
+			addCodedAttributeError(&diags, path.Root("name_template"), ErrCodeConfigInvalid, "Invalid Name Template", err.Error())
+			return nil, diags
+		}
+		rdata, err := expandGenerateTemplate(model.RDataTemplate.ValueString(), n)
+		if err != nil {
+			addCodedAttributeError(&diags, path.Root("rdata_template"), ErrCodeConfigInvalid, "Invalid Record Data Template", err.Error())
+			return nil, diags
+		}
+		if _, ok := records[name]; ok {
+			addCodedAttributeError(&diags, path.Root("name_template"), ErrCodeConfigInvalid, "Duplicate Record Name",
+				fmt.Sprintf("The name template gives the name %s for more than one number of the range. Include $ in the template.", name))
+			return nil, diags
+		}
+		records[name] = rdata
+	}
+	return records, diags
+}
+
+// expandGenerateTemplate substitutes n into a $GENERATE template: $ stands
+// for n, ${offset,width,base} for n plus offset, zero-padded to width and
+// written in base d (decimal), o (octal), x or X (hexadecimal), or n or N
+// (reversed nibbles separated by dots, as in ip6.arpa names). \$ is a
+// literal dollar sign.
+func expandGenerateTemplate(template string, n int64) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == '\\' && i+1 < len(template) && template[i+1] == '$':
+			b.WriteByte('$')
+			i++
+		case c != '$':
+			b.WriteByte(c)
+		case i+1 < len(template) && template[i+1] == '{':
+			end := strings.IndexByte(template[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated ${ in template %q", template)
+			}
+			formatted, err := formatGenerateModifier(template[i+2:i+end], n)
+			if err != nil {
+				return "", fmt.Errorf("template %q: %w", template, err)
+			}
+			b.WriteString(formatted)
+			i += end
+		default:
+			b.WriteString(strconv.FormatInt(n, 10))
+		}
+	}
+	return b.String(), nil
+}
+
+// formatGenerateModifier formats n with the offset,width,base modifier of a
+// $GENERATE template
+func formatGenerateModifier(modifier string, n int64) (string, error) {
+	parts := strings.Split(modifier, ",")
+	if len(parts) > 3 {
+		return "", fmt.Errorf("invalid modifier ${%s}, expected ${offset,width,base}", modifier)
+	}
+
+	offset, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid offset in ${%s}", modifier)
+	}
+	width := int64(0)
+	if len(parts) > 1 {
+		width, err = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+		if err != nil || width < 0 {
+			return "", fmt.Errorf("invalid width in ${%s}", modifier)
+		}
+	}
+	base := "d"
+	if len(parts) > 2 {
+		base = strings.TrimSpace(parts[2])
+	}
+
+	v := n + offset
+	if v < 0 {
+		return "", fmt.Errorf("${%s} gives the negative value %d", modifier, v)
+	}
+
+	switch base {
+	case "d":
+		return fmt.Sprintf("%0*d", width, v), nil
+	case "o":
+		return fmt.Sprintf("%0*o", width, v), nil
+	case "x":
+		return fmt.Sprintf("%0*x", width, v), nil
+	case "X":
+		return fmt.Sprintf("%0*X", width, v), nil
+	case "n", "N":
+		digits := fmt.Sprintf("%0*x", width, v)
+		if base == "N" {
+			digits = strings.ToUpper(digits)
+		}
+		nibbles := make([]string, len(digits))
+		for i := range digits {
+			nibbles[len(digits)-1-i] = digits[i : i+1]
+		}
+		return strings.Join(nibbles, "."), nil
+	}
+	return "", fmt.Errorf("invalid base %q in ${%s}, expected d, o, x, X, n or N", base, modifier)
+}
+
+// recordRangeID returns the identifier of a record range
+func recordRangeID(model *RecordRangeResourceModel) string {
+	return fmt.Sprintf("%s/%s/%d-%d/%s", zoneID(model.View.ValueString(), model.Zone.ValueString()), model.Type.ValueString(),
+		model.Start.ValueInt64(), model.Stop.ValueInt64(), model.NameTemplate.ValueString())
+}
+
+// rangeRecords returns the records of a model's records attribute
+func rangeRecords(ctx context.Context, model *RecordRangeResourceModel, diags *diag.Diagnostics) map[string]string {
+	records := make(map[string]string)
+	if !model.Records.IsNull() && !model.Records.IsUnknown() {
+		diags.Append(model.Records.ElementsAs(ctx, &records, false)...)
+	}
+	return records
+}
+
+// sortedNames returns the names of a record map in order, so records are
+// written in a predictable order
+func sortedNames(records map[string]string) []string {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Create creates the resource
+func (r *RecordRangeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_record_range.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan RecordRangeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	records := rangeRecords(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating record range", map[string]any{
+		"zone":    plan.Zone.ValueString(),
+		"type":    plan.Type.ValueString(),
+		"records": len(records),
+	})
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	created := r.applyChanges(ctx, &plan, nil, records, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Keep the records created before the error in state, so the next
+		// apply completes the range instead of adding them again
+		if len(created) > 0 {
+			r.setRecords(ctx, &plan, created, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		}
+		return
+	}
+
+	plan.ID = types.StringValue(recordRangeID(&plan))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// applyChanges removes the records of old that are not in new and adds the
+// records of new that are not in old. With ttlChanged set, the records in
+// both are removed and added again with the TTL of model. It returns the
+// records that exist afterwards, which on error are the records of old that
+// were not removed plus the records of new that were added.
+func (r *RecordRangeResource) applyChanges(ctx context.Context, model *RecordRangeResourceModel, old, new map[string]string, ttlChanged bool, diags *diag.Diagnostics) map[string]string {
+	zone := model.Zone.ValueString()
+	recordType := model.Type.ValueString()
+	class := model.Class.ValueString()
+
+	var removals, additions []string
+	for _, name := range sortedNames(old) {
+		if rdata, ok := new[name]; !ok || ttlChanged || !rdataEqual(recordType, old[name], rdata, false) {
+			removals = append(removals, name)
+		}
+	}
+	for _, name := range sortedNames(new) {
+		if rdata, ok := old[name]; !ok || ttlChanged || !rdataEqual(recordType, rdata, new[name], false) {
+			additions = append(additions, name)
+		}
+	}
+
+	result := make(map[string]string, len(new))
+	for name, rdata := range old {
+		result[name] = rdata
+	}
+
+	useTransaction := useRecordTransactions(r.client, diags)
+	if diags.HasError() {
+		return result
+	}
+
+	if useTransaction {
+		var changes []RecordChange
+		for _, name := range removals {
+			changes = append(changes, rangeRecordChange(recordChangeDelete, model, name, old[name]))
+		}
+		for _, name := range additions {
+			changes = append(changes, rangeRecordChange(recordChangeCreate, model, name, new[name]))
+		}
+		if err := r.client.transactions.submit(ctx, zone, changes); err != nil {
+			addRecordAPIError(diags, "Error Applying Record Range", "Could not apply the records of the range", err)
+			return result
+		}
+		return new
+	}
+
+	// The records of the range are separate record sets, so no ETag guards
+	// the changes
+	rrCtx := withETag(ctx, nil)
+	for _, name := range removals {
+		err := r.client.DeleteRecord(rrCtx, zone, name, recordType, class, old[name])
+		if err != nil && !isNotFound(err) {
+			addRecordAPIError(diags, "Error Applying Record Range", fmt.Sprintf("Could not delete record %s %s", name, recordType), err)
+			return result
+		}
+		delete(result, name)
+	}
+	for _, name := range additions {
+		createReq := &RecordCreateRequest{
+			RecordType:  recordType,
+			Name:        name,
+			TTL:         int(model.TTL.ValueInt64()),
+			RecordClass: class,
+			Data:        buildRecordData(recordType, new[name]),
+			RData:       new[name],
+		}
+		if _, err := r.client.CreateRecord(rrCtx, zone, createReq); err != nil {
+			addRecordAPIError(diags, "Error Applying Record Range", fmt.Sprintf("Could not create record %s %s", name, recordType), err)
+			return result
+		}
+		result[name] = new[name]
+	}
+	return result
+}
+
+// rangeRecordChange builds the transaction change adding or removing one
+// record of a range
+func rangeRecordChange(action string, model *RecordRangeResourceModel, name, rdata string) RecordChange {
+	change := RecordChange{
+		Action:      action,
+		RecordType:  model.Type.ValueString(),
+		Name:        name,
+		RecordClass: model.Class.ValueString(),
+		RData:       rdata,
+	}
+	if action == recordChangeCreate {
+		change.TTL = int(model.TTL.ValueInt64())
+		change.Data = buildRecordData(model.Type.ValueString(), rdata)
+	}
+	return change
+}
+
+// setRecords stores records in the model's records attribute
+func (r *RecordRangeResource) setRecords(ctx context.Context, model *RecordRangeResourceModel, records map[string]string, diags *diag.Diagnostics) {
+	recordsMap, d := types.MapValueFrom(ctx, types.StringType, records)
+	diags.Append(d...)
+	model.Records = recordsMap
+	model.ID = types.StringValue(recordRangeID(model))
+}
+
+// Read refreshes the Terraform state. Records of the range that no longer
+// exist are dropped from records, so the next plan adds them again.
+func (r *RecordRangeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_record_range.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var state RecordRangeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	resp.Diagnostics.Append(r.client.restoreSquelch(ctx, resp.Private)...)
+
+	expected := rangeRecords(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read all records of the type in the zone at once rather than one
+	// request per record
+	zone := state.Zone.ValueString()
+	existing, err := r.client.ReadRecords(withETag(ctx, nil), zone, state.Type.ValueString(), "", state.Class.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Reading Record Range", "Could not read the records of zone "+zone, err)
+		return
+	}
+
+	byName := make(map[string][]string)
+	for _, rec := range existing {
+		key := strings.ToLower(relativeName(zone, ownerName(zone, rec.Name)))
+		byName[key] = append(byName[key], rec.RData)
+	}
+
+	found := make(map[string]string, len(expected))
+	for name, rdata := range expected {
+		key := strings.ToLower(relativeName(zone, ownerName(zone, name)))
+		if containsRdata(state.Type.ValueString(), byName[key], rdata, false) {
+			found[name] = rdata
+		}
+	}
+
+	if len(found) == 0 && len(expected) > 0 {
+		tflog.Debug(ctx, "Record range no longer exists", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.setRecords(ctx, &state, found, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource, adding and removing records as the range
+// changes, and adding every record again when the TTL changes
+func (r *RecordRangeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_record_range.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan, state RecordRangeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	old := rangeRecords(ctx, &state, &resp.Diagnostics)
+	records := rangeRecords(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	result := r.applyChanges(ctx, &plan, old, records, !plan.TTL.Equal(state.TTL), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Record what exists now, so the next apply continues from there
+		r.setRecords(ctx, &plan, result, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	plan.ID = types.StringValue(recordRangeID(&plan))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource
+func (r *RecordRangeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_record_range.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var state RecordRangeResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	records := rangeRecords(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client.beginZoneChange(ctx, state.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, state.Zone.ValueString())
+
+	remaining := r.applyChanges(ctx, &state, records, nil, false, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() && len(remaining) > 0 {
+		// Keep the records that could not be deleted in state
+		r.setRecords(ctx, &state, remaining, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
@@ -0,0 +1,687 @@
+// Records Batch Resource
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// batchChunkSize is the largest number of record changes sent in one
+// transaction request
+const batchChunkSize = 500
+
+// batchConcurrency is the number of record requests in flight at once when
+// the server has no transaction endpoint. The provider's max_concurrent_requests
+// still applies.
+const batchConcurrency = 8
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource               = &RecordsBatchResource{}
+	_ resource.ResourceWithModifyPlan = &RecordsBatchResource{}
+)
+
+// NewRecordsBatchResource creates a new records batch resource
+func NewRecordsBatchResource() resource.Resource {
+	return &RecordsBatchResource{}
+}
+
+// RecordsBatchResource manages many records of a zone as one resource,
+// applying their changes in bulk
+type RecordsBatchResource struct {
+	client *Client
+}
+
+// RecordsBatchResourceModel describes the resource data model
+type RecordsBatchResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Zone    types.String `tfsdk:"zone"`
+	View    types.String `tfsdk:"view"`
+	TTL     types.Int64  `tfsdk:"ttl"`
+	Class   types.String `tfsdk:"class"`
+	Records types.Set    `tfsdk:"records"`
+}
+
+// BatchRecordModel describes one record of a batch
+type BatchRecordModel struct {
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	RData types.String `tfsdk:"rdata"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+}
+
+// batchRecordAttrTypes are the attribute types of a batch record object
+var batchRecordAttrTypes = map[string]attr.Type{
+	"name":  types.StringType,
+	"type":  types.StringType,
+	"rdata": types.StringType,
+	"ttl":   types.Int64Type,
+}
+
+// batchRecord is a record of a batch with its effective TTL
+type batchRecord struct {
+	model BatchRecordModel
+	ttl   int64
+}
+
+// key returns the record set of a batch record in a zone
+func (b batchRecord) key(zone string) string {
+	return batchRRsetKey(zone, b.model.Name.ValueString(), b.model.Type.ValueString())
+}
+
+// batchRRsetKey identifies a record set in a zone, however its name is spelled
+func batchRRsetKey(zone, name, recordType string) string {
+	return strings.ToLower(relativeName(zone, ownerName(zone, name))) + "/" + strings.ToUpper(recordType)
+}
+
+// Metadata returns the resource type name
+func (r *RecordsBatchResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_records_batch"
+}
+
+// Schema defines the schema for the resource
+func (r *RecordsBatchResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages many DNS records of a zone as one resource, applying their changes in bulk.",
+		MarkdownDescription: `
+Manages many DNS records of a zone as one resource. Changes are sent through the API's
+transaction endpoint in chunks of ` + fmt.Sprint(batchChunkSize) + ` records, or, if the server lacks it, as
+parallel requests. Use it to bring hundreds of records of a zone under Terraform without
+one resource, and one refresh request, per record set.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "bind9_records_batch" "hosts" {
+  zone = "example.com"
+  ttl  = 300
+
+  records = [
+    { name = "web1", type = "A", rdata = "192.0.2.11" },
+    { name = "web2", type = "A", rdata = "192.0.2.12" },
+    { name = "www", type = "CNAME", rdata = "web1.example.com.", ttl = 3600 },
+  ]
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Batch identifier (zone/batch)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateUnlessChanged(path.Root("zone")),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "Zone name (e.g., example.com). Compared case-insensitively, ignoring a trailing dot.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+					zoneRequiresReplace(func() *Client { return r.client }),
+				},
+			},
+			"view": recordViewAttribute(),
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live in seconds of records that do not set their own. Defaults to the provider default_ttl (3600 if unset).",
+				Optional:    true,
+				Computed:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"class": schema.StringAttribute{
+				Description: "Record class (IN, CH, HS). Must match the zone class. Defaults to the provider default_class (IN if unset).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Description: "The records of the batch. Each is one value of a record set; records with the same name and type form one set and must share a TTL.",
+				Required:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Record name relative to the zone (e.g., www, @ for the zone apex)",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record type (A, AAAA, CNAME, MX, TXT, etc.)",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									"A", "AAAA", "CNAME", "MX", "TXT", "NS", "PTR",
+									"SRV", "CAA", "NAPTR", "HTTPS", "SVCB", "TLSA", "SSHFP",
+									"DNSKEY", "DS", "CDNSKEY", "CDS", "LOC", "HINFO", "RP", "DNAME", "URI",
+									"CERT", "SMIMEA", "OPENPGPKEY", "CSYNC", "ZONEMD",
+								),
+							},
+						},
+						"rdata": schema.StringAttribute{
+							Description: "Record data in presentation format, as for bind9_record records",
+							Required:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live in seconds. Defaults to the ttl of the batch.",
+							Optional:    true,
+							Validators:  []validator.Int64{int64validator.AtLeast(0)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *RecordsBatchResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan applies the provider-level record defaults to ttl and class,
+// and checks that the records of each record set share a TTL
+func (r *RecordsBatchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = planView(ctx, r.client, req, &resp.Diagnostics)
+
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	var class types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ttl.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), r.client.defaultTTL)...)
+	}
+	if class.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("class"), r.client.defaultClass)...)
+	}
+
+	var plan RecordsBatchResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Zone.IsUnknown() || plan.TTL.IsUnknown() || plan.Records.IsUnknown() {
+		return
+	}
+
+	records := batchRecords(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	zone := plan.Zone.ValueString()
+	ttls := make(map[string]int64)
+	for _, rec := range records {
+		if rec.model.Name.IsUnknown() || rec.model.Type.IsUnknown() || rec.model.TTL.IsUnknown() {
+			continue
+		}
+		key := rec.key(zone)
+		if other, ok := ttls[key]; ok && other != rec.ttl {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("records"), ErrCodeConfigInvalid, "Inconsistent TTLs in Record Set",
+				fmt.Sprintf("The %s records named %s have the TTLs %d and %d. RFC 2181 requires the records of a set to share one TTL.",
+					rec.model.Type.ValueString(), rec.model.Name.ValueString(), other, rec.ttl))
+			return
+		}
+		ttls[key] = rec.ttl
+	}
+}
+
+// batchRecords returns the records of a model with their effective TTLs
+func batchRecords(ctx context.Context, model *RecordsBatchResourceModel, diags *diag.Diagnostics) []batchRecord {
+	if model.Records.IsNull() || model.Records.IsUnknown() {
+		return nil
+	}
+
+	var models []BatchRecordModel
+	diags.Append(model.Records.ElementsAs(ctx, &models, false)...)
+
+	records := make([]batchRecord, 0, len(models))
+	for _, m := range models {
+		ttl := model.TTL.ValueInt64()
+		if !m.TTL.IsNull() && !m.TTL.IsUnknown() {
+			ttl = m.TTL.ValueInt64()
+		}
+		records = append(records, batchRecord{model: m, ttl: ttl})
+	}
+
+	// Apply changes in a predictable order
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i].model, records[j].model
+		if a.Name.ValueString() != b.Name.ValueString() {
+			return a.Name.ValueString() < b.Name.ValueString()
+		}
+		if a.Type.ValueString() != b.Type.ValueString() {
+			return a.Type.ValueString() < b.Type.ValueString()
+		}
+		return a.RData.ValueString() < b.RData.ValueString()
+	})
+	return records
+}
+
+// setBatchRecords stores records in the model's records attribute
+func setBatchRecords(ctx context.Context, model *RecordsBatchResourceModel, records []batchRecord, diags *diag.Diagnostics) {
+	models := make([]BatchRecordModel, 0, len(records))
+	for _, rec := range records {
+		models = append(models, rec.model)
+	}
+	recordsSet, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: batchRecordAttrTypes}, models)
+	diags.Append(d...)
+	model.Records = recordsSet
+	model.ID = types.StringValue(zoneID(model.View.ValueString(), model.Zone.ValueString()) + "/batch")
+}
+
+// containsBatchRecord reports whether records holds a record of the same
+// record set, value and TTL as rec
+func containsBatchRecord(zone string, records []batchRecord, rec batchRecord) bool {
+	for _, other := range records {
+		if other.key(zone) == rec.key(zone) && other.ttl == rec.ttl &&
+			rdataEqual(rec.model.Type.ValueString(), other.model.RData.ValueString(), rec.model.RData.ValueString(), false) {
+			return true
+		}
+	}
+	return false
+}
+
+// batchChange is one record addition or removal of a batch
+type batchChange struct {
+	action string
+	record batchRecord
+}
+
+// recordChange returns the transaction change of a batch change
+func (c batchChange) recordChange(model *RecordsBatchResourceModel) RecordChange {
+	recordType := c.record.model.Type.ValueString()
+	rdata := c.record.model.RData.ValueString()
+	change := RecordChange{
+		Action:      c.action,
+		RecordType:  recordType,
+		Name:        apexName(model.Zone.ValueString(), c.record.model.Name.ValueString()),
+		RecordClass: model.Class.ValueString(),
+		RData:       rdata,
+	}
+	if c.action == recordChangeCreate {
+		change.TTL = int(c.record.ttl)
+		change.Data = buildRecordData(recordType, rdata)
+	}
+	return change
+}
+
+// Create creates the resource
+func (r *RecordsBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_records_batch.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan RecordsBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	records := batchRecords(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating records batch", map[string]any{
+		"zone":    plan.Zone.ValueString(),
+		"records": len(records),
+	})
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	created := r.applyChanges(ctx, &plan, nil, records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Keep the records created before the error in state, so the next
+		// apply completes the batch instead of adding them again
+		if len(created) > 0 {
+			setBatchRecords(ctx, &plan, created, &resp.Diagnostics)
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		}
+		return
+	}
+
+	plan.ID = types.StringValue(zoneID(plan.View.ValueString(), plan.Zone.ValueString()) + "/batch")
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// applyChanges removes the records of old that are not in new and adds the
+// records of new that are not in old. A record whose TTL changed is removed
+// and added again. It returns the records that exist afterwards, which on
+// error are the records of old that were not removed plus the records of
+// new that were added.
+func (r *RecordsBatchResource) applyChanges(ctx context.Context, model *RecordsBatchResourceModel, old, new []batchRecord, diags *diag.Diagnostics) []batchRecord {
+	zone := model.Zone.ValueString()
+
+	var changes []batchChange
+	for _, rec := range old {
+		if !containsBatchRecord(zone, new, rec) {
+			changes = append(changes, batchChange{action: recordChangeDelete, record: rec})
+		}
+	}
+	for _, rec := range new {
+		if !containsBatchRecord(zone, old, rec) {
+			changes = append(changes, batchChange{action: recordChangeCreate, record: rec})
+		}
+	}
+
+	done := make([]bool, len(changes))
+	if len(changes) > 0 {
+		tflog.Debug(ctx, "Applying records batch", map[string]any{"zone": zone, "changes": len(changes)})
+		if !r.applyTransactions(ctx, model, changes, done, diags) && !diags.HasError() {
+			r.applyRequests(ctx, model, changes, done, diags)
+		}
+	}
+
+	var result []batchRecord
+	for _, rec := range old {
+		removed := false
+		for i, change := range changes {
+			if done[i] && change.action == recordChangeDelete && change.record == rec {
+				removed = true
+				break
+			}
+		}
+		if !removed {
+			result = append(result, rec)
+		}
+	}
+	for i, change := range changes {
+		if done[i] && change.action == recordChangeCreate {
+			result = append(result, change.record)
+		}
+	}
+	return result
+}
+
+// applyTransactions applies changes through the transaction endpoint in
+// chunks of batchChunkSize, marking the changes of each committed chunk in
+// done. It returns false, without error, if the server has no transaction
+// endpoint.
+func (r *RecordsBatchResource) applyTransactions(ctx context.Context, model *RecordsBatchResourceModel, changes []batchChange, done []bool, diags *diag.Diagnostics) bool {
+	if r.client.dnsUpdate == nil && !r.client.SupportsFeature(FeatureTransactions) {
+		return false
+	}
+
+	// The records of a batch are separate record sets, so no ETag guards
+	// the changes
+	txCtx := withETag(ctx, nil)
+	zone := model.Zone.ValueString()
+	for start := 0; start < len(changes); start += batchChunkSize {
+		end := start + batchChunkSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+
+		chunk := make([]RecordChange, 0, end-start)
+		for _, change := range changes[start:end] {
+			chunk = append(chunk, change.recordChange(model))
+		}
+		if err := r.client.ApplyRecordTransaction(txCtx, zone, chunk); err != nil {
+			if start == 0 && isUnsupportedOperation(err) && r.client.dnsUpdate == nil {
+				tflog.Debug(ctx, "Record transactions not available, applying records one by one", map[string]any{"error": err.Error()})
+				return false
+			}
+			addRecordAPIError(diags, "Error Applying Records Batch",
+				fmt.Sprintf("Could not apply records %d to %d of the %d changes in zone %s", start+1, end, len(changes), zone), err)
+			return true
+		}
+		for i := start; i < end; i++ {
+			done[i] = true
+		}
+	}
+	return true
+}
+
+// applyRequests applies changes with one request per record, batchConcurrency
+// at a time, marking the changes applied in done. Removals are applied before
+// additions, so a record whose TTL changed is removed before it is added
+// again.
+func (r *RecordsBatchResource) applyRequests(ctx context.Context, model *RecordsBatchResourceModel, changes []batchChange, done []bool, diags *diag.Diagnostics) {
+	rrCtx := withETag(ctx, nil)
+	zone := model.Zone.ValueString()
+	class := model.Class.ValueString()
+
+	var mu sync.Mutex
+	var failures []string
+	var firstErr error
+
+	apply := func(i int) {
+		change := changes[i]
+		recordType := change.record.model.Type.ValueString()
+		name := apexName(zone, change.record.model.Name.ValueString())
+		rdata := change.record.model.RData.ValueString()
+
+		var err error
+		if change.action == recordChangeDelete {
+			err = r.client.DeleteRecord(rrCtx, zone, name, recordType, class, rdata)
+			if err != nil && isNotFound(err) {
+				err = nil
+			}
+		} else {
+			_, err = r.client.CreateRecord(rrCtx, zone, &RecordCreateRequest{
+				RecordType:  recordType,
+				Name:        name,
+				TTL:         int(change.record.ttl),
+				RecordClass: class,
+				Data:        buildRecordData(recordType, rdata),
+				RData:       rdata,
+			})
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			failures = append(failures, fmt.Sprintf("%s %s %s %q", change.action, name, recordType, rdata))
+			return
+		}
+		done[i] = true
+	}
+
+	for _, action := range []string{recordChangeDelete, recordChangeCreate} {
+		work := make(chan int)
+		var wg sync.WaitGroup
+		for w := 0; w < batchConcurrency; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range work {
+					apply(i)
+				}
+			}()
+		}
+		for i, change := range changes {
+			if change.action == action {
+				work <- i
+			}
+		}
+		close(work)
+		wg.Wait()
+
+		if firstErr != nil {
+			sort.Strings(failures)
+			if len(failures) > 10 {
+				failures = append(failures[:10], fmt.Sprintf("and %d more", len(failures)-10))
+			}
+			addRecordAPIError(diags, "Error Applying Records Batch",
+				fmt.Sprintf("Could not apply these record changes in zone %s: %s", zone, strings.Join(failures, ", ")), firstErr)
+			return
+		}
+	}
+}
+
+// Read refreshes the Terraform state. Records of the batch that no longer
+// exist are dropped from records, so the next plan adds them again.
+func (r *RecordsBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_records_batch.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var state RecordsBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	resp.Diagnostics.Append(r.client.restoreSquelch(ctx, resp.Private)...)
+
+	expected := batchRecords(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Read all records of the zone at once rather than one request per
+	// record set
+	zone := state.Zone.ValueString()
+	existing, err := r.client.ReadRecords(withETag(ctx, nil), zone, "", "", state.Class.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Reading Records Batch", "Could not read the records of zone "+zone, err)
+		return
+	}
+
+	byKey := make(map[string][]Record)
+	for _, rec := range existing {
+		key := batchRRsetKey(zone, rec.Name, rec.Type)
+		byKey[key] = append(byKey[key], rec)
+	}
+
+	var found []batchRecord
+	for _, rec := range expected {
+		for _, server := range byKey[rec.key(zone)] {
+			if rdataEqual(rec.model.Type.ValueString(), server.RData, rec.model.RData.ValueString(), false) {
+				// A TTL changed on the server shows as a diff
+				if int64(server.TTL) != rec.ttl {
+					rec.model.TTL = types.Int64Value(int64(server.TTL))
+				}
+				found = append(found, rec)
+				break
+			}
+		}
+	}
+
+	if len(found) == 0 && len(expected) > 0 {
+		tflog.Debug(ctx, "Records batch no longer exists", map[string]any{"id": state.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setBatchRecords(ctx, &state, found, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource, adding and removing records as the batch
+// changes
+func (r *RecordsBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_records_batch.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan, state RecordsBatchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	old := batchRecords(ctx, &state, &resp.Diagnostics)
+	records := batchRecords(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	result := r.applyChanges(ctx, &plan, old, records, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		// Record what exists now, so the next apply continues from there
+		setBatchRecords(ctx, &plan, result, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	plan.ID = types.StringValue(zoneID(plan.View.ValueString(), plan.Zone.ValueString()) + "/batch")
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource
+func (r *RecordsBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_records_batch.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var state RecordsBatchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	records := batchRecords(ctx, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.client.beginZoneChange(ctx, state.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, state.Zone.ValueString())
+
+	remaining := r.applyChanges(ctx, &state, records, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() && len(remaining) > 0 {
+		// Keep the records that could not be deleted in state
+		setBatchRecords(ctx, &state, remaining, &resp.Diagnostics)
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
@@ -0,0 +1,671 @@
+// Reverse Zone Resource
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                   = &ReverseZoneResource{}
+	_ resource.ResourceWithImportState    = &ReverseZoneResource{}
+	_ resource.ResourceWithModifyPlan     = &ReverseZoneResource{}
+	_ resource.ResourceWithValidateConfig = &ReverseZoneResource{}
+)
+
+// NewReverseZoneResource creates a new reverse zone resource
+func NewReverseZoneResource() resource.Resource {
+	return &ReverseZoneResource{}
+}
+
+// ReverseZoneResource manages the reverse zone of an IP network, named
+// from its CIDR
+type ReverseZoneResource struct {
+	client *Client
+}
+
+// ReverseZoneResourceModel describes the resource data model
+type ReverseZoneResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	CIDR          types.String `tfsdk:"cidr"`
+	Name          types.String `tfsdk:"name"`
+	View          types.String `tfsdk:"view"`
+	File          types.String `tfsdk:"file"`
+	Nameservers   types.List   `tfsdk:"nameservers"`
+	SOAMname      types.String `tfsdk:"soa_mname"`
+	SOARname      types.String `tfsdk:"soa_rname"`
+	SOARefresh    types.Int64  `tfsdk:"soa_refresh"`
+	SOARetry      types.Int64  `tfsdk:"soa_retry"`
+	SOAExpire     types.Int64  `tfsdk:"soa_expire"`
+	SOAMinimum    types.Int64  `tfsdk:"soa_minimum"`
+	DefaultTTL    types.Int64  `tfsdk:"default_ttl"`
+	AllowTransfer types.List   `tfsdk:"allow_transfer"`
+	AllowUpdate   types.List   `tfsdk:"allow_update"`
+	AllowQuery    types.List   `tfsdk:"allow_query"`
+	DeleteFile    types.Bool   `tfsdk:"delete_file_on_destroy"`
+	ForceDestroy  types.Bool   `tfsdk:"force_destroy"`
+	Serial        types.Int64  `tfsdk:"serial"`
+	Loaded        types.Bool   `tfsdk:"loaded"`
+}
+
+// soa returns the SOA attributes of the model
+func (m *ReverseZoneResourceModel) soa() zoneSOAModel {
+	return zoneSOAModel{&m.SOAMname, &m.SOARname, &m.SOARefresh, &m.SOARetry, &m.SOAExpire, &m.SOAMinimum}
+}
+
+// Metadata returns the resource type name
+func (r *ReverseZoneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reverse_zone"
+}
+
+// Schema defines the schema for the resource
+func (r *ReverseZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the reverse zone of an IP network on BIND9 server.",
+		MarkdownDescription: `
+Manages the reverse (in-addr.arpa or ip6.arpa) master zone of an IP network on a BIND9 server.
+The zone name is computed from the network's CIDR: ` + "`10.20.0.0/16`" + ` becomes ` + "`20.10.in-addr.arpa`" + `,
+and IPv4 networks longer than /24 become RFC 2317 classless zones such as ` + "`0/26.2.0.192.in-addr.arpa`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "bind9_reverse_zone" "servers" {
+  cidr        = "10.20.0.0/16"
+  nameservers = ["ns1.example.com", "ns2.example.com"]
+}
+
+resource "bind9_ptr_record" "web" {
+  zone       = bind9_reverse_zone.servers.name
+  ip_address = "10.20.1.10"
+  ptrdname   = "web.example.com"
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Zone identifier (same as name, prefixed with the view if set)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cidr": schema.StringAttribute{
+				Description: "IPv4 or IPv6 network of the zone in CIDR notation (e.g., 10.20.0.0/16). IPv4 prefixes must be /8, /16, /24 or longer, IPv6 prefixes a multiple of 4.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Reverse zone name computed from cidr (e.g., 20.10.in-addr.arpa)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"view": schema.StringAttribute{
+				Description: "View the zone is placed in. Default: the server's default view",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"file": schema.StringAttribute{
+				Description: "Zone file path (auto-generated if not specified)",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"nameservers": schema.ListAttribute{
+				Description: "Fully qualified names of the zone's nameservers (e.g., ns1.example.com)",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"soa_mname": schema.StringAttribute{
+				Description: "Primary nameserver for SOA record. Default: the first of nameservers",
+				Optional:    true,
+				Computed:    true,
+			},
+			"soa_rname": schema.StringAttribute{
+				Description: "Responsible person email for SOA (use . instead of @). Default: hostmaster in the domain of the first nameserver",
+				Optional:    true,
+				Computed:    true,
+			},
+			"soa_refresh": schema.Int64Attribute{
+				Description: "SOA refresh interval in seconds",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(86400),
+			},
+			"soa_retry": schema.Int64Attribute{
+				Description: "SOA retry interval in seconds",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(7200),
+			},
+			"soa_expire": schema.Int64Attribute{
+				Description: "SOA expire time in seconds",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3600000),
+			},
+			"soa_minimum": schema.Int64Attribute{
+				Description: "SOA minimum/negative TTL in seconds",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3600),
+			},
+			"default_ttl": schema.Int64Attribute{
+				Description: "Default TTL for records",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(3600),
+			},
+			"allow_transfer": schema.ListAttribute{
+				Description: "ACL for zone transfers",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allow_update": schema.ListAttribute{
+				Description: "ACL for dynamic updates",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"allow_query": schema.ListAttribute{
+				Description: "ACL for queries",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"delete_file_on_destroy": schema.BoolAttribute{
+				Description: "Delete zone file when zone is destroyed",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Destroy the zone even if it holds records not managed by Terraform. Default: false",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"serial": schema.Int64Attribute{
+				Description: "Zone serial number after the zone was created or its SOA last changed through this resource. " +
+					"Record changes are not reflected; use the bind9_zone data source for the current serial",
+				Computed: true,
+			},
+			"loaded": schema.BoolAttribute{
+				Description: "Whether the zone is loaded",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *ReverseZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ValidateConfig checks that cidr is a network a reverse zone can cover
+func (r *ReverseZoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var cidr types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("cidr"), &cidr)...)
+	if resp.Diagnostics.HasError() || !isKnownString(cidr) {
+		return
+	}
+	if _, err := reverseZoneName(cidr.ValueString()); err != nil {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("cidr"), ErrCodeConfigInvalid, "Invalid Reverse Zone Network", err.Error())
+	}
+}
+
+// ModifyPlan computes the zone name from cidr and the SOA names from the
+// nameservers
+func (r *ReverseZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured, other
+	// than clearing the replacement mark of an earlier plan
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		markZoneReplacement(ctx, req, resp)
+		return
+	}
+	markZoneReplacement(ctx, req, resp, "cidr", "view")
+
+	var plan ReverseZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if isKnownString(plan.View) && !r.client.SupportsFeature(FeatureViews) {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("view"), ErrCodeFeatureUnsupported, "Views Not Supported",
+			fmt.Sprintf("The BIND9 REST API server (version %s) does not manage zones in views. Remove view to use the default view.",
+				r.client.ServerVersion()))
+	}
+
+	if isKnownString(plan.CIDR) && !plan.View.IsUnknown() {
+		name, err := reverseZoneName(plan.CIDR.ValueString())
+		if err != nil {
+			// Reported by ValidateConfig
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("name"), name)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), zoneID(plan.View.ValueString(), name))...)
+	}
+
+	r.planSOANames(ctx, req, resp, &plan)
+	planZoneSerial(ctx, req, resp, "soa_mname", "soa_rname", "soa_refresh", "soa_retry", "soa_expire", "soa_minimum")
+}
+
+// planSOANames defaults the SOA names to the first nameserver
+func (r *ReverseZoneResource) planSOANames(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan *ReverseZoneResourceModel) {
+	var mname, rname types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("soa_mname"), &mname)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("soa_rname"), &rname)...)
+	if resp.Diagnostics.HasError() || plan.Nameservers.IsUnknown() || len(plan.Nameservers.Elements()) == 0 {
+		return
+	}
+	first, ok := plan.Nameservers.Elements()[0].(types.String)
+	if !ok || first.IsUnknown() {
+		return
+	}
+	primary := strings.TrimSuffix(first.ValueString(), ".")
+	if mname.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("soa_mname"), primary)...)
+	}
+	if rname.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("soa_rname"), defaultSOARname(primary))...)
+	}
+}
+
+// reverseZoneName returns the reverse zone name of a network in CIDR
+// notation. IPv4 networks on an octet boundary and IPv6 networks on a
+// nibble boundary get a zone of their own; IPv4 networks longer than /24
+// get an RFC 2317 classless zone named start/prefix.
+func reverseZoneName(cidr string) (string, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return "", fmt.Errorf("%q is not a network in CIDR notation (e.g., 10.20.0.0/16)", cidr)
+	}
+	if prefix != prefix.Masked() {
+		return "", fmt.Errorf("%q has host bits set. Use the network address, %s.", cidr, prefix.Masked())
+	}
+	bits := prefix.Bits()
+
+	if prefix.Addr().Is4() {
+		octets := prefix.Addr().As4()
+		switch {
+		case bits == 0:
+			return "", fmt.Errorf("%q covers the whole IPv4 address space; use a /8 or longer prefix", cidr)
+		case bits > 24:
+			return fmt.Sprintf("%d/%d.%d.%d.%d.in-addr.arpa", octets[3], bits, octets[2], octets[1], octets[0]), nil
+		case bits%8 != 0:
+			return "", fmt.Errorf("%q does not end on an octet boundary. Reverse zones of IPv4 networks shorter than /24 "+
+				"must be /8 or /16; split the network into /%d networks with one zone each.", cidr, (bits/8+1)*8)
+		}
+		labels := make([]string, 0, 6)
+		for i := bits/8 - 1; i >= 0; i-- {
+			labels = append(labels, fmt.Sprint(octets[i]))
+		}
+		return strings.Join(append(labels, "in-addr", "arpa"), "."), nil
+	}
+
+	switch {
+	case bits == 0 || bits == 128:
+		return "", fmt.Errorf("%q is not a network a reverse zone can cover; use a prefix from /4 to /124", cidr)
+	case bits%4 != 0:
+		return "", fmt.Errorf("%q does not end on a nibble boundary. Reverse zones of IPv6 networks need a prefix length that is a multiple of 4, such as /%d.",
+			cidr, bits/4*4+4)
+	}
+	addr := prefix.Addr().As16()
+	labels := make([]string, 0, bits/4+2)
+	for i := bits/4 - 1; i >= 0; i-- {
+		b := addr[i/2]
+		if i%2 == 0 {
+			b >>= 4
+		}
+		labels = append(labels, fmt.Sprintf("%x", b&0xf))
+	}
+	return strings.Join(append(labels, "ip6", "arpa"), "."), nil
+}
+
+// defaultSOARname returns the hostmaster mailbox in the domain of a
+// nameserver
+func defaultSOARname(nameserver string) string {
+	if _, domain, ok := strings.Cut(nameserver, "."); ok && domain != "" {
+		return "hostmaster." + domain
+	}
+	return "hostmaster." + nameserver
+}
+
+// Create creates the resource
+func (r *ReverseZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_reverse_zone.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan ReverseZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The network was not known at plan time
+	name, err := reverseZoneName(plan.CIDR.ValueString())
+	if err != nil {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("cidr"), ErrCodeConfigInvalid, "Invalid Reverse Zone Network", err.Error())
+		return
+	}
+	plan.Name = types.StringValue(name)
+
+	tflog.Debug(ctx, "Creating reverse zone", map[string]any{"cidr": plan.CIDR.ValueString(), "name": name, "view": plan.View.ValueString()})
+	ctx = withView(ctx, plan.View.ValueString())
+
+	var nameservers []string
+	resp.Diagnostics.Append(plan.Nameservers.ElementsAs(ctx, &nameservers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.SOAMname.IsUnknown() {
+		plan.SOAMname = types.StringValue(strings.TrimSuffix(nameservers[0], "."))
+	}
+	if plan.SOARname.IsUnknown() {
+		plan.SOARname = types.StringValue(defaultSOARname(strings.TrimSuffix(nameservers[0], ".")))
+	}
+
+	createReq := &ZoneCreateRequest{
+		Name:        name,
+		View:        plan.View.ValueString(),
+		Type:        "master",
+		File:        plan.File.ValueString(),
+		SOAMname:    plan.SOAMname.ValueString(),
+		SOARname:    plan.SOARname.ValueString(),
+		SOARefresh:  int(plan.SOARefresh.ValueInt64()),
+		SOARetry:    int(plan.SOARetry.ValueInt64()),
+		SOAExpire:   int(plan.SOAExpire.ValueInt64()),
+		SOAMinimum:  int(plan.SOAMinimum.ValueInt64()),
+		DefaultTTL:  int(plan.DefaultTTL.ValueInt64()),
+		Nameservers: nameservers,
+	}
+
+	options, diags := reverseZoneOptions(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	createReq.Options = options
+
+	zone, err := r.client.CreateZone(ctx, createReq)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Reverse Zone", "Could not create zone "+name, err)
+		return
+	}
+
+	plan.ID = types.StringValue(zoneID(plan.View.ValueString(), zone.Name))
+	setReverseZoneComputed(&plan, zone)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// reverseZoneOptions returns the ACL options of a reverse zone, or nil if
+// none are set
+func reverseZoneOptions(ctx context.Context, plan *ReverseZoneResourceModel) (*ZoneOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	options := &ZoneOptions{}
+	for _, acl := range []struct {
+		list   types.List
+		target *[]string
+	}{
+		{plan.AllowTransfer, &options.AllowTransfer},
+		{plan.AllowUpdate, &options.AllowUpdate},
+		{plan.AllowQuery, &options.AllowQuery},
+	} {
+		if !acl.list.IsNull() {
+			diags.Append(acl.list.ElementsAs(ctx, acl.target, false)...)
+		}
+	}
+	if plan.AllowTransfer.IsNull() && plan.AllowUpdate.IsNull() && plan.AllowQuery.IsNull() {
+		return nil, diags
+	}
+	return options, diags
+}
+
+// setReverseZoneComputed sets the attributes of a reverse zone read from
+// the server. A known serial is kept; see planZoneSerial.
+func setReverseZoneComputed(model *ReverseZoneResourceModel, zone *Zone) {
+	if model.Serial.IsUnknown() || model.Serial.IsNull() {
+		model.Serial = types.Int64Value(zone.Serial)
+	}
+	model.Loaded = types.BoolValue(zone.Loaded)
+	if zone.File != "" {
+		model.File = types.StringValue(zone.File)
+	} else if model.File.IsUnknown() {
+		model.File = types.StringNull()
+	}
+}
+
+// Read refreshes the Terraform state
+func (r *ReverseZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_reverse_zone.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var state ReverseZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported zones only have the network
+	if state.Name.IsNull() {
+		name, err := reverseZoneName(state.CIDR.ValueString())
+		if err != nil {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("cidr"), ErrCodeConfigInvalid, "Invalid Reverse Zone Network", err.Error())
+			return
+		}
+		state.Name = types.StringValue(name)
+		state.ID = types.StringValue(zoneID(state.View.ValueString(), name))
+	}
+
+	tflog.Debug(ctx, "Reading reverse zone", map[string]any{"name": state.Name.ValueString(), "view": state.View.ValueString()})
+	ctx = withView(ctx, state.View.ValueString())
+
+	zone, err := r.client.GetZone(ctx, state.Name.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Reverse Zone", "Could not read zone "+state.Name.ValueString(), err)
+		return
+	}
+
+	setReverseZoneComputed(&state, zone)
+	checkLeftoverFreeze(ctx, r.client, zone, &resp.Diagnostics)
+	if zone.Options != nil {
+		for _, acl := range []struct {
+			values []string
+			target *types.List
+		}{
+			{zone.Options.AllowTransfer, &state.AllowTransfer},
+			{zone.Options.AllowUpdate, &state.AllowUpdate},
+			{zone.Options.AllowQuery, &state.AllowQuery},
+		} {
+			// An ACL left unset stays unset while the server reports none
+			if len(acl.values) == 0 && acl.target.IsNull() {
+				continue
+			}
+			list, diags := types.ListValueFrom(ctx, types.StringType, acl.values)
+			resp.Diagnostics.Append(diags...)
+			*acl.target = list
+		}
+	}
+	readZoneSOA(ctx, r.client, state.Name.ValueString(), state.soa(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update applies SOA and ACL changes in place. The nameservers are only
+// used when the zone is created.
+func (r *ReverseZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_reverse_zone.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan, state ReverseZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating reverse zone", map[string]any{"name": plan.Name.ValueString(), "view": plan.View.ValueString()})
+	ctx = withView(ctx, plan.View.ValueString())
+
+	update := &ZoneUpdateRequest{}
+	changed := false
+	for _, acl := range []struct {
+		plan, state types.List
+		target      **[]string
+	}{
+		{plan.AllowTransfer, state.AllowTransfer, &update.AllowTransfer},
+		{plan.AllowUpdate, state.AllowUpdate, &update.AllowUpdate},
+		{plan.AllowQuery, state.AllowQuery, &update.AllowQuery},
+	} {
+		if acl.plan.Equal(acl.state) {
+			continue
+		}
+		var values []string
+		if !acl.plan.IsNull() {
+			resp.Diagnostics.Append(acl.plan.ElementsAs(ctx, &values, false)...)
+		}
+		// Removing an ACL from the configuration clears it on the server
+		*acl.target = optionList(values)
+		changed = true
+	}
+	if soaUpdate(plan.soa(), state.soa(), update) {
+		changed = true
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if changed {
+		if _, err := r.client.UpdateZone(ctx, plan.Name.ValueString(), update); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Updating Reverse Zone", "Could not update zone options", err)
+			return
+		}
+	}
+
+	zone, err := r.client.GetZone(ctx, plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Reverse Zone", "Could not read zone after update", err)
+		return
+	}
+	setReverseZoneComputed(&plan, zone)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource
+func (r *ReverseZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_reverse_zone.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var state ReverseZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting reverse zone", map[string]any{"name": state.Name.ValueString(), "view": state.View.ValueString()})
+	ctx = withView(ctx, state.View.ValueString())
+
+	// The records of a replaced zone stay for the new one
+	if !state.ForceDestroy.ValueBool() && !zoneReplaced(ctx, req.Private) {
+		zone := state.Name.ValueString()
+		own := map[string]bool{
+			zoneRecordKey(zone, "@", "SOA"): true,
+			zoneRecordKey(zone, "@", "NS"):  true,
+		}
+		checkUnmanagedRecords(ctx, r.client, zone, own, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	deleteFile := !state.DeleteFile.IsNull() && state.DeleteFile.ValueBool()
+	if err := r.client.DeleteZone(ctx, state.Name.ValueString(), deleteFile); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Deleting Reverse Zone", "Could not delete zone "+state.Name.ValueString(), err)
+	}
+}
+
+// ImportState imports an existing resource
+func (r *ReverseZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: cidr, or cidr=...[,view=...]
+	values, err := reverseZoneImportID.parse(req.ID)
+	if err == nil {
+		_, err = reverseZoneName(values["cidr"])
+	}
+	if err != nil {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
+			"Invalid Import ID",
+			fmt.Sprintf("%s (e.g., 10.20.0.0/16 or cidr=10.20.0.0/16,view=internal)", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cidr"), values["cidr"])...)
+	if view := values["view"]; view != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view"), view)...)
+	}
+}
+
+// reverseZoneImportID is the import ID format of bind9_reverse_zone
+var reverseZoneImportID = importID{
+	positional: []string{"cidr"},
+	optional:   []string{"view"},
+}
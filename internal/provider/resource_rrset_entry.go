@@ -0,0 +1,501 @@
+// RRset Entry Resource
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces
+var (
+	_ resource.Resource                = &RRsetEntryResource{}
+	_ resource.ResourceWithImportState = &RRsetEntryResource{}
+	_ resource.ResourceWithModifyPlan  = &RRsetEntryResource{}
+)
+
+// NewRRsetEntryResource creates a new RRset entry resource
+func NewRRsetEntryResource() resource.Resource {
+	return &RRsetEntryResource{}
+}
+
+// RRsetEntryResource manages a single value of a record set, leaving the
+// other values of the set alone
+type RRsetEntryResource struct {
+	client *Client
+}
+
+// RRsetEntryResourceModel describes the resource data model
+type RRsetEntryResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Zone  types.String `tfsdk:"zone"`
+	View  types.String `tfsdk:"view"`
+	Name  types.String `tfsdk:"name"`
+	Type  types.String `tfsdk:"type"`
+	RData types.String `tfsdk:"rdata"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+	Class types.String `tfsdk:"class"`
+	FQDN  types.String `tfsdk:"fqdn"`
+}
+
+// Metadata returns the resource type name
+func (r *RRsetEntryResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rrset_entry"
+}
+
+// Schema defines the schema for the resource
+func (r *RRsetEntryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a single value of a DNS record set on BIND9 server.",
+		MarkdownDescription: `
+Manages a single value (rdata) of a DNS record set on a BIND9 server. Other values of the same
+name and type are left alone, so several workspaces or modules can each own their own value,
+for example one A record each under a shared round-robin name.
+
+Do not manage a record set with both ` + "`bind9_record`" + ` and ` + "`bind9_rrset_entry`" + `: the
+` + "`bind9_record`" + ` resource owns every value of its set and removes the others.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "bind9_rrset_entry" "web1" {
+  zone  = "example.com"
+  name  = "www"
+  type  = "A"
+  rdata = "192.0.2.10"
+  ttl   = 300
+}
+` + "```" + `
+`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Entry identifier (zone/name/type/rdata)",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					useStateUnlessChanged(path.Root("zone")),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Description: "Zone name (e.g., example.com). Compared case-insensitively, ignoring a trailing dot.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+					zoneRequiresReplace(func() *Client { return r.client }),
+				},
+			},
+			"view": recordViewAttribute(),
+			"name": schema.StringAttribute{
+				Description: "Record name (e.g., www, @). Compared case-insensitively, ignoring a trailing dot.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					dnsNameString(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Record type (A, AAAA, MX, TXT, NS, SRV, etc.)",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						"A", "AAAA", "CNAME", "MX", "TXT", "NS", "PTR",
+						"SRV", "CAA", "NAPTR", "HTTPS", "SVCB", "TLSA", "SSHFP",
+						"DNSKEY", "DS", "CDNSKEY", "CDS", "LOC", "HINFO", "RP", "DNAME", "URI",
+						"CERT", "SMIMEA", "OPENPGPKEY", "CSYNC", "ZONEMD",
+					),
+				},
+			},
+			"rdata": schema.StringAttribute{
+				Description: "The record data value managed by this resource, in the same format as a value of bind9_record records.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Description: "Time to live in seconds. All values of a record set share one TTL, so entries of the same set should use the same ttl. Defaults to the provider default_ttl (3600 if unset).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"class": schema.StringAttribute{
+				Description: "Record class (IN, CH, HS). Must match the zone class. Defaults to the provider default_class (IN if unset).",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+			"fqdn": schema.StringAttribute{
+				Description: "Fully qualified record name, without trailing dot",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *RRsetEntryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan applies the provider-level record defaults to ttl and class
+// when they are omitted from the configuration
+func (r *RRsetEntryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	ctx = planView(ctx, r.client, req, &resp.Diagnostics)
+
+	var ttl types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ttl"), &ttl)...)
+	var class types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("class"), &class)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if ttl.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ttl"), r.client.defaultTTL)...)
+	}
+	if class.IsNull() {
+		class = types.StringValue(r.client.defaultClass)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("class"), class)...)
+	}
+
+	// Check new entries for CNAME conflicts at their name
+	if !req.State.Raw.IsNull() || class.IsUnknown() {
+		return
+	}
+	var zone, name, recordType types.String
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("zone"), &zone)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &recordType)...)
+	if resp.Diagnostics.HasError() || !isKnownString(zone) || !isKnownString(name) || !isKnownString(recordType) {
+		return
+	}
+	planCNAMEConflict(ctx, r.client, zone.ValueString(), name.ValueString(), class.ValueString(), recordType.ValueString(), &resp.Diagnostics)
+}
+
+// Create creates the resource
+func (r *RRsetEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_rrset_entry.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan RRsetEntryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, plan.View.ValueString())
+
+	tflog.Debug(ctx, "Creating RRset entry", map[string]any{
+		"zone":  plan.Zone.ValueString(),
+		"name":  plan.Name.ValueString(),
+		"type":  plan.Type.ValueString(),
+		"rdata": plan.RData.ValueString(),
+	})
+
+	r.client.beginZoneChange(ctx, plan.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, plan.Zone.ValueString())
+	resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+
+	// Records must be in the same class as their zone
+	if r.client.hasAPI() {
+		zone, err := r.client.lookupZone(ctx, plan.Zone.ValueString())
+		if err != nil {
+			addAPIError(&resp.Diagnostics, "Error Creating RRset Entry", "Could not read zone "+plan.Zone.ValueString(), err)
+			return
+		}
+		if zone.Class != "" && !strings.EqualFold(zone.Class, plan.Class.ValueString()) {
+			addCodedAttributeError(
+				&resp.Diagnostics,
+				path.Root("class"),
+				ErrCodeConfigInvalid,
+				"Record Class Does Not Match Zone",
+				fmt.Sprintf("Zone %s is in class %s, but the entry is in class %s. Set class = %q on the entry.",
+					plan.Zone.ValueString(), strings.ToUpper(zone.Class), plan.Class.ValueString(), strings.ToUpper(zone.Class)),
+			)
+			return
+		}
+	}
+
+	checkCNAMEConflict(ctx, r.client, plan.Zone.ValueString(), plan.Name.ValueString(), plan.Class.ValueString(), plan.Type.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Other values of the set belong to other resources, so the set's ETag
+	// is not used to guard the change
+	rdata := plan.RData.ValueString()
+	detail := fmt.Sprintf("Could not add %q to record %s %s", rdata, plan.Name.ValueString(), plan.Type.ValueString())
+	if useTransaction {
+		change := RecordChange{
+			Action:      recordChangeCreate,
+			RecordType:  plan.Type.ValueString(),
+			Name:        plan.Name.ValueString(),
+			TTL:         int(plan.TTL.ValueInt64()),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData(plan.Type.ValueString(), rdata),
+			RData:       rdata,
+		}
+		if err := r.client.transactions.submit(ctx, plan.Zone.ValueString(), []RecordChange{change}); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Creating RRset Entry", detail, err)
+			return
+		}
+	} else {
+		createReq := &RecordCreateRequest{
+			RecordType:  plan.Type.ValueString(),
+			Name:        plan.Name.ValueString(),
+			TTL:         int(plan.TTL.ValueInt64()),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData(plan.Type.ValueString(), rdata),
+			RData:       rdata,
+		}
+		if _, err := r.client.CreateRecord(withETag(ctx, nil), plan.Zone.ValueString(), createReq); err != nil {
+			addRecordAPIError(&resp.Diagnostics, "Error Creating RRset Entry", detail, err)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(rrsetEntryID(&plan))
+	plan.FQDN = types.StringValue(recordFQDN(plan.Zone.ValueString(), plan.Name.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state
+func (r *RRsetEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_rrset_entry.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var state RRsetEntryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	resp.Diagnostics.Append(r.client.restoreSquelch(ctx, resp.Private)...)
+
+	class := state.Class.ValueString()
+	if class == "" {
+		class = r.client.defaultClass
+	}
+
+	records, err := r.client.ReadRecords(withETag(ctx, nil), state.Zone.ValueString(), state.Type.ValueString(), state.Name.ValueString(), class)
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Reading RRset Entry", "Could not read record", err)
+		return
+	}
+
+	if len(records) == 0 && !r.client.readsAreAuthoritative() {
+		// As for bind9_record, records of dynamic zones may still be in the
+		// journal and not be listed yet
+		tflog.Warn(ctx, "API returned no records, but record may exist in zone journal. Keeping state.", map[string]any{
+			"zone": state.Zone.ValueString(),
+			"name": state.Name.ValueString(),
+			"type": state.Type.ValueString(),
+		})
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	// Find this entry's value among the values of the set. Servers may change
+	// the case of names in record data, so case is ignored and the
+	// configured spelling kept.
+	var found *Record
+	for i := range records {
+		if rdataEqual(state.Type.ValueString(), records[i].RData, state.RData.ValueString(), false) {
+			found = &records[i]
+			break
+		}
+	}
+	if found == nil {
+		tflog.Debug(ctx, "RRset entry no longer exists", map[string]any{
+			"zone":  state.Zone.ValueString(),
+			"name":  state.Name.ValueString(),
+			"type":  state.Type.ValueString(),
+			"rdata": state.RData.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.TTL = types.Int64Value(found.TTL)
+	state.Class = types.StringValue(class)
+	state.ID = types.StringValue(rrsetEntryID(&state))
+	state.FQDN = types.StringValue(recordFQDN(state.Zone.ValueString(), state.Name.ValueString()))
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource. Every argument forces replacement, except a
+// zone renamed in place, so only the planned values are stored.
+func (r *RRsetEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan RRsetEntryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The entry moved with its renamed zone
+	if plan.ID.IsUnknown() {
+		plan.ID = types.StringValue(rrsetEntryID(&plan))
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource
+func (r *RRsetEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_rrset_entry.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var state RRsetEntryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withView(ctx, state.View.ValueString())
+
+	tflog.Debug(ctx, "Deleting RRset entry", map[string]any{
+		"zone":  state.Zone.ValueString(),
+		"name":  state.Name.ValueString(),
+		"type":  state.Type.ValueString(),
+		"rdata": state.RData.ValueString(),
+	})
+
+	r.client.beginZoneChange(ctx, state.Zone.ValueString())
+	defer r.client.endZoneChange(ctx, state.Zone.ValueString())
+
+	useTransaction := useRecordTransactions(r.client, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	if useTransaction {
+		change := RecordChange{
+			Action:      recordChangeDelete,
+			RecordType:  state.Type.ValueString(),
+			Name:        state.Name.ValueString(),
+			RecordClass: state.Class.ValueString(),
+			RData:       state.RData.ValueString(),
+		}
+		err = r.client.transactions.submit(ctx, state.Zone.ValueString(), []RecordChange{change})
+	} else {
+		err = r.client.DeleteRecord(withETag(ctx, nil), state.Zone.ValueString(), state.Name.ValueString(),
+			state.Type.ValueString(), state.Class.ValueString(), state.RData.ValueString())
+	}
+	if err != nil {
+		// The value, or its whole zone, is already gone
+		if isNotFound(err) {
+			tflog.Debug(ctx, "RRset entry already deleted or zone removed", map[string]any{
+				"zone":  state.Zone.ValueString(),
+				"name":  state.Name.ValueString(),
+				"error": err.Error(),
+			})
+			return
+		}
+		addRecordAPIError(&resp.Diagnostics, "Error Deleting RRset Entry", "Could not delete record value", err)
+	}
+}
+
+// ImportState imports an existing resource
+func (r *RRsetEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import format: zone/name/type/rdata, or zone=...,name=...,type=...,rdata=...[,class=...][,view=...]
+	values, err := rrsetEntryImportID.parse(req.ID)
+	if err != nil {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
+			"Invalid Import ID",
+			fmt.Sprintf("%s (e.g., example.com/www/A/192.0.2.10)", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone"), values["zone"])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), values["name"])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(values["type"]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("rdata"), values["rdata"])...)
+	if class := values["class"]; class != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("class"), strings.ToUpper(class))...)
+	}
+	if view := values["view"]; view != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view"), view)...)
+	}
+}
+
+// rrsetEntryImportID is the import ID format of bind9_rrset_entry. Record
+// data may contain slashes, so it takes the rest of a positional ID.
+var rrsetEntryImportID = importID{
+	positional:   []string{"zone", "name", "type", "rdata"},
+	optional:     []string{"class", "view"},
+	trailingRest: true,
+}
+
+// rrsetEntryID returns the resource ID of an entry
+func rrsetEntryID(model *RRsetEntryResourceModel) string {
+	return fmt.Sprintf("%s/%s/%s/%s", zoneID(model.View.ValueString(), model.Zone.ValueString()), model.Name.ValueString(), model.Type.ValueString(), model.RData.ValueString())
+}
@@ -4,9 +4,17 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/netip"
+	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -16,14 +24,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/miekg/dns"
 )
 
 // Ensure the implementation satisfies the expected interfaces
 var (
-	_ resource.Resource                = &ZoneResource{}
-	_ resource.ResourceWithImportState = &ZoneResource{}
+	_ resource.Resource                 = &ZoneResource{}
+	_ resource.ResourceWithImportState  = &ZoneResource{}
+	_ resource.ResourceWithModifyPlan   = &ZoneResource{}
+	_ resource.ResourceWithUpgradeState = &ZoneResource{}
 )
 
 // NewZoneResource creates a new zone resource
@@ -38,27 +51,76 @@ type ZoneResource struct {
 
 // ZoneResourceModel describes the resource data model
 type ZoneResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	Type          types.String `tfsdk:"type"`
-	File          types.String `tfsdk:"file"`
-	SOAMname      types.String `tfsdk:"soa_mname"`
-	SOARname      types.String `tfsdk:"soa_rname"`
-	SOARefresh    types.Int64  `tfsdk:"soa_refresh"`
-	SOARetry      types.Int64  `tfsdk:"soa_retry"`
-	SOAExpire     types.Int64  `tfsdk:"soa_expire"`
-	SOAMinimum    types.Int64  `tfsdk:"soa_minimum"`
-	DefaultTTL    types.Int64  `tfsdk:"default_ttl"`
-	Nameservers   types.List   `tfsdk:"nameservers"`
-	NSAddresses   types.Map    `tfsdk:"ns_addresses"`
-	AllowTransfer types.List   `tfsdk:"allow_transfer"`
-	AllowUpdate   types.List   `tfsdk:"allow_update"`
-	AllowQuery    types.List   `tfsdk:"allow_query"`
-	Notify        types.Bool   `tfsdk:"notify"`
-	DeleteFile    types.Bool   `tfsdk:"delete_file_on_destroy"`
-	Serial        types.Int64  `tfsdk:"serial"`
-	Loaded        types.Bool   `tfsdk:"loaded"`
-	DNSSECEnabled types.Bool   `tfsdk:"dnssec_enabled"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Class           types.String `tfsdk:"class"`
+	View            types.String `tfsdk:"view"`
+	File            types.String `tfsdk:"file"`
+	SOAMname        types.String `tfsdk:"soa_mname"`
+	SOARname        types.String `tfsdk:"soa_rname"`
+	SOARefresh      types.Int64  `tfsdk:"soa_refresh"`
+	SOARetry        types.Int64  `tfsdk:"soa_retry"`
+	SOAExpire       types.Int64  `tfsdk:"soa_expire"`
+	SOAMinimum      types.Int64  `tfsdk:"soa_minimum"`
+	DefaultTTL      types.Int64  `tfsdk:"default_ttl"`
+	Nameservers     types.List   `tfsdk:"nameservers"`
+	NSAddresses     types.Map    `tfsdk:"ns_addresses"`
+	Primaries       types.List   `tfsdk:"primaries"`
+	Forwarders      types.List   `tfsdk:"forwarders"`
+	Forward         types.String `tfsdk:"forward"`
+	ServerAddresses types.List   `tfsdk:"server_addresses"`
+	ServerNames     types.List   `tfsdk:"server_names"`
+	AllowTransfer   types.List   `tfsdk:"allow_transfer"`
+	AllowUpdate     types.List   `tfsdk:"allow_update"`
+	UpdateKeys      types.List   `tfsdk:"allow_update_keys"`
+	AllowQuery      types.List   `tfsdk:"allow_query"`
+	Notify          types.String `tfsdk:"notify"`
+	NotifySource    types.String `tfsdk:"notify_source"`
+	TransferSource  types.String `tfsdk:"transfer_source"`
+	TransferSource6 types.String `tfsdk:"transfer_source_v6"`
+	MaxTransferTime types.Int64  `tfsdk:"max_transfer_time_in"`
+	MaxTransferIdle types.Int64  `tfsdk:"max_transfer_idle_in"`
+	TransferFormat  types.String `tfsdk:"transfer_format"`
+	InlineSigning   types.Bool   `tfsdk:"inline_signing"`
+	DNSSECPolicy    types.String `tfsdk:"dnssec_policy"`
+	KeyDirectory    types.String `tfsdk:"key_directory"`
+	AutoDNSSEC      types.String `tfsdk:"auto_dnssec"`
+	LoadKeysPeriod  types.Int64  `tfsdk:"dnssec_loadkeys_interval"`
+	SerialFormat    types.String `tfsdk:"serial_format"`
+	CheckNames      types.String `tfsdk:"check_names"`
+	MaxJournal      types.String `tfsdk:"max_journal_size"`
+	Journal         types.String `tfsdk:"journal"`
+	FileFormat      types.String `tfsdk:"masterfile_format"`
+	ResponsePolicy  types.Object `tfsdk:"response_policy"`
+	InitialRecords  types.List   `tfsdk:"initial_records"`
+	ZoneFileContent types.String `tfsdk:"zone_file_content"`
+	DeleteFile      types.Bool   `tfsdk:"delete_file_on_destroy"`
+	ForceDestroy    types.Bool   `tfsdk:"force_destroy"`
+	Serial          types.Int64  `tfsdk:"serial"`
+	Loaded          types.Bool   `tfsdk:"loaded"`
+	DNSSECEnabled   types.Bool   `tfsdk:"dnssec_enabled"`
+	OnChange        types.String `tfsdk:"on_change_webhook"`
+}
+
+// soa returns the SOA attributes of the model
+func (m *ZoneResourceModel) soa() zoneSOAModel {
+	return zoneSOAModel{&m.SOAMname, &m.SOARname, &m.SOARefresh, &m.SOARetry, &m.SOAExpire, &m.SOAMinimum}
+}
+
+// PrimaryModel describes a primary server a slave or stub zone loads from
+type PrimaryModel struct {
+	Address types.String `tfsdk:"address"`
+	Port    types.Int64  `tfsdk:"port"`
+	Key     types.String `tfsdk:"key"`
+}
+
+// UpdateKeyModel describes a TSIG key allowed to update the zone, optionally
+// limited to some names and record types
+type UpdateKeyModel struct {
+	Key   types.String `tfsdk:"key"`
+	Names types.List   `tfsdk:"names"`
+	Types types.List   `tfsdk:"types"`
 }
 
 // Metadata returns the resource type name
@@ -69,6 +131,8 @@ func (r *ZoneResource) Metadata(ctx context.Context, req resource.MetadataReques
 // Schema defines the schema for the resource
 func (r *ZoneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changed notify from a boolean to a mode
+		Version:     1,
 		Description: "Manages a DNS zone on BIND9 server.",
 		MarkdownDescription: `
 Manages a DNS zone on a BIND9 server.
@@ -92,7 +156,17 @@ resource "bind9_zone" "example" {
   ]
   
   allow_transfer = ["192.168.1.0/24"]
-  notify = true
+  notify = "yes"
+}
+` + "```" + `
+
+### CHAOS Class Zone
+
+` + "```hcl" + `
+resource "bind9_zone" "monitoring" {
+  name  = "monitoring.example"
+  type  = "master"
+  class = "CH"
 }
 ` + "```" + `
 
@@ -102,8 +176,11 @@ resource "bind9_zone" "example" {
 resource "bind9_zone" "slave" {
   name = "example.com"
   type = "slave"
-  
-  # Masters would be configured in BIND9 directly
+
+  primaries = [
+    { address = "192.0.2.1" },
+    { address = "192.0.2.2", key = "transfer-key" },
+  ]
 }
 ` + "```" + `
 `,
@@ -116,18 +193,37 @@ resource "bind9_zone" "slave" {
 				},
 			},
 			"name": schema.StringAttribute{
-				Description: "Zone name (e.g., example.com)",
+				Description: "Zone name (e.g., example.com). Changing it renames the zone in place if the server can rename zones, and replaces it otherwise.",
+				Required:    true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Zone type: master, slave, forward, stub, static-stub, or redirect",
 				Required:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"type": schema.StringAttribute{
-				Description: "Zone type: master, slave, forward, stub",
-				Required:    true,
+			"class": schema.StringAttribute{
+				Description: "Zone class: IN, CH (CHAOS), or HS (Hesiod). Default: IN",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("IN"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.OneOf("IN", "CH", "HS"),
+				},
+			},
+			"view": schema.StringAttribute{
+				Description: "View the zone is placed in, for servers serving the same zone name in several views (split horizon). Default: the server's default view",
+				Optional:    true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"file": schema.StringAttribute{
 				Description: "Zone file path (auto-generated if not specified)",
@@ -183,6 +279,61 @@ resource "bind9_zone" "slave" {
 				ElementType: types.StringType,
 				Default:     listdefault.StaticValue(types.ListNull(types.StringType)),
 			},
+			"primaries": schema.ListNestedAttribute{
+				Description: "Primary servers a slave zone transfers from, or a stub zone pulls its NS records from, each optionally " +
+					"authenticated with a TSIG key. Required for slave and stub zones, and not allowed for master and forward zones.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "IPv4 or IPv6 address of the primary server",
+							Required:    true,
+						},
+						"port": schema.Int64Attribute{
+							Description: "Port of the primary server. Default: 53",
+							Optional:    true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 65535),
+							},
+						},
+						"key": schema.StringAttribute{
+							Description: "TSIG key that signs transfers from the primary server",
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"forwarders": schema.ListAttribute{
+				Description: "IP addresses of the servers a forward zone sends its queries to. " +
+					"Required for forward zones; an empty list turns forwarding off for the zone.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"forward": schema.StringAttribute{
+				Description: "Forwarding mode of a forward zone: only, or first to fall back to normal resolution. Default: first",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("only", "first"),
+				},
+			},
+			"server_addresses": schema.ListAttribute{
+				Description: "IP addresses of the authoritative servers a static-stub zone sends its queries to. " +
+					"A static-stub zone needs server_addresses, server_names or both; not allowed for other zone types.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"server_names": schema.ListAttribute{
+				Description: "Names of the authoritative servers a static-stub zone sends its queries to, resolved by the server. " +
+					"Only for static-stub zones.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
 			"allow_transfer": schema.ListAttribute{
 				Description: "ACL for zone transfers",
 				Optional:    true,
@@ -197,6 +348,29 @@ resource "bind9_zone" "slave" {
 				ElementType: types.StringType,
 				Default:     listdefault.StaticValue(types.ListNull(types.StringType)),
 			},
+			"allow_update_keys": schema.ListNestedAttribute{
+				Description: "TSIG keys allowed to update the zone. Keys without names or types are added to allow_update; " +
+					"if any key is scoped, all keys are translated into update-policy grants instead.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Description: "TSIG key name",
+							Required:    true,
+						},
+						"names": schema.ListAttribute{
+							Description: "Record names the key may update, relative to the zone. Use @ for the apex and *.name for everything below a name. Default: the whole zone",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+						"types": schema.ListAttribute{
+							Description: "Record types the key may update. Default: all types",
+							Optional:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
 			"allow_query": schema.ListAttribute{
 				Description: "ACL for queries",
 				Optional:    true,
@@ -204,11 +378,171 @@ resource "bind9_zone" "slave" {
 				ElementType: types.StringType,
 				Default:     listdefault.StaticValue(types.ListNull(types.StringType)),
 			},
-			"notify": schema.BoolAttribute{
-				Description: "Send NOTIFY to slaves on zone changes",
+			"notify": schema.StringAttribute{
+				Description: "Send NOTIFY on zone changes: yes (to the zone's nameservers), no, or explicit (only to the also-notify servers of the server configuration). Default: yes",
 				Optional:    true,
 				Computed:    true,
-				Default:     booldefault.StaticBool(true),
+				Default:     stringdefault.StaticString(notifyYes),
+				Validators: []validator.String{
+					stringvalidator.OneOf(notifyYes, notifyNo, notifyExplicit),
+				},
+			},
+			"notify_source": schema.StringAttribute{
+				Description: "Local IPv4 or IPv6 address NOTIFY messages are sent from, for servers with several interfaces. Default: the server's setting",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					ipAddressString(),
+				},
+			},
+			"transfer_source": schema.StringAttribute{
+				Description: "Local IPv4 address a slave or stub zone is transferred from its IPv4 primaries with, such as the address the primaries allow transfers to. Default: the server's setting",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					ipAddressString(),
+				},
+			},
+			"transfer_source_v6": schema.StringAttribute{
+				Description: "Local IPv6 address a slave or stub zone is transferred from its IPv6 primaries with. Default: the server's setting",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					ipAddressString(),
+				},
+			},
+			"max_transfer_time_in": schema.Int64Attribute{
+				Description: "Minutes an inbound transfer of a slave or stub zone may run before it is terminated, up to 40320 (28 days). Default: the server's setting (120)",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxTransferMinutes),
+				},
+			},
+			"max_transfer_idle_in": schema.Int64Attribute{
+				Description: "Minutes an inbound transfer of a slave or stub zone may make no progress before it is terminated, up to 40320 (28 days). Default: the server's setting (60)",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxTransferMinutes),
+				},
+			},
+			"transfer_format": schema.StringAttribute{
+				Description: "Message format of the transfers the server sends of the zone to its secondaries: one-answer (one record per message) or many-answers (as many records as fit). Default: the server's setting (many-answers)",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("one-answer", "many-answers"),
+				},
+			},
+			"inline_signing": schema.BoolAttribute{
+				Description: "Sign the zone into a separate signed copy, leaving the zone file unsigned. " +
+					"Required on slave zones signed with a dnssec_policy. Default: the server's setting",
+				Optional: true,
+			},
+			"dnssec_policy": schema.StringAttribute{
+				Description: "Name of the dnssec-policy that signs the zone and manages its keys: default, insecure, none, " +
+					"or a policy defined in the server configuration",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(dnssecPolicyName, "must be a dnssec-policy name"),
+				},
+			},
+			"key_directory": schema.StringAttribute{
+				Description: "Directory holding the zone's DNSSEC key files. Default: the server's setting (its working directory)",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"auto_dnssec": schema.StringAttribute{
+				Description: "Key maintenance of zones signed without a dnssec_policy: allow (sign with the keys in key_directory on rndc sign), " +
+					"maintain (also load and roll keys on their timing metadata), or off. Conflicts with dnssec_policy. Default: off",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow", "maintain", "off"),
+				},
+			},
+			"dnssec_loadkeys_interval": schema.Int64Attribute{
+				Description: "Minutes between checks of key_directory for new or changed keys of a maintained zone, up to 1440 (1 day). " +
+					"Default: the server's setting (60)",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxLoadKeysMinutes),
+				},
+			},
+			"serial_format": schema.StringAttribute{
+				Description: "How the SOA serial is bumped on changes: increment, unixtime (seconds since the epoch), " +
+					"or date (YYYYMMDDnn). Only for master zones. Default: increment",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("increment", "unixtime", "date"),
+				},
+			},
+			"check_names": schema.StringAttribute{
+				Description: "How names that are not valid hostnames are handled: fail, warn or ignore. " +
+					"Default: the server's setting (fail on master zones, warn on slave zones)",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("fail", "warn", "ignore"),
+				},
+			},
+			"max_journal_size": schema.StringAttribute{
+				Description: "Largest size of the zone's journal before it is trimmed: a size in bytes with an optional " +
+					"k, m or g suffix, unlimited, or default. Default: the server's setting",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(journalSize, "must be a size such as 10m, unlimited or default"),
+				},
+			},
+			"journal": schema.StringAttribute{
+				Description: "Path of the zone's journal file. Default: the zone file path with .jnl appended",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"masterfile_format": schema.StringAttribute{
+				Description: "Format of the zone file: text, or the binary raw or map formats, which named loads faster. " +
+					"Not allowed for forward zones. Default: the server's setting (text)",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("text", "raw", "map"),
+				},
+			},
+			"response_policy": responsePolicyAttribute(),
+			"initial_records": schema.ListNestedAttribute{
+				Description: "Records the zone is created with, besides its SOA and NS records. Only used when the zone is created; " +
+					"manage records of an existing zone with bind9_record. Conflicts with zone_file_content.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description: "Record name relative to the zone (e.g., www, @ for the zone apex)",
+							Required:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Record type (A, AAAA, CNAME, MX, TXT, etc.)",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									"A", "AAAA", "CNAME", "MX", "TXT", "NS", "PTR",
+									"SRV", "CAA", "NAPTR", "HTTPS", "SVCB", "TLSA", "SSHFP",
+									"DNSKEY", "DS", "CDNSKEY", "CDS", "LOC", "HINFO", "RP", "DNAME", "URI",
+									"CERT", "SMIMEA", "OPENPGPKEY", "CSYNC", "ZONEMD",
+								),
+							},
+						},
+						"rdata": schema.StringAttribute{
+							Description: "Record data in presentation format, as for bind9_record records",
+							Required:    true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "Time to live in seconds. Defaults to default_ttl.",
+							Optional:    true,
+							Validators:  []validator.Int64{int64validator.AtLeast(0)},
+						},
+					},
+				},
+			},
+			"zone_file_content": schema.StringAttribute{
+				Description: "Zone file in master file format the zone is created from, replacing the generated SOA and NS records. " +
+					"Only used when the zone is created. Conflicts with initial_records.",
+				Optional: true,
 			},
 			"delete_file_on_destroy": schema.BoolAttribute{
 				Description: "Delete zone file when zone is destroyed",
@@ -216,14 +550,30 @@ resource "bind9_zone" "slave" {
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"force_destroy": schema.BoolAttribute{
+				Description: "Destroy a master zone even if it holds records not managed by this resource. " +
+					"When false, destroying a zone with records added by hand or managed elsewhere fails. Default: false",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"on_change_webhook": schema.StringAttribute{
+				Description: "URL POSTed a JSON event (zone, serial, action, changed attributes) after the zone is created, updated or deleted. " +
+					"Delivery failures are reported as warnings.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://`), "must be an http or https URL"),
+				},
+			},
 			"ns_addresses": schema.MapAttribute{
 				Description: "Map of nameserver names to IP addresses for glue records (e.g., {\"ns1\" = \"192.168.1.1\"})",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
 			"serial": schema.Int64Attribute{
-				Description: "Current zone serial number",
-				Computed:    true,
+				Description: "Zone serial number after the zone was created or its SOA last changed through this resource. " +
+					"Record changes are not reflected; use the bind9_zone data source for the current serial",
+				Computed: true,
 			},
 			"loaded": schema.BoolAttribute{
 				Description: "Whether zone is loaded",
@@ -237,6 +587,53 @@ resource "bind9_zone" "slave" {
 	}
 }
 
+// UpgradeState migrates state written by earlier schema versions
+func (r *ZoneResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 stored notify as a boolean
+		0: {StateUpgrader: upgradeZoneStateV0},
+	}
+}
+
+// upgradeZoneStateV0 turns the notify boolean of version 0 state into the
+// yes or no mode
+func upgradeZoneStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Zone State",
+			"The prior state of the bind9_zone resource is not in JSON format, which Terraform 0.12 and later write.")
+		return
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Zone State", "Could not parse the prior state: "+err.Error())
+		return
+	}
+
+	if raw, ok := state["notify"]; ok {
+		var notify *bool
+		if err := json.Unmarshal(raw, &notify); err != nil {
+			resp.Diagnostics.AddError("Unable to Upgrade Zone State", "Could not parse the prior notify setting: "+err.Error())
+			return
+		}
+		switch {
+		case notify == nil:
+			state["notify"] = json.RawMessage("null")
+		case *notify:
+			state["notify"] = json.RawMessage(`"` + notifyYes + `"`)
+		default:
+			state["notify"] = json.RawMessage(`"` + notifyNo + `"`)
+		}
+	}
+
+	upgraded, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Upgrade Zone State", err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
+
 // Configure adds the provider configured client to the resource
 func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -255,212 +652,1484 @@ func (r *ZoneResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
-// Create creates the resource and sets the initial Terraform state
-func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan ZoneResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// checkZoneLimit warns when the zones planned for creation would exceed the
+// server's zone limit
+func (r *ZoneResource) checkZoneLimit(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() {
 		return
 	}
 
-	tflog.Debug(ctx, "Creating zone", map[string]any{"name": plan.Name.ValueString()})
-
-	// Build create request
-	createReq := &ZoneCreateRequest{
-		Name:       plan.Name.ValueString(),
-		Type:       plan.Type.ValueString(),
-		SOAMname:   plan.SOAMname.ValueString(),
-		SOARname:   plan.SOARname.ValueString(),
-		SOARefresh: int(plan.SOARefresh.ValueInt64()),
-		SOARetry:   int(plan.SOARetry.ValueInt64()),
-		SOAExpire:  int(plan.SOAExpire.ValueInt64()),
-		SOAMinimum: int(plan.SOAMinimum.ValueInt64()),
-		DefaultTTL: int(plan.DefaultTTL.ValueInt64()),
+	var name, view types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("view"), &view)...)
+	if resp.Diagnostics.HasError() || name.IsUnknown() || name.IsNull() || view.IsUnknown() {
+		return
 	}
 
-	// Convert ns_addresses map
-	if !plan.NSAddresses.IsNull() {
-		nsAddresses := make(map[string]string)
-		diags = plan.NSAddresses.ElementsAs(ctx, &nsAddresses, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		createReq.NSAddresses = nsAddresses
+	total, limit, err := r.client.planZoneCreate(ctx, view.ValueString(), name.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "Could not check zone limit", map[string]any{"error": err.Error()})
+		return
 	}
-
-	if !plan.File.IsNull() {
-		createReq.File = plan.File.ValueString()
+	if limit > 0 && total > limit {
+		resp.Diagnostics.AddAttributeWarning(path.Root("name"), "Zone Limit Exceeded", quotaWarningDetail("zones", total, limit))
 	}
+}
 
-	// Convert nameservers
-	if !plan.Nameservers.IsNull() {
-		var nameservers []string
-		diags = plan.Nameservers.ElementsAs(ctx, &nameservers, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		createReq.Nameservers = nameservers
+// ModifyPlan checks the planned zone against the provider policy
+func (r *ZoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy or before the provider is configured, other
+	// than clearing the replacement mark of an earlier plan
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		markZoneReplacement(ctx, req, resp)
+		return
 	}
 
-	// Build options (allow_update, allow_transfer, allow_query)
-	options := &ZoneOptions{}
-	hasOptions := false
-
-	if !plan.AllowUpdate.IsNull() {
-		var allowUpdate []string
-		diags = plan.AllowUpdate.ElementsAs(ctx, &allowUpdate, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		options.AllowUpdate = allowUpdate
-		hasOptions = true
-	}
+	r.applyZoneDefaults(ctx, req, resp)
+	r.checkView(ctx, req, resp)
+	r.checkRename(ctx, req, resp)
+	markZoneReplacement(ctx, req, resp, "type", "class", "view")
+	r.checkUpdatePolicy(ctx, req, resp)
+	r.checkPrimaries(ctx, req, resp)
+	r.checkForwarding(ctx, req, resp)
+	r.checkStaticStub(ctx, req, resp)
+	r.checkRedirect(ctx, req, resp)
+	r.checkSigning(ctx, req, resp)
+	r.checkKeyMaintenance(ctx, req, resp)
+	r.checkSerialFormat(ctx, req, resp)
+	r.checkFileFormat(ctx, req, resp)
+	r.checkResponsePolicy(ctx, req, resp)
+	r.checkNotifySource(ctx, req, resp)
+	r.checkTransferSource(ctx, req, resp)
+	r.checkTransferTuning(ctx, req, resp)
+	r.checkInitialContent(ctx, req, resp)
+	r.checkZoneLimit(ctx, req, resp)
+	planZoneSerial(ctx, req, resp, zoneSerialAttributes...)
 
-	if !plan.AllowTransfer.IsNull() {
-		var allowTransfer []string
-		diags = plan.AllowTransfer.ElementsAs(ctx, &allowTransfer, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		options.AllowTransfer = allowTransfer
-		hasOptions = true
+	var soaMinimum types.Int64
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("soa_minimum"), &soaMinimum)...)
+	if resp.Diagnostics.HasError() || soaMinimum.IsUnknown() || soaMinimum.IsNull() {
+		return
 	}
 
-	if !plan.AllowQuery.IsNull() {
-		var allowQuery []string
-		diags = plan.AllowQuery.ElementsAs(ctx, &allowQuery, false)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		options.AllowQuery = allowQuery
-		hasOptions = true
+	maxSOAMinimum := r.client.policy.maxSOAMinimum
+	if maxSOAMinimum > 0 && soaMinimum.ValueInt64() > maxSOAMinimum {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("soa_minimum"),
+			"SOA Minimum Exceeds Policy",
+			fmt.Sprintf("The soa_minimum of %d seconds exceeds the maximum of %d set in the provider policy. "+
+				"soa_minimum is the negative caching TTL (RFC 2308), and resolvers cap it at their own limit "+
+				"(max-ncache-ttl in BIND9, 10800 by default), so larger values only delay recovery from NXDOMAIN answers.",
+				soaMinimum.ValueInt64(), maxSOAMinimum),
+		)
 	}
+}
 
-	if hasOptions {
-		createReq.Options = options
+// applyZoneDefaults plans the zone_defaults of the provider configuration
+// for the attributes the zone's configuration leaves out
+func (r *ZoneResource) applyZoneDefaults(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defaults := r.client.zoneDefaults
+	if defaults == nil {
+		return
 	}
 
-	// Create zone
-	zone, err := r.client.CreateZone(ctx, createReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Zone",
-			"Could not create zone: "+err.Error(),
-		)
+	var config ZoneResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Set state
-	plan.ID = types.StringValue(zone.Name)
-	plan.Serial = types.Int64Value(zone.Serial)
-	plan.Loaded = types.BoolValue(zone.Loaded)
-	plan.DNSSECEnabled = types.BoolValue(zone.DNSSECEnabled)
-	if zone.File != "" {
-		plan.File = types.StringValue(zone.File)
+	for _, d := range []struct {
+		name       string
+		configured attr.Value
+		inherited  attr.Value
+	}{
+		{"soa_mname", config.SOAMname, defaults.SOAMname},
+		{"soa_rname", config.SOARname, defaults.SOARname},
+		{"soa_refresh", config.SOARefresh, defaults.SOARefresh},
+		{"soa_retry", config.SOARetry, defaults.SOARetry},
+		{"soa_expire", config.SOAExpire, defaults.SOAExpire},
+		{"soa_minimum", config.SOAMinimum, defaults.SOAMinimum},
+		{"default_ttl", config.DefaultTTL, defaults.DefaultTTL},
+		{"nameservers", config.Nameservers, defaults.Nameservers},
+		{"allow_transfer", config.AllowTransfer, defaults.AllowTransfer},
+		{"allow_query", config.AllowQuery, defaults.AllowQuery},
+	} {
+		if d.configured.IsNull() && !d.inherited.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(d.name), d.inherited)...)
+		}
 	}
-
-	diags = resp.State.Set(ctx, plan)
-	resp.Diagnostics.Append(diags...)
 }
 
-// Read refreshes the Terraform state with the latest data
-func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state ZoneResourceModel
-	diags := req.State.Get(ctx, &state)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// checkView rejects a view on servers whose API does not manage views
+func (r *ZoneResource) checkView(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var view types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("view"), &view)...)
+	if resp.Diagnostics.HasError() || view.IsNull() || view.IsUnknown() {
 		return
 	}
 
-	tflog.Debug(ctx, "Reading zone", map[string]any{"name": state.Name.ValueString()})
+	if !r.client.SupportsFeature(FeatureViews) {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("view"), ErrCodeFeatureUnsupported, "Views Not Supported",
+			fmt.Sprintf("The BIND9 REST API server (version %s) does not manage zones in views. Remove view to use the default view.",
+				r.client.ServerVersion()))
+	}
+}
 
-	zone, err := r.client.GetZone(ctx, state.Name.ValueString())
-	if err != nil {
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
-			resp.State.RemoveResource(ctx)
-			return
-		}
-		resp.Diagnostics.AddError(
-			"Error Reading Zone",
-			"Could not read zone: "+err.Error(),
-		)
+// checkRename plans a changed zone name as a rename on servers that can
+// rename zones, and as a replacement otherwise. Planned renames are
+// remembered for the records of the zone, which move with it.
+func (r *ZoneResource) checkRename(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
 		return
 	}
 
-	// Update state with API response values
-	state.Serial = types.Int64Value(zone.Serial)
-	state.Loaded = types.BoolValue(zone.Loaded)
-	state.DNSSECEnabled = types.BoolValue(zone.DNSSECEnabled)
-	if zone.File != "" {
-		state.File = types.StringValue(zone.File)
+	var prior, name, view types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("view"), &view)...)
+	if resp.Diagnostics.HasError() || name.Equal(prior) {
+		return
 	}
-	if zone.Type != "" {
-		// Normalize zone type (BIND9 uses "primary"/"secondary" in newer versions,
-		// but "master"/"slave" are still commonly used synonyms)
-		zoneType := zone.Type
-		switch zoneType {
-		case "primary":
-			zoneType = "master"
-		case "secondary":
-			zoneType = "slave"
-		}
-		state.Type = types.StringValue(zoneType)
+
+	if name.IsUnknown() || !r.client.SupportsFeature(FeatureZoneRename) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("name"))
+		return
 	}
 
-	diags = resp.State.Set(ctx, &state)
-	resp.Diagnostics.Append(diags...)
+	r.client.zoneRenames.plan(prior.ValueString(), name.ValueString())
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), zoneID(view.ValueString(), name.ValueString()))...)
 }
 
-// Update updates the resource and sets the updated Terraform state
-func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ZoneResourceModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+// checkUpdatePolicy rejects plans combining scoped update keys with
+// allow_update entries, since BIND9 does not allow both allow-update and
+// update-policy on a zone
+func (r *ZoneResource) checkUpdatePolicy(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var allowUpdate, updateKeys types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_update"), &allowUpdate)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_update_keys"), &updateKeys)...)
+	if resp.Diagnostics.HasError() || updateKeys.IsNull() || updateKeys.IsUnknown() || allowUpdate.IsUnknown() {
 		return
 	}
 
-	tflog.Debug(ctx, "Updating zone", map[string]any{"name": plan.Name.ValueString()})
-
-	// Reload zone to apply changes
-	if err := r.client.ReloadZone(ctx, plan.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Zone",
-			"Could not reload zone: "+err.Error(),
-		)
+	var keys []UpdateKeyModel
+	resp.Diagnostics.Append(updateKeys.ElementsAs(ctx, &keys, false)...)
+	if resp.Diagnostics.HasError() || !hasScopedUpdateKey(keys) || len(allowUpdate.Elements()) == 0 {
 		return
 	}
 
-	// Read back the zone
-	zone, err := r.client.GetZone(ctx, plan.Name.ValueString())
+	addCodedAttributeError(
+		&resp.Diagnostics,
+		path.Root("allow_update"),
+		ErrCodeConfigInvalid,
+		"Conflicting Update Permissions",
+		"allow_update_keys contains keys limited by names or types, which are written as an update-policy. "+
+			"BIND9 does not allow update-policy and allow-update on the same zone. "+
+			"Move the allow_update entries to allow_update_keys, or remove the names and types limits.",
+	)
+}
+
+// checkPrimaries requires primaries on slave and stub zones, and rejects
+// them on zones that do not load from a primary
+func (r *ZoneResource) checkPrimaries(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType types.String
+	var primaries types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("primaries"), &primaries)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() || primaries.IsUnknown() {
+		return
+	}
+
+	switch strings.ToLower(zoneType.ValueString()) {
+	case "slave", "secondary", "stub":
+		if len(primaries.Elements()) == 0 {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("primaries"), ErrCodeConfigInvalid, "Missing Primaries",
+				fmt.Sprintf("Zone type %q loads its data from primary servers. Set primaries to at least one server.", zoneType.ValueString()))
+			return
+		}
+	case "master", "primary", "forward", "static-stub":
+		if len(primaries.Elements()) > 0 {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("primaries"), ErrCodeConfigInvalid, "Unexpected Primaries",
+				fmt.Sprintf("Zone type %q does not load from primary servers. Remove primaries, or change type to slave or stub.", zoneType.ValueString()))
+			return
+		}
+	}
+
+	if primaries.IsNull() {
+		return
+	}
+	var servers []PrimaryModel
+	resp.Diagnostics.Append(primaries.ElementsAs(ctx, &servers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, p := range servers {
+		if p.Address.IsUnknown() {
+			continue
+		}
+		if _, err := netip.ParseAddr(p.Address.ValueString()); err != nil {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("primaries").AtListIndex(i).AtName("address"), ErrCodeConfigInvalid,
+				"Invalid Primary Address", fmt.Sprintf("%q is not an IPv4 or IPv6 address.", p.Address.ValueString()))
+		}
+	}
+}
+
+// checkForwarding requires forwarders on forward zones and rejects the
+// forwarding attributes on other zones. Forward zones have no zone file, so
+// an unset file is planned as null rather than left unknown.
+func (r *ZoneResource) checkForwarding(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, forward, file types.String
+	var forwarders types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("forwarders"), &forwarders)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("forward"), &forward)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file"), &file)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() {
+		return
+	}
+
+	if !strings.EqualFold(zoneType.ValueString(), "forward") {
+		if !forwarders.IsNull() {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("forwarders"), ErrCodeConfigInvalid, "Unexpected Forwarders",
+				fmt.Sprintf("forwarders only applies to forward zones, and this zone has type %q. Remove forwarders, or change type to forward.", zoneType.ValueString()))
+		}
+		if !forward.IsNull() {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("forward"), ErrCodeConfigInvalid, "Unexpected Forward Mode",
+				fmt.Sprintf("forward only applies to forward zones, and this zone has type %q. Remove forward, or change type to forward.", zoneType.ValueString()))
+		}
+		return
+	}
+
+	if forwarders.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("forwarders"), ErrCodeConfigInvalid, "Missing Forwarders",
+			"Forward zones send their queries to the servers in forwarders. Set forwarders, or set it to an empty list to turn forwarding off for the zone.")
+		return
+	}
+	var configFile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("file"), &configFile)...)
+	if !configFile.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("file"), ErrCodeConfigInvalid, "Unexpected Zone File",
+			"Forward zones have no zone file. Remove file.")
+		return
+	}
+	if file.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file"), types.StringNull())...)
+	}
+
+	if forwarders.IsUnknown() {
+		return
+	}
+	var addresses []types.String
+	resp.Diagnostics.Append(forwarders.ElementsAs(ctx, &addresses, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for i, addr := range addresses {
+		if addr.IsUnknown() {
+			continue
+		}
+		if _, err := netip.ParseAddr(addr.ValueString()); err != nil {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("forwarders").AtListIndex(i), ErrCodeConfigInvalid,
+				"Invalid Forwarder Address", fmt.Sprintf("%q is not an IPv4 or IPv6 address.", addr.ValueString()))
+		}
+	}
+}
+
+// checkStaticStub requires servers on static-stub zones and rejects them on
+// other zones. Like forward zones, static-stub zones have no zone file.
+func (r *ZoneResource) checkStaticStub(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, file types.String
+	var addresses, names types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_addresses"), &addresses)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("server_names"), &names)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file"), &file)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() {
+		return
+	}
+
+	if !strings.EqualFold(zoneType.ValueString(), "static-stub") {
+		for _, opt := range []struct {
+			name string
+			set  bool
+		}{
+			{"server_addresses", !addresses.IsNull()},
+			{"server_names", !names.IsNull()},
+		} {
+			if opt.set {
+				addCodedAttributeError(&resp.Diagnostics, path.Root(opt.name), ErrCodeConfigInvalid, "Unexpected Static-Stub Servers",
+					fmt.Sprintf("%s only applies to static-stub zones, and this zone has type %q. Remove %s, or change type to static-stub.",
+						opt.name, zoneType.ValueString(), opt.name))
+			}
+		}
+		return
+	}
+
+	if addresses.IsNull() && names.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("server_addresses"), ErrCodeConfigInvalid, "Missing Static-Stub Servers",
+			"Static-stub zones send their queries to fixed authoritative servers. Set server_addresses, server_names or both.")
+		return
+	}
+	var configFile types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("file"), &configFile)...)
+	if !configFile.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("file"), ErrCodeConfigInvalid, "Unexpected Zone File",
+			"Static-stub zones have no zone file. Remove file.")
+		return
+	}
+	if file.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file"), types.StringNull())...)
+	}
+
+	if !addresses.IsNull() && !addresses.IsUnknown() {
+		var values []types.String
+		resp.Diagnostics.Append(addresses.ElementsAs(ctx, &values, false)...)
+		for i, addr := range values {
+			if addr.IsUnknown() {
+				continue
+			}
+			if _, err := netip.ParseAddr(addr.ValueString()); err != nil {
+				addCodedAttributeError(&resp.Diagnostics, path.Root("server_addresses").AtListIndex(i), ErrCodeConfigInvalid,
+					"Invalid Server Address", fmt.Sprintf("%q is not an IPv4 or IPv6 address.", addr.ValueString()))
+			}
+		}
+	}
+	if !names.IsNull() && !names.IsUnknown() {
+		var values []types.String
+		resp.Diagnostics.Append(names.ElementsAs(ctx, &values, false)...)
+		for i, name := range values {
+			if name.IsUnknown() {
+				continue
+			}
+			if _, ok := dns.IsDomainName(name.ValueString()); !ok || name.ValueString() == "" {
+				addCodedAttributeError(&resp.Diagnostics, path.Root("server_names").AtListIndex(i), ErrCodeConfigInvalid,
+					"Invalid Server Name", fmt.Sprintf("%q is not a domain name.", name.ValueString()))
+			}
+		}
+	}
+}
+
+// checkRedirect requires a redirect zone to be the root zone. BIND9 answers
+// queries that would get an NXDOMAIN from the root redirect zone instead.
+func (r *ZoneResource) checkRedirect(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, name types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() || name.IsUnknown() ||
+		!strings.EqualFold(zoneType.ValueString(), "redirect") {
+		return
+	}
+
+	if name.ValueString() != "." {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("name"), ErrCodeConfigInvalid, "Invalid Redirect Zone Name",
+			fmt.Sprintf("A redirect zone must be named \".\", and this zone is named %q. Set name to \".\" and put the "+
+				"redirect records in it, such as a wildcard A record.", name.ValueString()))
+	}
+}
+
+// journalSize matches a max-journal-size value
+var journalSize = regexp.MustCompile(`^(?i:unlimited|default|[0-9]+[kmg]?)$`)
+
+// dnssecPolicyName matches the name of a dnssec-policy statement
+var dnssecPolicyName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// checkSigning rejects signing attributes on zones without data of their
+// own, and a dnssec_policy on a slave zone without inline signing, since
+// the transferred zone can only be signed into a separate copy
+func (r *ZoneResource) checkSigning(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, policy types.String
+	var inlineSigning types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("dnssec_policy"), &policy)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("inline_signing"), &inlineSigning)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() {
+		return
+	}
+
+	switch strings.ToLower(zoneType.ValueString()) {
+	case "forward", "stub", "static-stub":
+		if !policy.IsNull() {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("dnssec_policy"), ErrCodeConfigInvalid, "Unexpected DNSSEC Policy",
+				fmt.Sprintf("Zones of type %q hold no data to sign. Remove dnssec_policy.", zoneType.ValueString()))
+		}
+		if !inlineSigning.IsNull() {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("inline_signing"), ErrCodeConfigInvalid, "Unexpected Inline Signing",
+				fmt.Sprintf("Zones of type %q hold no data to sign. Remove inline_signing.", zoneType.ValueString()))
+		}
+	case "slave", "secondary":
+		if policy.IsNull() || policy.IsUnknown() || policy.ValueString() == "none" || inlineSigning.IsUnknown() {
+			return
+		}
+		if !inlineSigning.ValueBool() {
+			addCodedAttributeError(&resp.Diagnostics, path.Root("inline_signing"), ErrCodeConfigInvalid, "Inline Signing Required",
+				"A slave zone can only be signed with a dnssec_policy into a separate signed copy of the transferred zone. Set inline_signing to true.")
+		}
+	}
+}
+
+// maxLoadKeysMinutes is the longest dnssec-loadkeys-interval BIND9 accepts
+const maxLoadKeysMinutes = 1440
+
+// checkKeyMaintenance validates the DNSSEC key maintenance options. BIND9
+// refuses auto-dnssec together with a dnssec-policy, and only maintains
+// keys of a zone it can change: a dynamic or inline-signed one.
+func (r *ZoneResource) checkKeyMaintenance(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, policy, keyDirectory, autoDNSSEC types.String
+	var inlineSigning types.Bool
+	var loadKeys types.Int64
+	var allowUpdate, updateKeys types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("dnssec_policy"), &policy)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("inline_signing"), &inlineSigning)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("key_directory"), &keyDirectory)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auto_dnssec"), &autoDNSSEC)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("dnssec_loadkeys_interval"), &loadKeys)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_update"), &allowUpdate)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_update_keys"), &updateKeys)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() {
+		return
+	}
+
+	switch strings.ToLower(zoneType.ValueString()) {
+	case "forward", "stub", "static-stub":
+		for _, opt := range []struct {
+			name string
+			set  bool
+		}{
+			{"key_directory", !keyDirectory.IsNull()},
+			{"auto_dnssec", !autoDNSSEC.IsNull()},
+			{"dnssec_loadkeys_interval", !loadKeys.IsNull()},
+		} {
+			if opt.set {
+				addCodedAttributeError(&resp.Diagnostics, path.Root(opt.name), ErrCodeConfigInvalid, "Unexpected DNSSEC Option",
+					fmt.Sprintf("Zones of type %q hold no data to sign. Remove %s.", zoneType.ValueString(), opt.name))
+			}
+		}
+		return
+	}
+
+	if autoDNSSEC.IsUnknown() || policy.IsUnknown() {
+		return
+	}
+	maintained := autoDNSSEC.ValueString() == "maintain" || (!policy.IsNull() && policy.ValueString() != "none")
+	if !loadKeys.IsNull() && !maintained {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("dnssec_loadkeys_interval"), ErrCodeConfigInvalid, "Keys Not Maintained",
+			`dnssec_loadkeys_interval only applies to zones whose keys are maintained. Set auto_dnssec to "maintain" or set dnssec_policy, or remove dnssec_loadkeys_interval.`)
+	}
+
+	if autoDNSSEC.IsNull() || autoDNSSEC.ValueString() == "off" {
+		return
+	}
+	if !policy.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("auto_dnssec"), ErrCodeConfigInvalid, "Conflicting DNSSEC Options",
+			"BIND9 does not accept auto_dnssec together with dnssec_policy, which maintains the keys itself. Remove one of them.")
+		return
+	}
+	if inlineSigning.IsUnknown() || allowUpdate.IsUnknown() || updateKeys.IsUnknown() || inlineSigning.ValueBool() {
+		return
+	}
+	if transfersFromPrimaries(zoneType.ValueString()) {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("inline_signing"), ErrCodeConfigInvalid, "Inline Signing Required",
+			"A slave zone can only be signed with auto_dnssec into a separate signed copy of the transferred zone. Set inline_signing to true.")
+		return
+	}
+	if allowUpdate.IsNull() && updateKeys.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("auto_dnssec"), ErrCodeConfigInvalid, "Zone Cannot Be Signed",
+			"BIND9 only signs a zone with auto_dnssec if it accepts dynamic updates or is signed inline. "+
+				"Set inline_signing to true, or allow updates with allow_update or allow_update_keys.")
+	}
+}
+
+// checkSerialFormat rejects serial_format on zones whose serial is set by
+// another server
+func (r *ZoneResource) checkSerialFormat(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, serialFormat types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("serial_format"), &serialFormat)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() || serialFormat.IsNull() {
+		return
+	}
+
+	switch strings.ToLower(zoneType.ValueString()) {
+	case "master", "primary":
+		return
+	}
+	addCodedAttributeError(&resp.Diagnostics, path.Root("serial_format"), ErrCodeConfigInvalid, "Unexpected Serial Format",
+		fmt.Sprintf("The serial of a zone of type %q is not bumped by this server. Remove serial_format, or set it on the master zone.", zoneType.ValueString()))
+}
+
+// checkFileFormat rejects masterfile_format on forward and static-stub zones,
+// which have no zone file, and warns about the map format, which BIND 9.18 removed
+func (r *ZoneResource) checkFileFormat(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType, format types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("masterfile_format"), &format)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() || format.IsNull() || format.IsUnknown() {
+		return
+	}
+
+	switch strings.ToLower(zoneType.ValueString()) {
+	case "forward", "static-stub":
+		addCodedAttributeError(&resp.Diagnostics, path.Root("masterfile_format"), ErrCodeConfigInvalid, "Unexpected Zone File Format",
+			fmt.Sprintf("Zones of type %q have no zone file. Remove masterfile_format.", zoneType.ValueString()))
+		return
+	}
+
+	if format.ValueString() == "map" {
+		resp.Diagnostics.AddAttributeWarning(path.Root("masterfile_format"), "Deprecated Zone File Format",
+			"The map format was deprecated in BIND 9.16 and removed in BIND 9.18, which refuses to load zones configured with it. "+
+				"Use raw for fast loading instead.")
+	}
+}
+
+// checkNotifySource rejects a notify_source that is not an IP address
+func (r *ZoneResource) checkNotifySource(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var source types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("notify_source"), &source)...)
+	if resp.Diagnostics.HasError() || source.IsNull() || source.IsUnknown() {
+		return
+	}
+
+	if _, err := netip.ParseAddr(source.ValueString()); err != nil {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("notify_source"), ErrCodeConfigInvalid, "Invalid NOTIFY Source",
+			fmt.Sprintf("%q is not an IPv4 or IPv6 address. Set notify_source to an address of the server.", source.ValueString()))
+	}
+}
+
+// checkTransferSource checks the transfer source addresses of a zone. Only
+// slave and stub zones are transferred from primaries, and each attribute
+// takes an address of its own family.
+func (r *ZoneResource) checkTransferSource(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, attr := range []struct {
+		name   string
+		family string
+		ipv6   bool
+	}{
+		{"transfer_source", "IPv4", false},
+		{"transfer_source_v6", "IPv6", true},
+	} {
+		var source types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(attr.name), &source)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if source.IsNull() || source.IsUnknown() {
+			continue
+		}
+
+		if !zoneType.IsUnknown() && !transfersFromPrimaries(zoneType.ValueString()) {
+			addCodedAttributeError(&resp.Diagnostics, path.Root(attr.name), ErrCodeConfigInvalid, "Unexpected Transfer Source",
+				fmt.Sprintf("Zone type %q is not transferred from primary servers. Remove %s, or change type to slave or stub.",
+					zoneType.ValueString(), attr.name))
+			continue
+		}
+
+		addr, err := netip.ParseAddr(source.ValueString())
+		if err != nil || addr.Is6() != attr.ipv6 {
+			addCodedAttributeError(&resp.Diagnostics, path.Root(attr.name), ErrCodeConfigInvalid, "Invalid Transfer Source",
+				fmt.Sprintf("%q is not an %s address. Set %s to an %s address of the server.",
+					source.ValueString(), attr.family, attr.name, attr.family))
+		}
+	}
+}
+
+// maxTransferMinutes is the longest transfer limit BIND9 accepts, 28 days
+const maxTransferMinutes = 40320
+
+// checkTransferTuning rejects inbound transfer limits on zones that are not
+// transferred from primaries. transfer_format applies to the transfers a zone
+// sends, so it is allowed on any zone.
+func (r *ZoneResource) checkTransferTuning(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType types.String
+	var maxTime, maxIdle types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_transfer_time_in"), &maxTime)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("max_transfer_idle_in"), &maxIdle)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() || transfersFromPrimaries(zoneType.ValueString()) {
+		return
+	}
+
+	for _, opt := range []struct {
+		name string
+		set  bool
+	}{
+		{"max_transfer_time_in", !maxTime.IsNull()},
+		{"max_transfer_idle_in", !maxIdle.IsNull()},
+	} {
+		if opt.set {
+			addCodedAttributeError(&resp.Diagnostics, path.Root(opt.name), ErrCodeConfigInvalid, "Unexpected Transfer Option",
+				fmt.Sprintf("Zone type %q is not transferred from primary servers. Remove %s, or change type to slave or stub.",
+					zoneType.ValueString(), opt.name))
+		}
+	}
+}
+
+// transfersFromPrimaries reports whether zones of a type are transferred
+// from primary servers
+func transfersFromPrimaries(zoneType string) bool {
+	switch strings.ToLower(zoneType) {
+	case "slave", "secondary", "stub":
+		return true
+	}
+	return false
+}
+
+// checkInitialContent checks the content a zone is planned to be created
+// with. Later changes to it are not applied, so only creation is checked.
+func (r *ZoneResource) checkInitialContent(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.State.Raw.IsNull() {
+		return
+	}
+
+	var zoneType, content types.String
+	var records types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("initial_records"), &records)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("zone_file_content"), &content)...)
+	if resp.Diagnostics.HasError() || zoneType.IsUnknown() || (records.IsNull() && content.IsNull()) {
+		return
+	}
+
+	if !records.IsNull() && !content.IsNull() {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("zone_file_content"), ErrCodeConfigInvalid, "Conflicting Initial Content",
+			"A zone is created either from zone_file_content or with initial_records, not both. "+
+				"Add the initial_records to the zone file content, or remove zone_file_content.")
+		return
+	}
+
+	switch strings.ToLower(zoneType.ValueString()) {
+	case "master", "primary":
+	default:
+		addCodedError(&resp.Diagnostics, ErrCodeConfigInvalid, "Unexpected Initial Content",
+			fmt.Sprintf("Only master zones are created with content of their own, and this zone has type %q. "+
+				"Remove initial_records and zone_file_content.", zoneType.ValueString()))
+		return
+	}
+
+	// Records can be created one by one after the zone, a zone file cannot
+	if !content.IsNull() && !r.client.SupportsFeature(FeatureZoneBootstrap) {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("zone_file_content"), ErrCodeFeatureUnsupported, "Zone File Content Not Supported",
+			fmt.Sprintf("The BIND9 REST API server (version %s) cannot create a zone from zone file content. Use initial_records instead.",
+				r.client.ServerVersion()))
+	}
+}
+
+// initialRecords returns the records a zone is created with, named relative
+// to the zone and defaulting to its default TTL
+func initialRecords(ctx context.Context, plan *ZoneResourceModel) ([]RecordCreateRequest, diag.Diagnostics) {
+	if plan.InitialRecords.IsNull() {
+		return nil, nil
+	}
+
+	var records []BatchRecordModel
+	diags := plan.InitialRecords.ElementsAs(ctx, &records, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	zone := plan.Name.ValueString()
+	result := make([]RecordCreateRequest, 0, len(records))
+	for _, rec := range records {
+		ttl := plan.DefaultTTL.ValueInt64()
+		if !rec.TTL.IsNull() {
+			ttl = rec.TTL.ValueInt64()
+		}
+		recordType := strings.ToUpper(rec.Type.ValueString())
+		result = append(result, RecordCreateRequest{
+			RecordType:  recordType,
+			Name:        apexName(zone, rec.Name.ValueString()),
+			TTL:         int(ttl),
+			RecordClass: plan.Class.ValueString(),
+			Data:        buildRecordData(recordType, rec.RData.ValueString()),
+			RData:       rec.RData.ValueString(),
+		})
+	}
+	return result, diags
+}
+
+// createInitialRecords creates the initial records of a zone one by one,
+// for servers that cannot create a zone with its records
+func (r *ZoneResource) createInitialRecords(ctx context.Context, zone string, records []RecordCreateRequest, diags *diag.Diagnostics) {
+	r.client.beginZoneChange(ctx, zone)
+	defer r.client.endZoneChange(ctx, zone)
+
+	for i := range records {
+		if _, err := r.client.CreateRecord(withETag(ctx, nil), zone, &records[i]); err != nil {
+			addRecordAPIError(diags, "Error Creating Initial Record",
+				fmt.Sprintf("Could not create record %s %s in zone %s", records[i].Name, records[i].RecordType, zone), err)
+			return
+		}
+	}
+}
+
+// zonePrimaries returns the primary servers of a zone for the API
+func zonePrimaries(ctx context.Context, primaries types.List) ([]ZonePrimary, diag.Diagnostics) {
+	if primaries.IsNull() {
+		return nil, nil
+	}
+
+	var servers []PrimaryModel
+	diags := primaries.ElementsAs(ctx, &servers, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	result := make([]ZonePrimary, 0, len(servers))
+	for _, p := range servers {
+		result = append(result, ZonePrimary{
+			Address: p.Address.ValueString(),
+			Port:    int(p.Port.ValueInt64()),
+			Key:     p.Key.ValueString(),
+		})
+	}
+	return result, diags
+}
+
+// updatePermissions returns the allow-update ACL and update-policy grants of
+// a zone. Keys in allow_update_keys are added to allow-update, unless any
+// of them is scoped, in which case all of them become update-policy grants.
+func updatePermissions(ctx context.Context, plan *ZoneResourceModel) (allowUpdate, updatePolicy []string, diags diag.Diagnostics) {
+	if !plan.AllowUpdate.IsNull() {
+		diags.Append(plan.AllowUpdate.ElementsAs(ctx, &allowUpdate, false)...)
+		if diags.HasError() {
+			return nil, nil, diags
+		}
+	}
+	if plan.UpdateKeys.IsNull() {
+		return allowUpdate, nil, diags
+	}
+
+	var keys []UpdateKeyModel
+	diags.Append(plan.UpdateKeys.ElementsAs(ctx, &keys, false)...)
+	if diags.HasError() {
+		return nil, nil, diags
+	}
+
+	if hasScopedUpdateKey(keys) {
+		grants, d := buildUpdatePolicy(ctx, plan.Name.ValueString(), keys)
+		diags.Append(d...)
+		return allowUpdate, grants, diags
+	}
+	for _, k := range keys {
+		allowUpdate = append(allowUpdate, "key "+k.Key.ValueString())
+	}
+	return allowUpdate, nil, diags
+}
+
+// zoneOptionsUpdate returns the zone options request for the ACLs, primaries,
+// forwarding, signing, serial, name checking, journal and notify settings
+// that differ between state and plan, or nil if none do. A removed
+// ACL is sent as an empty list, which clears it on the server.
+func zoneOptionsUpdate(ctx context.Context, plan, state *ZoneResourceModel) (*ZoneUpdateRequest, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	update := &ZoneUpdateRequest{}
+	changed := false
+
+	if !plan.AllowTransfer.Equal(state.AllowTransfer) {
+		var allowTransfer []string
+		if !plan.AllowTransfer.IsNull() {
+			diags.Append(plan.AllowTransfer.ElementsAs(ctx, &allowTransfer, false)...)
+		}
+		update.AllowTransfer = optionList(allowTransfer)
+		changed = true
+	}
+
+	if !plan.AllowQuery.Equal(state.AllowQuery) {
+		var allowQuery []string
+		if !plan.AllowQuery.IsNull() {
+			diags.Append(plan.AllowQuery.ElementsAs(ctx, &allowQuery, false)...)
+		}
+		update.AllowQuery = optionList(allowQuery)
+		changed = true
+	}
+
+	// Both are sent, so that moving between allow-update and update-policy
+	// clears the one no longer used. Grants name the zone, so a renamed zone
+	// gets them again.
+	if !plan.AllowUpdate.Equal(state.AllowUpdate) || !plan.UpdateKeys.Equal(state.UpdateKeys) ||
+		(!plan.Name.Equal(state.Name) && !plan.UpdateKeys.IsNull()) {
+		allowUpdate, updatePolicy, d := updatePermissions(ctx, plan)
+		diags.Append(d...)
+		update.AllowUpdate = optionList(allowUpdate)
+		update.UpdatePolicy = optionList(updatePolicy)
+		changed = true
+	}
+
+	if !plan.Primaries.Equal(state.Primaries) {
+		primaries, d := zonePrimaries(ctx, plan.Primaries)
+		diags.Append(d...)
+		if primaries == nil {
+			primaries = []ZonePrimary{}
+		}
+		update.Primaries = &primaries
+		changed = true
+	}
+
+	if !plan.Forwarders.Equal(state.Forwarders) {
+		var forwarders []string
+		if !plan.Forwarders.IsNull() {
+			diags.Append(plan.Forwarders.ElementsAs(ctx, &forwarders, false)...)
+		}
+		update.Forwarders = optionList(forwarders)
+		changed = true
+	}
+
+	// An unset mode is BIND9's default, first
+	if !plan.Forward.Equal(state.Forward) {
+		forward := "first"
+		if !plan.Forward.IsNull() {
+			forward = plan.Forward.ValueString()
+		}
+		update.Forward = &forward
+		changed = true
+	}
+
+	if !plan.ServerAddresses.Equal(state.ServerAddresses) {
+		var addresses []string
+		if !plan.ServerAddresses.IsNull() {
+			diags.Append(plan.ServerAddresses.ElementsAs(ctx, &addresses, false)...)
+		}
+		update.ServerAddresses = optionList(addresses)
+		changed = true
+	}
+
+	if !plan.ServerNames.Equal(state.ServerNames) {
+		var names []string
+		if !plan.ServerNames.IsNull() {
+			diags.Append(plan.ServerNames.ElementsAs(ctx, &names, false)...)
+		}
+		update.ServerNames = optionList(names)
+		changed = true
+	}
+
+	// Removing the attributes turns inline signing off and drops the policy
+	if !plan.InlineSigning.Equal(state.InlineSigning) {
+		inlineSigning := plan.InlineSigning.ValueBool()
+		update.InlineSigning = &inlineSigning
+		changed = true
+	}
+
+	if !plan.DNSSECPolicy.Equal(state.DNSSECPolicy) {
+		policy := plan.DNSSECPolicy.ValueString()
+		update.DNSSECPolicy = &policy
+		changed = true
+	}
+
+	// Removing the attributes returns them to the server's settings, and
+	// zero does so for the interval
+	if !plan.KeyDirectory.Equal(state.KeyDirectory) {
+		keyDirectory := plan.KeyDirectory.ValueString()
+		update.KeyDirectory = &keyDirectory
+		changed = true
+	}
+
+	if !plan.AutoDNSSEC.Equal(state.AutoDNSSEC) {
+		autoDNSSEC := "off"
+		if !plan.AutoDNSSEC.IsNull() {
+			autoDNSSEC = plan.AutoDNSSEC.ValueString()
+		}
+		update.AutoDNSSEC = &autoDNSSEC
+		changed = true
+	}
+
+	if !plan.LoadKeysPeriod.Equal(state.LoadKeysPeriod) {
+		interval := plan.LoadKeysPeriod.ValueInt64()
+		update.LoadKeysInterval = &interval
+		changed = true
+	}
+
+	// An unset format is BIND9's default, increment
+	if !plan.SerialFormat.Equal(state.SerialFormat) {
+		serialFormat := "increment"
+		if !plan.SerialFormat.IsNull() {
+			serialFormat = plan.SerialFormat.ValueString()
+		}
+		update.SerialFormat = &serialFormat
+		changed = true
+	}
+
+	// Removing the attributes returns them to the server's settings
+	if !plan.CheckNames.Equal(state.CheckNames) {
+		checkNames := plan.CheckNames.ValueString()
+		update.CheckNames = &checkNames
+		changed = true
+	}
+
+	if !plan.MaxJournal.Equal(state.MaxJournal) {
+		maxJournal := plan.MaxJournal.ValueString()
+		update.MaxJournalSize = &maxJournal
+		changed = true
+	}
+
+	if !plan.Journal.Equal(state.Journal) {
+		journal := plan.Journal.ValueString()
+		update.Journal = &journal
+		changed = true
+	}
+
+	// The server rewrites the zone file in the new format
+	if !plan.FileFormat.Equal(state.FileFormat) {
+		format := "text"
+		if !plan.FileFormat.IsNull() {
+			format = plan.FileFormat.ValueString()
+		}
+		update.MasterfileFormat = &format
+		changed = true
+	}
+
+	if !plan.ResponsePolicy.Equal(state.ResponsePolicy) {
+		policy, d := responsePolicy(ctx, plan.ResponsePolicy)
+		diags.Append(d...)
+		update.ResponsePolicy = &policy
+		changed = true
+	}
+
+	if !plan.Notify.Equal(state.Notify) {
+		notify := NotifyMode(plan.Notify.ValueString())
+		update.Notify = &notify
+		changed = true
+	}
+
+	if !plan.NotifySource.Equal(state.NotifySource) {
+		source := plan.NotifySource.ValueString()
+		update.NotifySource = &source
+		changed = true
+	}
+
+	if !plan.TransferSource.Equal(state.TransferSource) {
+		source := plan.TransferSource.ValueString()
+		update.TransferSource = &source
+		changed = true
+	}
+
+	if !plan.TransferSource6.Equal(state.TransferSource6) {
+		source := plan.TransferSource6.ValueString()
+		update.TransferSourceV6 = &source
+		changed = true
+	}
+
+	// Zero returns a transfer limit to the server's setting
+	if !plan.MaxTransferTime.Equal(state.MaxTransferTime) {
+		maxTime := plan.MaxTransferTime.ValueInt64()
+		update.MaxTransferTime = &maxTime
+		changed = true
+	}
+
+	if !plan.MaxTransferIdle.Equal(state.MaxTransferIdle) {
+		maxIdle := plan.MaxTransferIdle.ValueInt64()
+		update.MaxTransferIdle = &maxIdle
+		changed = true
+	}
+
+	if !plan.TransferFormat.Equal(state.TransferFormat) {
+		format := plan.TransferFormat.ValueString()
+		update.TransferFormat = &format
+		changed = true
+	}
+
+	if soaUpdate(plan.soa(), state.soa(), update) {
+		changed = true
+	}
+
+	if diags.HasError() || !changed {
+		return nil, diags
+	}
+	return update, diags
+}
+
+// optionList returns a pointer to a list for a zone options request, with
+// nil turned into an empty list
+func optionList(values []string) *[]string {
+	if values == nil {
+		values = []string{}
+	}
+	return &values
+}
+
+// hasScopedUpdateKey reports whether any update key is limited by names or types
+func hasScopedUpdateKey(keys []UpdateKeyModel) bool {
+	for _, k := range keys {
+		if len(k.Names.Elements()) > 0 || len(k.Types.Elements()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// buildUpdatePolicy translates update keys into update-policy grant rules
+func buildUpdatePolicy(ctx context.Context, zone string, keys []UpdateKeyModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var grants []string
+
+	for _, k := range keys {
+		var names, recordTypes []string
+		diags.Append(k.Names.ElementsAs(ctx, &names, false)...)
+		diags.Append(k.Types.ElementsAs(ctx, &recordTypes, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		typeList := "ANY"
+		if len(recordTypes) > 0 {
+			typeList = strings.ToUpper(strings.Join(recordTypes, " "))
+		}
+
+		if len(names) == 0 {
+			grants = append(grants, fmt.Sprintf("grant %s zonesub %s;", k.Key.ValueString(), typeList))
+			continue
+		}
+
+		for _, name := range names {
+			ruleType := "name"
+			if strings.HasPrefix(name, "*.") {
+				ruleType = "wildcard"
+			}
+			grants = append(grants, fmt.Sprintf("grant %s %s %s %s;", k.Key.ValueString(), ruleType, ownerName(zone, name), typeList))
+		}
+	}
+
+	return grants, diags
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *ZoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := startSpan(ctx, "bind9_zone.Create")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan ZoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating zone", map[string]any{"name": plan.Name.ValueString(), "view": plan.View.ValueString()})
+	ctx = withView(ctx, plan.View.ValueString())
+
+	// Build create request
+	createReq := &ZoneCreateRequest{
+		Name:       plan.Name.ValueString(),
+		View:       plan.View.ValueString(),
+		Type:       plan.Type.ValueString(),
+		SOAMname:   plan.SOAMname.ValueString(),
+		SOARname:   plan.SOARname.ValueString(),
+		SOARefresh: int(plan.SOARefresh.ValueInt64()),
+		SOARetry:   int(plan.SOARetry.ValueInt64()),
+		SOAExpire:  int(plan.SOAExpire.ValueInt64()),
+		SOAMinimum: int(plan.SOAMinimum.ValueInt64()),
+		DefaultTTL: int(plan.DefaultTTL.ValueInt64()),
+	}
+
+	// Only send non-default classes so older API versions keep working
+	if plan.Class.ValueString() != "IN" {
+		createReq.Class = plan.Class.ValueString()
+	}
+
+	// Convert ns_addresses map
+	if !plan.NSAddresses.IsNull() {
+		nsAddresses := make(map[string]string)
+		diags = plan.NSAddresses.ElementsAs(ctx, &nsAddresses, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createReq.NSAddresses = nsAddresses
+	}
+
+	if !plan.File.IsNull() {
+		createReq.File = plan.File.ValueString()
+	}
+
+	// Convert nameservers
+	if !plan.Nameservers.IsNull() {
+		var nameservers []string
+		diags = plan.Nameservers.ElementsAs(ctx, &nameservers, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createReq.Nameservers = nameservers
+	}
+
+	// Build options (allow_update, allow_transfer, allow_query)
+	options := &ZoneOptions{}
+	hasOptions := false
+
+	// Unscoped keys extend allow_update; scoped keys need an update-policy
+	allowUpdate, updatePolicy, diags := updatePermissions(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	options.AllowUpdate = allowUpdate
+	options.UpdatePolicy = updatePolicy
+	hasOptions = !plan.AllowUpdate.IsNull() || len(allowUpdate) > 0 || len(updatePolicy) > 0
+
+	if !plan.AllowTransfer.IsNull() {
+		var allowTransfer []string
+		diags = plan.AllowTransfer.ElementsAs(ctx, &allowTransfer, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		options.AllowTransfer = allowTransfer
+		hasOptions = true
+	}
+
+	if !plan.AllowQuery.IsNull() {
+		var allowQuery []string
+		diags = plan.AllowQuery.ElementsAs(ctx, &allowQuery, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		options.AllowQuery = allowQuery
+		hasOptions = true
+	}
+
+	primaries, diags := zonePrimaries(ctx, plan.Primaries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(primaries) > 0 {
+		options.Primaries = primaries
+		hasOptions = true
+	}
+
+	if !plan.Forwarders.IsNull() {
+		var forwarders []string
+		diags = plan.Forwarders.ElementsAs(ctx, &forwarders, false)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// An empty list is sent, so that the zone overrides the global forwarders
+		options.Forwarders = optionList(forwarders)
+		options.Forward = plan.Forward.ValueString()
+		hasOptions = true
+	}
+
+	for _, servers := range []struct {
+		list   types.List
+		target *[]string
+	}{
+		{plan.ServerAddresses, &options.ServerAddresses},
+		{plan.ServerNames, &options.ServerNames},
+	} {
+		if servers.list.IsNull() {
+			continue
+		}
+		resp.Diagnostics.Append(servers.list.ElementsAs(ctx, servers.target, false)...)
+		hasOptions = true
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.InlineSigning.IsNull() {
+		inlineSigning := plan.InlineSigning.ValueBool()
+		options.InlineSigning = &inlineSigning
+		hasOptions = true
+	}
+	if !plan.KeyDirectory.IsNull() {
+		options.KeyDirectory = plan.KeyDirectory.ValueString()
+		hasOptions = true
+	}
+	if !plan.AutoDNSSEC.IsNull() {
+		options.AutoDNSSEC = plan.AutoDNSSEC.ValueString()
+		hasOptions = true
+	}
+	if !plan.LoadKeysPeriod.IsNull() {
+		options.LoadKeysInterval = plan.LoadKeysPeriod.ValueInt64()
+		hasOptions = true
+	}
+	if !plan.DNSSECPolicy.IsNull() {
+		options.DNSSECPolicy = plan.DNSSECPolicy.ValueString()
+		hasOptions = true
+	}
+
+	if !plan.SerialFormat.IsNull() {
+		options.SerialFormat = plan.SerialFormat.ValueString()
+		hasOptions = true
+	}
+
+	if !plan.CheckNames.IsNull() {
+		options.CheckNames = plan.CheckNames.ValueString()
+		hasOptions = true
+	}
+	if !plan.MaxJournal.IsNull() {
+		options.MaxJournalSize = plan.MaxJournal.ValueString()
+		hasOptions = true
+	}
+	if !plan.Journal.IsNull() {
+		options.Journal = plan.Journal.ValueString()
+		hasOptions = true
+	}
+	if !plan.FileFormat.IsNull() {
+		options.MasterfileFormat = plan.FileFormat.ValueString()
+		hasOptions = true
+	}
+	if !plan.ResponsePolicy.IsNull() {
+		policy, diags := responsePolicy(ctx, plan.ResponsePolicy)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		options.ResponsePolicy = policy
+		hasOptions = true
+	}
+
+	records, diags := initialRecords(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	bootstrap := r.client.SupportsFeature(FeatureZoneBootstrap)
+	if bootstrap {
+		createReq.Records = records
+		createReq.ZoneFileContent = plan.ZoneFileContent.ValueString()
+	}
+
+	// yes is the server's default
+	if plan.Notify.ValueString() != notifyYes {
+		options.Notify = NotifyMode(plan.Notify.ValueString())
+		hasOptions = true
+	}
+	if !plan.NotifySource.IsNull() {
+		options.NotifySource = plan.NotifySource.ValueString()
+		hasOptions = true
+	}
+	if !plan.TransferSource.IsNull() {
+		options.TransferSource = plan.TransferSource.ValueString()
+		hasOptions = true
+	}
+	if !plan.TransferSource6.IsNull() {
+		options.TransferSourceV6 = plan.TransferSource6.ValueString()
+		hasOptions = true
+	}
+	if !plan.MaxTransferTime.IsNull() {
+		options.MaxTransferTime = plan.MaxTransferTime.ValueInt64()
+		hasOptions = true
+	}
+	if !plan.MaxTransferIdle.IsNull() {
+		options.MaxTransferIdle = plan.MaxTransferIdle.ValueInt64()
+		hasOptions = true
+	}
+	if !plan.TransferFormat.IsNull() {
+		options.TransferFormat = plan.TransferFormat.ValueString()
+		hasOptions = true
+	}
+
+	if hasOptions {
+		createReq.Options = options
+	}
+
+	// Create zone
+	zone, err := r.client.CreateZone(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Reading Zone",
-			"Could not read zone after update: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Creating Zone", "Could not create zone", err)
 		return
 	}
 
-	// Update all computed fields
+	// Set state
+	plan.ID = types.StringValue(zoneID(plan.View.ValueString(), zone.Name))
 	plan.Serial = types.Int64Value(zone.Serial)
 	plan.Loaded = types.BoolValue(zone.Loaded)
 	plan.DNSSECEnabled = types.BoolValue(zone.DNSSECEnabled)
 	if zone.File != "" {
 		plan.File = types.StringValue(zone.File)
+	} else if plan.File.IsUnknown() {
+		// Stub zones may be kept in memory only
+		plan.File = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+
+	// Without the bootstrap feature the records follow the zone. If one
+	// fails, Terraform taints the zone, and the next apply creates it again
+	// with all of its records.
+	if !bootstrap && len(records) > 0 {
+		r.createInitialRecords(ctx, zone.Name, records, &resp.Diagnostics)
+		resp.Diagnostics.Append(r.client.persistSquelch(ctx, resp.Private)...)
+	}
+
+	r.notifyChange(ctx, plan.OnChange, ZoneChangeEvent{
+		Zone:   zone.Name,
+		View:   plan.View.ValueString(),
+		Serial: zone.Serial,
+		Action: "create",
+	}, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *ZoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := startSpan(ctx, "bind9_zone.Read")
+	defer endOperationSpan(span, &resp.Diagnostics)
+
+	var state ZoneResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.client.restoreSquelch(ctx, resp.Private)...)
+
+	tflog.Debug(ctx, "Reading zone", map[string]any{"name": state.Name.ValueString(), "view": state.View.ValueString()})
+	ctx = withView(ctx, state.View.ValueString())
+
+	zone, err := r.client.GetZone(ctx, state.Name.ValueString())
+	if err != nil {
+		if isNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		addAPIError(&resp.Diagnostics, "Error Reading Zone", "Could not read zone", err)
+		return
+	}
+
+	// Update state with API response values. The serial is only read for
+	// imported zones; see planZoneSerial.
+	if state.Serial.IsNull() {
+		state.Serial = types.Int64Value(zone.Serial)
+	}
+	state.Loaded = types.BoolValue(zone.Loaded)
+	state.DNSSECEnabled = types.BoolValue(zone.DNSSECEnabled)
+	if zone.File != "" {
+		state.File = types.StringValue(zone.File)
+	}
+	if zone.Class != "" || state.Class.IsNull() {
+		state.Class = types.StringValue(zoneClass(*zone))
+	}
+	if zone.Type != "" {
+		// Normalize zone type (BIND9 uses "primary"/"secondary" in newer versions,
+		// but "master"/"slave" are still commonly used synonyms)
+		zoneType := zone.Type
+		switch zoneType {
+		case "primary":
+			zoneType = "master"
+		case "secondary":
+			zoneType = "slave"
+		}
+		state.Type = types.StringValue(zoneType)
+	}
+
+	checkLeftoverFreeze(ctx, r.client, zone, &resp.Diagnostics)
+
+	// Only master zones write their own SOA; the others load it
+	switch strings.ToLower(state.Type.ValueString()) {
+	case "master", "primary":
+		readZoneSOA(ctx, r.client, state.Name.ValueString(), state.soa(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state
+func (r *ZoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := startSpan(ctx, "bind9_zone.Update")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
+	var plan ZoneResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state ZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating zone", map[string]any{"name": plan.Name.ValueString(), "view": plan.View.ValueString()})
+	ctx = withView(ctx, plan.View.ValueString())
+
+	update, diags := zoneOptionsUpdate(ctx, &plan, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Zone changes are not guarded by an ETag: records changed earlier in
+	// the same apply bump the serial, and with it the zone's ETag
+	if !plan.Name.Equal(state.Name) {
+		if _, err := r.client.RenameZone(ctx, state.Name.ValueString(), plan.Name.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Renaming Zone",
+				fmt.Sprintf("Could not rename zone %s to %s", state.Name.ValueString(), plan.Name.ValueString()), err)
+			return
+		}
+	}
+	if update != nil {
+		if _, err := r.client.UpdateZone(ctx, plan.Name.ValueString(), update); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Updating Zone", "Could not update zone options", err)
+			return
+		}
+	}
+
+	// Reload zone to apply changes. BIND9 only reloads a dynamic zone while
+	// it is frozen.
+	r.client.freezer.begin(ctx, plan.Name.ValueString())
+	err := r.client.ReloadZone(ctx, plan.Name.ValueString())
+	r.client.freezer.end(ctx, plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Updating Zone", "Could not reload zone", err)
+		return
+	}
+
+	// Read back the zone
+	zone, err := r.client.GetZone(ctx, plan.Name.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Zone", "Could not read zone after update", err)
+		return
+	}
+
+	// Update all computed fields. The serial is only read when the plan left
+	// it unknown; see planZoneSerial.
+	if plan.Serial.IsUnknown() {
+		plan.Serial = types.Int64Value(zone.Serial)
+	}
+	plan.Loaded = types.BoolValue(zone.Loaded)
+	plan.DNSSECEnabled = types.BoolValue(zone.DNSSECEnabled)
+	if zone.File != "" {
+		plan.File = types.StringValue(zone.File)
+	} else if plan.File.IsUnknown() {
+		// Stub zones may be kept in memory only
+		plan.File = types.StringNull()
 	}
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+
+	r.notifyChange(ctx, plan.OnChange, ZoneChangeEvent{
+		Zone:    plan.Name.ValueString(),
+		View:    plan.View.ValueString(),
+		Serial:  zone.Serial,
+		Action:  "update",
+		Changes: changedAttributes(req.State.Raw, req.Plan.Raw),
+	}, &resp.Diagnostics)
 }
 
 // Delete deletes the resource
 func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := startSpan(ctx, "bind9_zone.Delete")
+	defer endOperationSpan(span, &resp.Diagnostics)
+	defer beginApplyOperation()()
+
 	var state ZoneResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -468,24 +2137,84 @@ func (r *ZoneResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	tflog.Debug(ctx, "Deleting zone", map[string]any{"name": state.Name.ValueString()})
+	tflog.Debug(ctx, "Deleting zone", map[string]any{"name": state.Name.ValueString(), "view": state.View.ValueString()})
+	ctx = withView(ctx, state.View.ValueString())
 
 	deleteFile := false
 	if !state.DeleteFile.IsNull() {
 		deleteFile = state.DeleteFile.ValueBool()
 	}
 
+	// Other zone types hold copies of records managed elsewhere, and the
+	// records of a replaced zone stay for the new one
+	isMaster := strings.EqualFold(state.Type.ValueString(), "master") || strings.EqualFold(state.Type.ValueString(), "primary")
+	if isMaster && !state.ForceDestroy.ValueBool() && !zoneReplaced(ctx, req.Private) {
+		own, diags := zoneOwnRecords(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		checkUnmanagedRecords(ctx, r.client, state.Name.ValueString(), own, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	if err := r.client.DeleteZone(ctx, state.Name.ValueString(), deleteFile); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Zone",
-			"Could not delete zone: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Deleting Zone", "Could not delete zone", err)
+		return
+	}
+
+	r.notifyChange(ctx, state.OnChange, ZoneChangeEvent{
+		Zone:   state.Name.ValueString(),
+		View:   state.View.ValueString(),
+		Serial: state.Serial.ValueInt64(),
+		Action: "delete",
+	}, &resp.Diagnostics)
+}
+
+// notifyChange sends a change event to the zone's on_change_webhook, if set.
+// The change is already applied, so failed deliveries only warn.
+func (r *ZoneResource) notifyChange(ctx context.Context, webhook types.String, event ZoneChangeEvent, diags *diag.Diagnostics) {
+	if webhook.IsNull() || webhook.IsUnknown() {
 		return
 	}
+
+	if err := r.client.NotifyZoneChange(ctx, webhook.ValueString(), event); err != nil {
+		diags.AddAttributeWarning(
+			path.Root("on_change_webhook"),
+			"Zone Change Webhook Failed",
+			fmt.Sprintf("The zone %s was %sd, but the on_change_webhook could not be notified: %s", event.Zone, event.Action, err),
+		)
+	}
 }
 
 // ImportState imports an existing resource into Terraform
 func (r *ZoneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	// Import format: name, or name=...[,class=...][,view=...]
+	values, err := zoneImportID.parse(req.ID)
+	if err != nil {
+		addCodedError(
+			&resp.Diagnostics,
+			ErrCodeConfigInvalid,
+			"Invalid Import ID",
+			fmt.Sprintf("%s (e.g., example.com or name=example.com,class=IN,view=internal)", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), zoneID(values["view"], values["name"]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), values["name"])...)
+	if view := values["view"]; view != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("view"), view)...)
+	}
+	if class := values["class"]; class != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("class"), strings.ToUpper(class))...)
+	}
+}
+
+// zoneImportID is the import ID format of bind9_zone
+var zoneImportID = importID{
+	positional: []string{"name"},
+	optional:   []string{"class", "view"},
 }
@@ -0,0 +1,328 @@
+// rndc control channel client for zone operations
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// rndcAlgorithm describes an HMAC algorithm accepted by the control channel
+type rndcAlgorithm struct {
+	code uint8
+	hash func() hash.Hash
+}
+
+// rndcAlgorithms maps the supported rndc key algorithms to their wire codes
+var rndcAlgorithms = map[string]rndcAlgorithm{
+	"hmac-md5":    {157, md5.New},
+	"hmac-sha1":   {161, sha1.New},
+	"hmac-sha224": {162, sha256.New224},
+	"hmac-sha256": {163, sha256.New},
+	"hmac-sha384": {164, sha512.New384},
+	"hmac-sha512": {165, sha512.New},
+}
+
+// Element types of the control channel message format
+const (
+	rndcTypeBinary = 1
+	rndcTypeTable  = 2
+)
+
+// rndcClient sends commands to named over the rndc control channel
+type rndcClient struct {
+	server    string
+	secret    []byte
+	algorithm rndcAlgorithm
+	timeout   time.Duration
+
+	// Opens the control channel connection; nil dials directly
+	dial func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// newRNDCClient creates a control channel client. The secret is the base64
+// key secret from rndc.key.
+func newRNDCClient(server, keySecret, algorithm string, timeout time.Duration) (*rndcClient, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(strings.Trim(server, "[]"), "953")
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(keySecret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rndc key secret: %w", err)
+	}
+
+	alg, ok := rndcAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unsupported rndc key algorithm %s", algorithm)
+	}
+
+	return &rndcClient{
+		server:    server,
+		secret:    secret,
+		algorithm: alg,
+		timeout:   timeout,
+	}, nil
+}
+
+// rndcEntry is a key/value pair of a control channel table. Tables are
+// ordered, since the signature covers their serialized form.
+type rndcEntry struct {
+	key   string
+	value interface{} // []byte or []rndcEntry
+}
+
+// rndcTable is an ordered control channel table
+type rndcTable []rndcEntry
+
+// get returns the value of a key, or nil if absent
+func (t rndcTable) get(key string) interface{} {
+	for _, e := range t {
+		if e.key == key {
+			return e.value
+		}
+	}
+	return nil
+}
+
+// getString returns a binary value as a string, or "" if absent
+func (t rndcTable) getString(key string) string {
+	if v, ok := t.get(key).([]byte); ok {
+		return string(v)
+	}
+	return ""
+}
+
+// getTable returns a table value, or nil if absent
+func (t rndcTable) getTable(key string) rndcTable {
+	if v, ok := t.get(key).(rndcTable); ok {
+		return v
+	}
+	return nil
+}
+
+// serialize encodes the table, skipping the _auth entry when skipAuth is set
+func (t rndcTable) serialize(skipAuth bool) []byte {
+	var buf bytes.Buffer
+	for _, e := range t {
+		if skipAuth && e.key == "_auth" {
+			continue
+		}
+		buf.WriteByte(byte(len(e.key)))
+		buf.WriteString(e.key)
+
+		var data []byte
+		var typ byte
+		switch v := e.value.(type) {
+		case []byte:
+			typ, data = rndcTypeBinary, v
+		case rndcTable:
+			typ, data = rndcTypeTable, v.serialize(false)
+		}
+		buf.WriteByte(typ)
+		_ = binary.Write(&buf, binary.BigEndian, uint32(len(data)))
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+// parseRNDCTable decodes a serialized table
+func parseRNDCTable(data []byte) (rndcTable, error) {
+	var t rndcTable
+	for len(data) > 0 {
+		keyLen := int(data[0])
+		if len(data) < 1+keyLen+5 {
+			return nil, errors.New("truncated rndc message")
+		}
+		key := string(data[1 : 1+keyLen])
+		typ := data[1+keyLen]
+		valueLen := int(binary.BigEndian.Uint32(data[2+keyLen:]))
+		data = data[6+keyLen:]
+		if len(data) < valueLen {
+			return nil, errors.New("truncated rndc message")
+		}
+		value := data[:valueLen]
+		data = data[valueLen:]
+
+		switch typ {
+		case rndcTypeBinary:
+			t = append(t, rndcEntry{key, value})
+		case rndcTypeTable:
+			sub, err := parseRNDCTable(value)
+			if err != nil {
+				return nil, err
+			}
+			t = append(t, rndcEntry{key, sub})
+		default:
+			// Lists are not used in responses to the commands sent here
+		}
+	}
+	return t, nil
+}
+
+// sign computes the _auth table for a message
+func (r *rndcClient) sign(msg rndcTable) rndcTable {
+	mac := hmac.New(r.algorithm.hash, r.secret)
+	mac.Write(msg.serialize(true))
+	digest := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if r.algorithm.code == rndcAlgorithms["hmac-md5"].code {
+		return rndcTable{{"hmd5", []byte(strings.TrimRight(digest, "="))}}
+	}
+
+	hsha := make([]byte, 89)
+	hsha[0] = r.algorithm.code
+	copy(hsha[1:], digest)
+	return rndcTable{{"hsha", hsha}}
+}
+
+// verify checks the signature of a response
+func (r *rndcClient) verify(msg rndcTable) bool {
+	auth := msg.getTable("_auth")
+	var remote string
+	if v, ok := auth.get("hmd5").([]byte); ok {
+		remote = string(v)
+	} else if v, ok := auth.get("hsha").([]byte); ok && len(v) > 1 {
+		remote = string(v[1:])
+	}
+	remote = strings.TrimRight(remote, "\x00=")
+
+	mac := hmac.New(r.algorithm.hash, r.secret)
+	mac.Write(msg.serialize(true))
+	local := strings.TrimRight(base64.StdEncoding.EncodeToString(mac.Sum(nil)), "=")
+
+	return hmac.Equal([]byte(local), []byte(remote))
+}
+
+// roundTrip sends a signed command on the connection and returns the response
+func (r *rndcClient) roundTrip(conn net.Conn, serial int, nonce []byte, command string) (rndcTable, error) {
+	now := time.Now().Unix()
+	ctrl := rndcTable{
+		{"_ser", []byte(strconv.Itoa(serial))},
+		{"_tim", []byte(strconv.FormatInt(now, 10))},
+		{"_exp", []byte(strconv.FormatInt(now+60, 10))},
+	}
+	if nonce != nil {
+		ctrl = append(ctrl, rndcEntry{"_nonce", nonce})
+	}
+
+	msg := rndcTable{
+		{"_auth", rndcTable{}},
+		{"_ctrl", ctrl},
+		{"_data", rndcTable{{"type", []byte(command)}}},
+	}
+	msg[0].value = r.sign(msg)
+
+	body := msg.serialize(false)
+	header := make([]byte, 8)
+	binary.BigEndian.PutUint32(header, uint32(len(body)+4))
+	binary.BigEndian.PutUint32(header[4:], 1)
+	if _, err := conn.Write(append(header, body...)); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header)
+	if length < 4 || length > 1<<24 {
+		return nil, fmt.Errorf("invalid rndc response length %d", length)
+	}
+	data := make([]byte, length-4)
+	if _, err := io.ReadFull(conn, data); err != nil {
+		return nil, err
+	}
+
+	resp, err := parseRNDCTable(data)
+	if err != nil {
+		return nil, err
+	}
+	if !r.verify(resp) {
+		return nil, errRNDCBadSignature
+	}
+	return resp, nil
+}
+
+// RNDCError is an rndc command that named ran and reported as failed
+type RNDCError struct {
+	// Command sent, such as "reload example.com"
+	Command string
+	// Error message of named, with the text of the response if any
+	Message string
+}
+
+// Error formats the error as "rndc <command> failed: <message>"
+func (e *RNDCError) Error() string {
+	return fmt.Sprintf("rndc %s failed: %s", e.Command, e.Message)
+}
+
+// errRNDCBadSignature is returned for rndc responses not signed with the
+// configured key
+var errRNDCBadSignature = errors.New("rndc response has a bad signature, check the rndc key")
+
+// command runs an rndc command such as "reload example.com" and returns its
+// output text
+func (r *rndcClient) command(ctx context.Context, command string) (result string, err error) {
+	ctx, span := startSpan(ctx, "rndc "+strings.Fields(command)[0],
+		attribute.String("rndc.command", command),
+	)
+	defer func() { endSpan(span, err) }()
+
+	dial := r.dial
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: r.timeout}).DialContext
+	}
+	conn, err := dial(ctx, "tcp", r.server)
+	if err != nil {
+		return "", fmt.Errorf("rndc connection to %s failed: %w", r.server, err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else if r.timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(r.timeout))
+	}
+
+	// The server hands out a nonce in response to a null command, which
+	// must accompany the real command
+	serial := rand.Intn(1 << 24)
+	hello, err := r.roundTrip(conn, serial, nil, "null")
+	if err != nil {
+		return "", fmt.Errorf("rndc handshake with %s failed: %w", r.server, err)
+	}
+	nonce, _ := hello.getTable("_ctrl").get("_nonce").([]byte)
+
+	resp, err := r.roundTrip(conn, serial+1, nonce, command)
+	if err != nil {
+		return "", fmt.Errorf("rndc %s failed: %w", command, err)
+	}
+
+	data := resp.getTable("_data")
+	if res := data.getString("result"); res != "" && res != "0" {
+		msg := data.getString("err")
+		if text := data.getString("text"); text != "" {
+			msg += ": " + text
+		}
+		return "", &RNDCError{Command: command, Message: msg}
+	}
+
+	return data.getString("text"), nil
+}
@@ -0,0 +1,241 @@
+// SSH jump host tunneling
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// sshTunnelConfig describes how to reach the jump host
+type sshTunnelConfig struct {
+	host           string
+	user           string
+	privateKey     string
+	privateKeyFile string
+	hostKey        string
+	knownHostsFile string
+	timeout        time.Duration
+}
+
+// sshTunnel forwards TCP connections to the BIND9 servers through an SSH
+// jump host, as `ssh -J` does. The SSH connection is opened on first use and
+// reopened if it drops.
+type sshTunnel struct {
+	addr   string
+	config *ssh.ClientConfig
+
+	// host:port destinations reached through the tunnel; others are dialed
+	// directly
+	targets map[string]bool
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// newSSHTunnel prepares a tunnel through the jump host. No connection is
+// made until the first dial.
+func newSSHTunnel(cfg sshTunnelConfig) (*sshTunnel, error) {
+	addr := cfg.host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(strings.Trim(addr, "[]"), "22")
+	}
+
+	auth, err := sshAuthMethod(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := sshHostKeyCallback(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sshTunnel{
+		addr: addr,
+		config: &ssh.ClientConfig{
+			User:            cfg.user,
+			Auth:            []ssh.AuthMethod{auth},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         cfg.timeout,
+		},
+		targets: make(map[string]bool),
+	}, nil
+}
+
+// sshAuthMethod authenticates with the configured private key, or with the
+// keys of the running ssh-agent
+func sshAuthMethod(cfg sshTunnelConfig) (ssh.AuthMethod, error) {
+	pemBytes := []byte(cfg.privateKey)
+	if cfg.privateKey == "" && cfg.privateKeyFile != "" {
+		data, err := os.ReadFile(expandHome(cfg.privateKeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("could not read SSH private key: %w", err)
+		}
+		pemBytes = data
+	}
+
+	if len(pemBytes) > 0 {
+		signer, err := ssh.ParsePrivateKey(pemBytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH private key: %w", err)
+		}
+		return ssh.PublicKeys(signer), nil
+	}
+
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, fmt.Errorf("no SSH private key given and SSH_AUTH_SOCK is not set; set private_key or private_key_file, or start ssh-agent")
+	}
+	return ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			return nil, fmt.Errorf("could not connect to ssh-agent: %w", err)
+		}
+		defer conn.Close()
+		return agent.NewClient(conn).Signers()
+	}), nil
+}
+
+// sshHostKeyCallback verifies the jump host against the configured host key,
+// or against a known_hosts file
+func sshHostKeyCallback(cfg sshTunnelConfig) (ssh.HostKeyCallback, error) {
+	if cfg.hostKey != "" {
+		key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(cfg.hostKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SSH host key: %w", err)
+		}
+		return ssh.FixedHostKey(key), nil
+	}
+
+	file := cfg.knownHostsFile
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("no SSH host key given and the home directory is unknown: %w", err)
+		}
+		file = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	callback, err := knownhosts.New(expandHome(file))
+	if err != nil {
+		return nil, fmt.Errorf("could not load known hosts to verify the jump host; set host_key or known_hosts_file: %w", err)
+	}
+	return callback, nil
+}
+
+// expandHome expands a leading ~ in a path to the home directory
+func expandHome(p string) string {
+	if p != "~" && !strings.HasPrefix(p, "~/") {
+		return p
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return p
+	}
+	return filepath.Join(home, strings.TrimPrefix(p, "~"))
+}
+
+// route sends HTTP connections to the host of a URL through the tunnel
+func (t *sshTunnel) route(rawURL string) {
+	if addr := urlHostPort(rawURL); addr != "" {
+		t.targets[strings.ToLower(addr)] = true
+	}
+}
+
+// connect returns the SSH connection to the jump host, opening it if needed
+func (t *sshTunnel) connect(ctx context.Context) (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	dialer := &net.Dialer{Timeout: t.config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return nil, fmt.Errorf("SSH connection to jump host %s failed: %w", t.addr, err)
+	}
+
+	// Bound the handshake, which does not observe the context
+	if t.config.Timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(t.config.Timeout))
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, t.addr, t.config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("SSH handshake with jump host %s failed: %w", t.addr, err)
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	client := ssh.NewClient(c, chans, reqs)
+	t.client = client
+
+	// Forget the connection once it drops, so the next dial reopens it
+	go func() {
+		_ = client.Wait()
+		t.mu.Lock()
+		if t.client == client {
+			t.client = nil
+		}
+		t.mu.Unlock()
+	}()
+
+	return client, nil
+}
+
+// DialContext opens a TCP connection to addr through the jump host
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		conn, err := client.Dial(network, addr)
+		done <- result{conn, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return nil, fmt.Errorf("connection to %s through jump host %s failed: %w", addr, t.addr, r.err)
+		}
+		return r.conn, nil
+	case <-ctx.Done():
+		// Close the connection if it is opened after all
+		go func() {
+			if r := <-done; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// wrap returns a dial function sending connections to the tunnel's targets
+// through the jump host, and others to next
+func (t *sshTunnel) wrap(next func(ctx context.Context, network, addr string) (net.Conn, error)) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if t.targets[strings.ToLower(addr)] {
+			return t.DialContext(ctx, network, addr)
+		}
+		return next(ctx, network, addr)
+	}
+}
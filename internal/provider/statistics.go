@@ -0,0 +1,169 @@
+// BIND9 statistics channel client
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ServerStats holds the server-wide counters of the statistics channel
+type ServerStats struct {
+	Version     string           `json:"version"`
+	BootTime    string           `json:"boot-time"`
+	ConfigTime  string           `json:"config-time"`
+	CurrentTime string           `json:"current-time"`
+	Opcodes     map[string]int64 `json:"opcodes"`
+	Rcodes      map[string]int64 `json:"rcodes"`
+	QTypes      map[string]int64 `json:"qtypes"`
+	NSStats     map[string]int64 `json:"nsstats"`
+	Views       map[string]struct {
+		Resolver struct {
+			Cache      map[string]int64 `json:"cache"`
+			CacheStats map[string]int64 `json:"cachestats"`
+		} `json:"resolver"`
+	} `json:"views"`
+}
+
+// ZoneStats holds the per-zone counters of the statistics channel. Query
+// counters are only present for zones with zone-statistics enabled.
+type ZoneStats struct {
+	View   string           `json:"-"`
+	Name   string           `json:"name"`
+	Class  string           `json:"class"`
+	Serial int64            `json:"serial"`
+	Type   string           `json:"type"`
+	Loaded string           `json:"loaded"`
+	Rcodes map[string]int64 `json:"rcodes"`
+	QTypes map[string]int64 `json:"qtypes"`
+}
+
+// zoneStatsResponse is the document served at /json/v1/zones
+type zoneStatsResponse struct {
+	Views map[string]struct {
+		Zones []ZoneStats `json:"zones"`
+	} `json:"views"`
+}
+
+// errNoStatisticsURL is returned for statistics reads by a provider
+// configured without a statistics_url
+var errNoStatisticsURL = errors.New("this operation requires the BIND9 statistics channel, but no statistics_url is configured")
+
+// getStatistics fetches a document from the statistics channel
+func (c *Client) getStatistics(ctx context.Context, path string, v interface{}) (err error) {
+	if c.statisticsURL == "" {
+		return errNoStatisticsURL
+	}
+
+	ctx, span := startSpan(ctx, "HTTP GET",
+		attribute.String("http.request.method", "GET"),
+		attribute.String("url.path", path),
+	)
+	defer func() { endSpan(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.statisticsURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	release, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("statistics channel error %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+// GetServerStats retrieves server-wide query, response and cache counters
+func (c *Client) GetServerStats(ctx context.Context) (*ServerStats, error) {
+	var stats ServerStats
+	if err := c.getStatistics(ctx, "/json/v1/server", &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetZoneStats retrieves the counters of a zone in a view. An empty view
+// matches the first view, in name order, containing the zone.
+func (c *Client) GetZoneStats(ctx context.Context, zone, view string) (*ZoneStats, error) {
+	zones, err := c.ListZoneStats(ctx, view)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSuffix(zone, ".")
+	for i := range zones {
+		if strings.EqualFold(zones[i].Name, name) {
+			return &zones[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("zone %s not found in statistics channel", zone)
+}
+
+// ListZoneStats retrieves the counters of every zone in a view, or in all
+// views if view is empty, ordered by view name
+func (c *Client) ListZoneStats(ctx context.Context, view string) ([]ZoneStats, error) {
+	var doc zoneStatsResponse
+	if err := c.getStatistics(ctx, "/json/v1/zones", &doc); err != nil {
+		return nil, err
+	}
+
+	views := make([]string, 0, len(doc.Views))
+	for viewName := range doc.Views {
+		if view == "" || viewName == view {
+			views = append(views, viewName)
+		}
+	}
+	sort.Strings(views)
+
+	var zones []ZoneStats
+	for _, viewName := range views {
+		for _, z := range doc.Views[viewName].Zones {
+			z.View = viewName
+			zones = append(zones, z)
+		}
+	}
+	return zones, nil
+}
+
+// queryCount returns the number of queries the zone received, the sum of
+// its per-type query counters
+func (z *ZoneStats) queryCount() int64 {
+	var total int64
+	for _, n := range z.QTypes {
+		total += n
+	}
+	return total
+}
+
+// transferCount returns the number of zone transfers (AXFR and IXFR) the
+// server completed for the zone
+func (z *ZoneStats) transferCount() int64 {
+	return z.Rcodes["XfrReqDone"]
+}
@@ -0,0 +1,103 @@
+// OpenTelemetry tracing for resource operations and API calls
+
+package provider
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/harutyundermenjyan/terraform-provider-bind9"
+
+// tracerProvider is set when tracing is enabled, so spans can be flushed on shutdown
+var tracerProvider *sdktrace.TracerProvider
+
+// InitTracing enables OTLP trace export when an OTLP endpoint is configured
+// with the standard OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables. Other OTEL_*
+// variables (headers, sampler, service name) are honored by the SDK.
+// Tracing stays disabled, at no cost, when neither variable is set.
+func InitTracing(ctx context.Context, version string) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		log.Printf("[WARN] Could not create OTLP trace exporter, tracing disabled: %s", err)
+		return
+	}
+
+	res, err := sdkresource.Merge(
+		sdkresource.Default(),
+		sdkresource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("terraform-provider-bind9"),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		res = sdkresource.Default()
+	}
+
+	tracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// shutdownTracing flushes pending spans to the exporter
+func shutdownTracing(ctx context.Context) {
+	if tracerProvider == nil {
+		return
+	}
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		log.Printf("[WARN] Could not flush traces: %s", err)
+	}
+}
+
+// startSpan starts a span and adds its trace and span IDs to the log fields of
+// the returned context, so provider logs can be matched with traces
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+
+	if sc := span.SpanContext(); sc.IsValid() {
+		ctx = tflog.SetField(ctx, "trace_id", sc.TraceID().String())
+		ctx = tflog.SetField(ctx, "span_id", sc.SpanID().String())
+	}
+
+	return ctx, span
+}
+
+// endSpan records err on the span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// endOperationSpan ends the span of a resource or data source operation,
+// marking it as failed if the operation reported errors
+func endOperationSpan(span trace.Span, diags *diag.Diagnostics) {
+	if diags.HasError() {
+		span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+	}
+	span.End()
+}
@@ -0,0 +1,221 @@
+// Atomic record transactions spanning several resources
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/miekg/dns"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// transactionWindow is how long a zone's transaction stays open for the
+// record changes of other resources applied at the same time
+const transactionWindow = 250 * time.Millisecond
+
+// FeatureTransactions is the API feature providing atomic record transactions
+const FeatureTransactions = "transactions"
+
+// RecordChange is a single record addition or removal in a transaction
+type RecordChange struct {
+	Action      string                 `json:"action"`
+	RecordType  string                 `json:"record_type"`
+	Name        string                 `json:"name"`
+	TTL         int                    `json:"ttl,omitempty"`
+	RecordClass string                 `json:"record_class,omitempty"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+	RData       string                 `json:"rdata,omitempty"`
+
+	// ETag the RRset must still have, as If-Match does for single requests
+	IfMatch string `json:"if_match,omitempty"`
+	// Fail the transaction if the RRset no longer exists
+	RequireRRset bool `json:"require_rrset,omitempty"`
+}
+
+// Record change actions
+const (
+	recordChangeCreate = "create"
+	recordChangeDelete = "delete"
+)
+
+// RecordTransaction is the request body of an atomic record transaction
+type RecordTransaction struct {
+	Changes []RecordChange `json:"changes"`
+}
+
+// ApplyRecordTransaction applies record changes to a zone atomically: either
+// all of them are committed, or none is
+func (c *Client) ApplyRecordTransaction(ctx context.Context, zone string, changes []RecordChange) error {
+	if c.recordCache != nil {
+		defer c.recordCache.invalidate(ctx, zone)
+	}
+
+	for i := range changes {
+		changes[i].RData = writeRdata(changes[i].RecordType, changes[i].RData)
+	}
+
+	if c.dnsUpdate != nil {
+		release, err := c.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return c.dnsUpdate.applyChanges(ctx, zone, changes)
+	}
+
+	path := "/api/v1/zones/" + url.PathEscape(zone) + "/records/transaction"
+
+	resp, err := c.doRequest(ctx, "POST", path, &RecordTransaction{Changes: changes})
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(resp, nil)
+}
+
+// applyChanges sends record changes as a single DNS UPDATE, which the server
+// applies atomically. RRsets that must still exist become prerequisites.
+func (u *dnsUpdater) applyChanges(ctx context.Context, zone string, changes []RecordChange) error {
+	m := new(dns.Msg)
+	m.SetUpdate(dns.Fqdn(zone))
+
+	required := make(map[string]bool)
+	for _, change := range changes {
+		class := change.RecordClass
+		if class == "" {
+			class = "IN"
+		}
+		m.Question[0].Qclass = classCode(class)
+
+		rrtype, ok := dns.StringToType[strings.ToUpper(change.RecordType)]
+		if !ok {
+			return fmt.Errorf("unknown record type %s", change.RecordType)
+		}
+		hdr := dns.RR_Header{Name: ownerName(zone, change.Name), Rrtype: rrtype, Class: classCode(class)}
+
+		if change.RequireRRset {
+			key := strings.ToLower(hdr.Name) + "/" + change.RecordType
+			if !required[key] {
+				required[key] = true
+				m.RRsetUsed([]dns.RR{&dns.ANY{Hdr: hdr}})
+			}
+		}
+
+		switch {
+		case change.Action == recordChangeDelete && change.RData == "":
+			m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: hdr}})
+		case change.Action == recordChangeDelete:
+			rr, err := parseRR(zone, change.Name, 0, class, change.RecordType, change.RData)
+			if err != nil {
+				return err
+			}
+			m.Remove([]dns.RR{rr})
+		default:
+			rr, err := parseRR(zone, change.Name, int64(change.TTL), class, change.RecordType, change.RData)
+			if err != nil {
+				return err
+			}
+			m.Insert([]dns.RR{rr})
+		}
+	}
+
+	// Large transactions do not fit in a UDP message
+	transport := "udp"
+	if m.Len() > dns.MinMsgSize {
+		transport = "tcp"
+	}
+	_, err := u.exchange(ctx, m, transport)
+	return err
+}
+
+// recordTransactions collects the record changes that concurrently applied
+// resources make to a zone, and commits them as one transaction
+// transactionWindow after the first change. Every resource in the
+// transaction then succeeds or fails together. The transaction covers the
+// changes made within the window, not the whole apply.
+type recordTransactions struct {
+	client *Client
+
+	mu    sync.Mutex
+	zones map[string]*recordBatch
+}
+
+// recordBatch is an open transaction of a zone. err is set once done is
+// closed.
+type recordBatch struct {
+	zone    string
+	changes []RecordChange
+	members int
+	done    chan struct{}
+	err     error
+}
+
+// newRecordTransactions creates the transaction collector of a client
+func newRecordTransactions(client *Client) *recordTransactions {
+	return &recordTransactions{
+		client: client,
+		zones:  make(map[string]*recordBatch),
+	}
+}
+
+// submit adds the changes of one resource to the zone's open transaction and
+// waits for the transaction to be committed. It waits even once ctx is
+// cancelled, since the commit may still apply the changes, and returns the
+// commit's result.
+func (rt *recordTransactions) submit(ctx context.Context, zone string, changes []RecordChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	rt.mu.Lock()
+	key := viewCacheKey(ctx, zone)
+	b, ok := rt.zones[key]
+	if !ok {
+		b = &recordBatch{zone: zone, done: make(chan struct{})}
+		rt.zones[key] = b
+
+		// The commit outlives the resource that opened the transaction
+		commitCtx := withETag(context.WithoutCancel(ctx), nil)
+		time.AfterFunc(transactionWindow, func() { rt.commit(commitCtx, key, b) })
+	}
+	b.changes = append(b.changes, changes...)
+	b.members++
+	rt.mu.Unlock()
+
+	<-b.done
+	return b.err
+}
+
+// commit closes a zone's transaction and applies its changes
+func (rt *recordTransactions) commit(ctx context.Context, key string, b *recordBatch) {
+	rt.mu.Lock()
+	if rt.zones[key] == b {
+		delete(rt.zones, key)
+	}
+	rt.mu.Unlock()
+
+	ctx, span := startSpan(ctx, "record transaction",
+		attribute.String("bind9.zone", b.zone),
+		attribute.Int("bind9.transaction.changes", len(b.changes)),
+		attribute.Int("bind9.transaction.resources", b.members),
+	)
+
+	tflog.Debug(ctx, "Committing record transaction", map[string]any{
+		"zone":      b.zone,
+		"changes":   len(b.changes),
+		"resources": b.members,
+	})
+
+	b.err = rt.client.ApplyRecordTransaction(ctx, b.zone, b.changes)
+	if b.err != nil && b.members > 1 {
+		b.err = fmt.Errorf("transaction of %d resources in zone %s was not applied: %w", b.members, b.zone, b.err)
+	}
+	endSpan(span, b.err)
+	close(b.done)
+}
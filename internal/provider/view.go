@@ -0,0 +1,88 @@
+// Views: the same zone name served in several views of a server
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type viewContextKey struct{}
+
+// withView returns a context whose API requests address the zones of a
+// view. An empty view addresses the server's default view.
+func withView(ctx context.Context, view string) context.Context {
+	return context.WithValue(ctx, viewContextKey{}, view)
+}
+
+// viewFromContext returns the view of a context, or ""
+func viewFromContext(ctx context.Context) string {
+	view, _ := ctx.Value(viewContextKey{}).(string)
+	return view
+}
+
+// viewQuery adds the view query parameter to a request path
+func viewQuery(path, view string) string {
+	if view == "" {
+		return path
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "view=" + url.QueryEscape(view)
+}
+
+// zoneID returns the resource ID of a zone, which is prefixed with its view
+// so the same zone name can be managed in several views
+func zoneID(view, name string) string {
+	if view == "" {
+		return name
+	}
+	return view + "/" + name
+}
+
+// recordViewAttribute is the schema of the view attribute of the record
+// resources
+func recordViewAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "View of the zone the records are in, as set on its bind9_zone. Default: the server's default view",
+		Optional:    true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// planView returns a context addressing the view planned for a record
+// resource, so that the checks made at plan time read the right zone. It
+// fails the plan when the server does not manage views.
+func planView(ctx context.Context, client *Client, req resource.ModifyPlanRequest, diags *diag.Diagnostics) context.Context {
+	var view types.String
+	diags.Append(req.Plan.GetAttribute(ctx, path.Root("view"), &view)...)
+	if diags.HasError() || view.IsNull() || view.IsUnknown() {
+		return ctx
+	}
+
+	if !client.SupportsFeature(FeatureViews) {
+		addCodedAttributeError(diags, path.Root("view"), ErrCodeFeatureUnsupported, "Views Not Supported",
+			fmt.Sprintf("The BIND9 REST API server (version %s) does not manage zones in views. Remove view to use the default view.",
+				client.ServerVersion()))
+	}
+	return withView(ctx, view.ValueString())
+}
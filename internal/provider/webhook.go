@@ -0,0 +1,93 @@
+// Zone change webhooks
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ZoneChangeEvent is the body POSTed to a zone's on_change_webhook
+type ZoneChangeEvent struct {
+	Zone      string   `json:"zone"`
+	View      string   `json:"view,omitempty"`
+	Serial    int64    `json:"serial"`
+	Action    string   `json:"action"`
+	Changes   []string `json:"changes,omitempty"`
+	Timestamp string   `json:"timestamp"`
+}
+
+// NotifyZoneChange POSTs a zone change event to a webhook URL. Any 2xx
+// response counts as delivered.
+func (c *Client) NotifyZoneChange(ctx context.Context, webhookURL string, event ZoneChangeEvent) (err error) {
+	ctx, span := startSpan(ctx, "webhook POST",
+		attribute.String("bind9.zone", event.Zone),
+		attribute.String("bind9.zone.action", event.Action),
+	)
+	defer func() { endSpan(span, err) }()
+
+	event.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	return nil
+}
+
+// changedAttributes lists the top-level attributes whose planned value
+// differs from the prior state, ignoring values only known after apply
+func changedAttributes(state, plan tftypes.Value) []string {
+	diffs, err := state.Diff(plan)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for _, d := range diffs {
+		steps := d.Path.Steps()
+		if len(steps) == 0 {
+			continue
+		}
+		name, ok := steps[0].(tftypes.AttributeName)
+		if !ok || (d.Value2 != nil && !d.Value2.IsFullyKnown()) {
+			continue
+		}
+		seen[string(name)] = true
+	}
+
+	changes := make([]string, 0, len(seen))
+	for name := range seen {
+		changes = append(changes, name)
+	}
+	sort.Strings(changes)
+	return changes
+}
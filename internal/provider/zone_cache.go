@@ -0,0 +1,91 @@
+// Zone listing cache for the duration of a Terraform operation
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// zoneListCache keeps zone listings for the life of the provider process, one
+// plan or apply, so many bind9_zones data sources and zone checks share a
+// single request. Zone changes made through the client clear it.
+type zoneListCache struct {
+	mu    sync.Mutex
+	lists map[string]*zoneListEntry
+}
+
+// zoneListEntry holds a listing once done is closed
+type zoneListEntry struct {
+	done  chan struct{}
+	zones []Zone
+	err   error
+}
+
+// newZoneListCache creates an empty zone listing cache
+func newZoneListCache() *zoneListCache {
+	return &zoneListCache{lists: make(map[string]*zoneListEntry)}
+}
+
+// get returns the cached listing for a query in the view of ctx, calling
+// fetch once for concurrent callers. Failed fetches are not cached.
+func (zc *zoneListCache) get(ctx context.Context, query url.Values, fetch func() ([]Zone, error)) ([]Zone, error) {
+	key := zoneID(viewFromContext(ctx), query.Encode())
+
+	zc.mu.Lock()
+	e, ok := zc.lists[key]
+	if !ok {
+		e = &zoneListEntry{done: make(chan struct{})}
+		zc.lists[key] = e
+	}
+	zc.mu.Unlock()
+
+	if ok {
+		select {
+		case <-e.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	} else {
+		e.zones, e.err = fetch()
+		if e.err != nil {
+			zc.mu.Lock()
+			if zc.lists[key] == e {
+				delete(zc.lists, key)
+			}
+			zc.mu.Unlock()
+		}
+		close(e.done)
+	}
+
+	if e.err != nil {
+		return nil, e.err
+	}
+	// Callers may modify the slice they get
+	return append([]Zone(nil), e.zones...), nil
+}
+
+// invalidate drops all cached listings after a zone was changed
+func (zc *zoneListCache) invalidate() {
+	zc.mu.Lock()
+	zc.lists = make(map[string]*zoneListEntry)
+	zc.mu.Unlock()
+}
+
+// lookupZone returns a zone for existence and class checks, from the cached
+// listing of all zones when it has the zone and its class, or from the API
+// otherwise
+func (c *Client) lookupZone(ctx context.Context, name string) (*Zone, error) {
+	zones, err := c.ListZones(ctx, nil)
+	if err == nil {
+		want := strings.TrimSuffix(name, ".")
+		for i := range zones {
+			if zones[i].Class != "" && strings.EqualFold(strings.TrimSuffix(zones[i].Name, "."), want) {
+				return &zones[i], nil
+			}
+		}
+	}
+	return c.GetZone(ctx, name)
+}
@@ -0,0 +1,163 @@
+// Protection of the records still present in a zone when it is destroyed
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/miekg/dns"
+)
+
+// maxListedRecords is the number of records named in the error refusing to
+// destroy a zone
+const maxListedRecords = 10
+
+// serverMaintainedTypes are the record types BIND9 maintains in a signed
+// zone itself
+var serverMaintainedTypes = map[string]bool{
+	"RRSIG":      true,
+	"NSEC":       true,
+	"NSEC3":      true,
+	"NSEC3PARAM": true,
+	"DNSKEY":     true,
+	"CDS":        true,
+	"CDNSKEY":    true,
+	"TYPE65534":  true,
+}
+
+// zoneReplacePrivateKey is the private state key marking the plan of a zone
+// that replaces it
+const zoneReplacePrivateKey = "zone_replace"
+
+// markZoneReplacement records in private state whether the plan of a zone
+// with prior state replaces it: whether ModifyPlan required the replacement
+// or one of attributes changes. Terraform plans the new zone of a
+// replacement, and deletes the old one, with the private state of this
+// plan. Destroy plans clear the mark.
+func markZoneReplacement(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributes ...string) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	replace := !req.Plan.Raw.IsNull() && len(resp.RequiresReplace) > 0
+	for _, name := range attributes {
+		if replace || req.Plan.Raw.IsNull() {
+			break
+		}
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+		replace = !planned.Equal(prior)
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, zoneReplacePrivateKey, []byte(strconv.FormatBool(replace)))...)
+}
+
+// zoneReplaced reports whether the private state of a zone being deleted
+// marks it as replaced
+func zoneReplaced(ctx context.Context, p privateState) bool {
+	data, diags := p.GetKey(ctx, zoneReplacePrivateKey)
+	return !diags.HasError() && string(data) == "true"
+}
+
+// checkUnmanagedRecords fails the destroy of a master zone that still holds
+// records other than own, the records the zone resource created. Terraform
+// destroys the record resources that reference a zone before the zone, so
+// records left at this point were added by hand, are managed elsewhere, or
+// belong to resources that name the zone as a plain string.
+func checkUnmanagedRecords(ctx context.Context, client *Client, zone string, own map[string]bool, diags *diag.Diagnostics) {
+	records, err := client.ListRecords(ctx, zone, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return
+		}
+		addAPIError(diags, "Error Deleting Zone", "Could not list the zone's records to check for records still present", err)
+		return
+	}
+
+	remaining := unmanagedRecords(zone, records, own)
+	if len(remaining) == 0 {
+		return
+	}
+
+	listed := remaining
+	if len(listed) > maxListedRecords {
+		listed = listed[:maxListedRecords]
+	}
+	detail := fmt.Sprintf("Zone %s still holds %d record(s) besides those this resource created: %s",
+		zone, len(remaining), strings.Join(listed, ", "))
+	if len(remaining) > len(listed) {
+		detail += ", ..."
+	}
+	detail += ". Destroying the zone would delete them. Remove or import the records, or set force_destroy = true and apply before destroying the zone. " +
+		"Records of resources that name the zone as a plain string instead of referencing it are destroyed in the same run; destroy again once they are gone."
+	addCodedError(diags, ErrCodeConflict, "Zone Still Has Records", detail)
+}
+
+// zoneOwnRecords returns the keys of the records a zone resource created:
+// its SOA and apex NS records, the glue of its nameservers, and its initial
+// content
+func zoneOwnRecords(ctx context.Context, state *ZoneResourceModel) (map[string]bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	zone := state.Name.ValueString()
+	own := map[string]bool{
+		zoneRecordKey(zone, "@", "SOA"): true,
+		zoneRecordKey(zone, "@", "NS"):  true,
+	}
+
+	if !state.NSAddresses.IsNull() {
+		addresses := make(map[string]string)
+		diags.Append(state.NSAddresses.ElementsAs(ctx, &addresses, false)...)
+		for ns := range addresses {
+			own[zoneRecordKey(zone, ns, "A")] = true
+			own[zoneRecordKey(zone, ns, "AAAA")] = true
+		}
+	}
+
+	if !state.InitialRecords.IsNull() {
+		var records []BatchRecordModel
+		diags.Append(state.InitialRecords.ElementsAs(ctx, &records, false)...)
+		for _, rec := range records {
+			own[zoneRecordKey(zone, rec.Name.ValueString(), rec.Type.ValueString())] = true
+		}
+	}
+
+	if content := state.ZoneFileContent; !content.IsNull() && !content.IsUnknown() {
+		zp := dns.NewZoneParser(strings.NewReader(content.ValueString()), dns.Fqdn(zone), "")
+		for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+			own[zoneRecordKey(zone, rr.Header().Name, dns.TypeToString[rr.Header().Rrtype])] = true
+		}
+	}
+
+	return own, diags
+}
+
+// unmanagedRecords returns the records of a zone that are neither in own
+// nor maintained by the server, as sorted "name TYPE" strings
+func unmanagedRecords(zone string, records []Record, own map[string]bool) []string {
+	seen := make(map[string]bool)
+	var unmanaged []string
+	for _, rec := range records {
+		recordType := strings.ToUpper(rec.Type)
+		key := zoneRecordKey(zone, rec.Name, recordType)
+		if serverMaintainedTypes[recordType] || own[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		unmanaged = append(unmanaged, recordFQDN(zone, rec.Name)+" "+recordType)
+	}
+	sort.Strings(unmanaged)
+	return unmanaged
+}
+
+// zoneRecordKey identifies a record set of a zone by owner name and type
+func zoneRecordKey(zone, name, recordType string) string {
+	return strings.ToLower(recordFQDN(zone, name)) + "/" + strings.ToUpper(recordType)
+}
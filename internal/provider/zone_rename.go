@@ -0,0 +1,120 @@
+// Renaming zones in place, for servers whose API can rename a zone
+
+package provider
+
+import (
+	"context"
+	"net/url"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ZoneRenameRequest is the request body for renaming a zone
+type ZoneRenameRequest struct {
+	NewName string `json:"new_name"`
+}
+
+// RenameZone renames a zone, keeping its records and options
+func (c *Client) RenameZone(ctx context.Context, name, newName string) (*Zone, error) {
+	defer c.zoneLists.invalidate()
+	if c.freezer != nil {
+		defer c.freezer.forget(ctx, name)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/api/v1/zones/"+url.PathEscape(name)+"/rename", &ZoneRenameRequest{NewName: newName})
+	if err != nil {
+		return nil, err
+	}
+
+	var zone Zone
+	if err := c.parseResponse(resp, &zone); err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+// zoneRenames remembers the zone renames planned by bind9_zone resources in
+// this provider process. Terraform plans a zone before the records that
+// reference its name, so their plans can tell a record that moves with its
+// renamed zone from one moved to another zone.
+type zoneRenames struct {
+	mu sync.Mutex
+	// New zone name by old zone name
+	planned map[string]string
+}
+
+// newZoneRenames creates an empty registry of planned zone renames
+func newZoneRenames() *zoneRenames {
+	return &zoneRenames{planned: make(map[string]string)}
+}
+
+// plan records a planned rename of a zone
+func (z *zoneRenames) plan(oldName, newName string) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	z.planned[cacheKey(oldName)] = cacheKey(newName)
+}
+
+// renamed reports whether a rename of a zone from oldName to newName is
+// planned
+func (z *zoneRenames) renamed(oldName, newName string) bool {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	planned, ok := z.planned[cacheKey(oldName)]
+	return ok && planned == cacheKey(newName)
+}
+
+// zoneRequiresReplace returns a plan modifier that replaces a record
+// resource whose zone changes, unless the change follows a planned rename
+// of the zone, which takes the records along. The client is looked up when
+// planning, since schemas are built before the provider is configured.
+func zoneRequiresReplace(client func() *Client) planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			c := client()
+			resp.RequiresReplace = c == nil || !c.zoneRenames.renamed(req.StateValue.ValueString(), req.PlanValue.ValueString())
+		},
+		"Changing the zone replaces the resource, unless the zone is renamed in place.",
+		"Changing the zone replaces the resource, unless the zone is renamed in place.",
+	)
+}
+
+// useStateUnlessChanged returns a plan modifier that keeps the prior state
+// value of a computed attribute unless the attribute at other changes, as
+// an identifier derived from it does
+func useStateUnlessChanged(other path.Path) planmodifier.String {
+	return useStateUnlessChangedModifier{other: other}
+}
+
+type useStateUnlessChangedModifier struct {
+	other path.Path
+}
+
+func (m useStateUnlessChangedModifier) Description(ctx context.Context) string {
+	return "Keeps the prior state value unless " + m.other.String() + " changes."
+}
+
+func (m useStateUnlessChangedModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateUnlessChangedModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var prior, planned types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.other, &prior)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.other, &planned)...)
+	if resp.Diagnostics.HasError() || !prior.Equal(planned) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
@@ -0,0 +1,160 @@
+// Response policy zones (RPZ): zones listed in the server's response-policy
+// statement, whose records rewrite the answers of the recursive server
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/miekg/dns"
+)
+
+// ZoneResponsePolicy is the entry of a zone in the server's response-policy
+// statement
+type ZoneResponsePolicy struct {
+	Policy        string `json:"policy,omitempty"`
+	CNAME         string `json:"cname,omitempty"`
+	MaxPolicyTTL  int64  `json:"max_policy_ttl,omitempty"`
+	RecursiveOnly *bool  `json:"recursive_only,omitempty"`
+	Log           *bool  `json:"log,omitempty"`
+}
+
+// ResponsePolicyModel describes the response policy of a zone
+type ResponsePolicyModel struct {
+	Policy        types.String `tfsdk:"policy"`
+	CNAME         types.String `tfsdk:"cname"`
+	MaxPolicyTTL  types.Int64  `tfsdk:"max_policy_ttl"`
+	RecursiveOnly types.Bool   `tfsdk:"recursive_only"`
+	Log           types.Bool   `tfsdk:"log"`
+}
+
+// responsePolicyAttribute is the schema of the response_policy attribute of
+// bind9_zone
+func responsePolicyAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Lists the zone in the server's response-policy statement, making it a response policy zone (RPZ) " +
+			"whose records rewrite the answers of the recursive server. Only for master and slave zones.",
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"policy": schema.StringAttribute{
+				Description: "Overrides the action of the zone's rules: given (the action of each rule), disabled (log only), " +
+					"passthru, drop, tcp-only, nxdomain, nodata, or cname. Default: given",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("given", "disabled", "passthru", "drop", "tcp-only", "nxdomain", "nodata", "cname"),
+				},
+			},
+			"cname": schema.StringAttribute{
+				Description: "Domain name every rule answers with a CNAME to. Required when policy is cname, and not allowed otherwise",
+				Optional:    true,
+			},
+			"max_policy_ttl": schema.Int64Attribute{
+				Description: "Largest TTL in seconds of the rewritten answers. Default: the server's setting (5 seconds)",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"recursive_only": schema.BoolAttribute{
+				Description: "Only rewrite answers to recursive queries. Default: the server's setting (true)",
+				Optional:    true,
+			},
+			"log": schema.BoolAttribute{
+				Description: "Log the rewrites made by the zone's rules. Default: true",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// responsePolicy converts the response policy of a zone resource model for
+// the API. A null object returns nil.
+func responsePolicy(ctx context.Context, object types.Object) (*ZoneResponsePolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if object.IsNull() || object.IsUnknown() {
+		return nil, diags
+	}
+
+	var model ResponsePolicyModel
+	diags.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	policy := &ZoneResponsePolicy{
+		Policy:       model.Policy.ValueString(),
+		CNAME:        model.CNAME.ValueString(),
+		MaxPolicyTTL: model.MaxPolicyTTL.ValueInt64(),
+	}
+	if !model.RecursiveOnly.IsNull() {
+		recursiveOnly := model.RecursiveOnly.ValueBool()
+		policy.RecursiveOnly = &recursiveOnly
+	}
+	if !model.Log.IsNull() {
+		log := model.Log.ValueBool()
+		policy.Log = &log
+	}
+	return policy, diags
+}
+
+// checkResponsePolicy validates the response policy of a zone. Only zones
+// with data of their own hold policy rules, and the cname attribute goes
+// with the cname policy alone.
+func (r *ZoneResource) checkResponsePolicy(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var zoneType types.String
+	var object types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("type"), &zoneType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("response_policy"), &object)...)
+	if resp.Diagnostics.HasError() || object.IsNull() || object.IsUnknown() {
+		return
+	}
+
+	if !r.client.SupportsFeature(FeatureResponsePolicy) {
+		addCodedAttributeError(&resp.Diagnostics, path.Root("response_policy"), ErrCodeFeatureUnsupported, "Response Policy Not Supported",
+			fmt.Sprintf("The BIND9 REST API server (version %s) cannot change the server's response-policy statement. "+
+				"Remove response_policy, and list the zone in named.conf instead.", r.client.ServerVersion()))
+		return
+	}
+
+	if !zoneType.IsUnknown() {
+		switch strings.ToLower(zoneType.ValueString()) {
+		case "master", "primary", "slave", "secondary":
+		default:
+			addCodedAttributeError(&resp.Diagnostics, path.Root("response_policy"), ErrCodeConfigInvalid, "Unexpected Response Policy",
+				fmt.Sprintf("Zones of type %q hold no policy rules. Remove response_policy, or change type to master or slave.", zoneType.ValueString()))
+			return
+		}
+	}
+
+	var model ResponsePolicyModel
+	resp.Diagnostics.Append(object.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || model.Policy.IsUnknown() || model.CNAME.IsUnknown() {
+		return
+	}
+
+	cnamePath := path.Root("response_policy").AtName("cname")
+	switch {
+	case model.Policy.ValueString() == "cname" && model.CNAME.IsNull():
+		addCodedAttributeError(&resp.Diagnostics, cnamePath, ErrCodeConfigInvalid, "Missing CNAME Target",
+			`The cname policy answers every rule with a CNAME. Set cname to the target domain, such as "walled-garden.example.com".`)
+	case model.Policy.ValueString() != "cname" && !model.CNAME.IsNull():
+		addCodedAttributeError(&resp.Diagnostics, cnamePath, ErrCodeConfigInvalid, "Unexpected CNAME Target",
+			`cname only applies to the cname policy. Remove cname, or set policy to "cname".`)
+	case !model.CNAME.IsNull():
+		if _, ok := dns.IsDomainName(model.CNAME.ValueString()); !ok || model.CNAME.ValueString() == "" {
+			addCodedAttributeError(&resp.Diagnostics, cnamePath, ErrCodeConfigInvalid, "Invalid CNAME Target",
+				fmt.Sprintf("%q is not a domain name.", model.CNAME.ValueString()))
+		}
+	}
+}
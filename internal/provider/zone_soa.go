@@ -0,0 +1,205 @@
+// Reading back and updating the SOA record and serial of a zone
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ZoneSOA holds the fields of a zone's SOA record other than its serial
+type ZoneSOA struct {
+	Mname   string
+	Rname   string
+	Refresh int64
+	Retry   int64
+	Expire  int64
+	Minimum int64
+}
+
+// GetZoneSOA reads the SOA record of a zone. It returns nil for a zone that
+// has none, such as one that is not loaded.
+func (c *Client) GetZoneSOA(ctx context.Context, zone string) (*ZoneSOA, error) {
+	records, err := c.GetRecords(ctx, zone, "SOA", "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rec := range records {
+		if !strings.EqualFold(rec.Type, "SOA") {
+			continue
+		}
+		return parseZoneSOA(zone, rec.RData)
+	}
+
+	return nil, nil
+}
+
+// parseZoneSOA parses SOA rdata into fully qualified names and timers
+func parseZoneSOA(zone, rdata string) (*ZoneSOA, error) {
+	fields := strings.Fields(rdata)
+	if len(fields) != 7 {
+		return nil, fmt.Errorf("invalid SOA record data %q in zone %s", rdata, zone)
+	}
+
+	timers := make([]int64, 4)
+	for i, field := range fields[3:] {
+		value, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SOA record data %q in zone %s: %w", rdata, zone, err)
+		}
+		timers[i] = value
+	}
+
+	return &ZoneSOA{
+		Mname:   recordFQDN(zone, fields[0]),
+		Rname:   recordFQDN(zone, fields[1]),
+		Refresh: timers[0],
+		Retry:   timers[1],
+		Expire:  timers[2],
+		Minimum: timers[3],
+	}, nil
+}
+
+// zoneSOAModel points at the SOA attributes of a zone resource model
+type zoneSOAModel struct {
+	Mname   *types.String
+	Rname   *types.String
+	Refresh *types.Int64
+	Retry   *types.Int64
+	Expire  *types.Int64
+	Minimum *types.Int64
+}
+
+// readZoneSOA sets the SOA attributes of a model from the zone's SOA record,
+// so that changes made outside Terraform show up as drift. Names are kept
+// in the form they were configured in while they name the same host.
+func readZoneSOA(ctx context.Context, client *Client, zone string, model zoneSOAModel, diags *diag.Diagnostics) {
+	soa, err := client.GetZoneSOA(ctx, zone)
+	if err != nil {
+		addAPIError(diags, "Error Reading Zone", "Could not read the zone's SOA record", err)
+		return
+	}
+	if soa == nil {
+		return
+	}
+
+	if model.Mname.IsNull() || !sameSOAName(zone, model.Mname.ValueString(), soa.Mname) {
+		*model.Mname = types.StringValue(relativeSOAName(zone, soa.Mname))
+	}
+	if model.Rname.IsNull() || !sameSOAName(zone, model.Rname.ValueString(), soa.Rname) {
+		*model.Rname = types.StringValue(relativeSOAName(zone, soa.Rname))
+	}
+	*model.Refresh = types.Int64Value(soa.Refresh)
+	*model.Retry = types.Int64Value(soa.Retry)
+	*model.Expire = types.Int64Value(soa.Expire)
+	*model.Minimum = types.Int64Value(soa.Minimum)
+}
+
+// sameSOAName reports whether a configured SOA name is the fully qualified
+// name read from the server. A configured name with dots but no trailing
+// dot is taken as either relative to the zone or fully qualified, and an
+// RNAME may be written as an email address.
+func sameSOAName(zone, configured, fqdn string) bool {
+	if strings.LastIndex(configured, "@") > 0 {
+		configured = mailboxToDname(configured)
+	}
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	return strings.EqualFold(recordFQDN(zone, configured), fqdn) ||
+		strings.EqualFold(strings.TrimSuffix(configured, "."), fqdn)
+}
+
+// relativeSOAName returns a fully qualified SOA name relative to the zone
+// when it is inside it, as the attribute defaults are written
+func relativeSOAName(zone, fqdn string) string {
+	zone = strings.TrimSuffix(zone, ".")
+	fqdn = strings.TrimSuffix(fqdn, ".")
+	if suffix := "." + zone; len(fqdn) > len(suffix) && strings.HasSuffix(strings.ToLower(fqdn), strings.ToLower(suffix)) {
+		return fqdn[:len(fqdn)-len(suffix)]
+	}
+	return fqdn
+}
+
+// soaUpdate adds the SOA attributes that differ between plan and state to a
+// zone update request, and reports whether there were any
+func soaUpdate(plan, state zoneSOAModel, update *ZoneUpdateRequest) bool {
+	changed := false
+	for _, name := range []struct {
+		plan, state *types.String
+		target      **string
+	}{
+		{plan.Mname, state.Mname, &update.SOAMname},
+		{plan.Rname, state.Rname, &update.SOARname},
+	} {
+		if name.plan.IsUnknown() || name.plan.Equal(*name.state) {
+			continue
+		}
+		value := name.plan.ValueString()
+		*name.target = &value
+		changed = true
+	}
+	for _, timer := range []struct {
+		plan, state *types.Int64
+		target      **int64
+	}{
+		{plan.Refresh, state.Refresh, &update.SOARefresh},
+		{plan.Retry, state.Retry, &update.SOARetry},
+		{plan.Expire, state.Expire, &update.SOAExpire},
+		{plan.Minimum, state.Minimum, &update.SOAMinimum},
+	} {
+		if timer.plan.IsUnknown() || timer.plan.Equal(*timer.state) {
+			continue
+		}
+		value := timer.plan.ValueInt64()
+		*timer.target = &value
+		changed = true
+	}
+	return changed
+}
+
+// zoneSerialAttributes are the attributes of bind9_zone whose change makes
+// the server bump the zone's serial
+var zoneSerialAttributes = []string{
+	"serial_format", "soa_mname", "soa_rname", "soa_refresh", "soa_retry", "soa_expire", "soa_minimum",
+}
+
+// planZoneSerial keeps the prior serial of a zone in the plan, unless one of
+// attributes changes and the apply bumps it. Records and dynamic updates
+// change the serial outside of the zone resource, so it is neither shown as
+// a diff of the zone nor refreshed by Read; the bind9_zone data source reads
+// the current serial. It runs after the rest of ModifyPlan, once defaults
+// and computed SOA names are in the plan.
+func planZoneSerial(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, attributes ...string) {
+	if req.State.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var serial types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("serial"), &serial)...)
+	if resp.Diagnostics.HasError() || serial.IsNull() {
+		return
+	}
+
+	for _, name := range attributes {
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planned.Equal(prior) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("serial"), types.Int64Unknown())...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("serial"), serial)...)
+}
@@ -35,7 +35,14 @@ func main() {
 		Debug:   debug,
 	}
 
+	// Export traces if an OTLP endpoint is configured
+	provider.InitTracing(context.Background(), version)
+
 	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Restore zone settings deferred until the end of the apply and flush traces
+	provider.Shutdown(context.Background())
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}